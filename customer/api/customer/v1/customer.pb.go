@@ -10,6 +10,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +24,374 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// CustomerStatus is where a customer is in its lifecycle. Customers are
+// created as prospects and move on by the transition RPCs only: prospect
+// to active, active to suspended and back, and any status to closed.
+type CustomerStatus int32
+
+const (
+	CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED CustomerStatus = 0
+	CustomerStatus_CUSTOMER_STATUS_PROSPECT    CustomerStatus = 1
+	CustomerStatus_CUSTOMER_STATUS_ACTIVE      CustomerStatus = 2
+	CustomerStatus_CUSTOMER_STATUS_SUSPENDED   CustomerStatus = 3
+	CustomerStatus_CUSTOMER_STATUS_CLOSED      CustomerStatus = 4
+)
+
+// Enum value maps for CustomerStatus.
+var (
+	CustomerStatus_name = map[int32]string{
+		0: "CUSTOMER_STATUS_UNSPECIFIED",
+		1: "CUSTOMER_STATUS_PROSPECT",
+		2: "CUSTOMER_STATUS_ACTIVE",
+		3: "CUSTOMER_STATUS_SUSPENDED",
+		4: "CUSTOMER_STATUS_CLOSED",
+	}
+	CustomerStatus_value = map[string]int32{
+		"CUSTOMER_STATUS_UNSPECIFIED": 0,
+		"CUSTOMER_STATUS_PROSPECT":    1,
+		"CUSTOMER_STATUS_ACTIVE":      2,
+		"CUSTOMER_STATUS_SUSPENDED":   3,
+		"CUSTOMER_STATUS_CLOSED":      4,
+	}
+)
+
+func (x CustomerStatus) Enum() *CustomerStatus {
+	p := new(CustomerStatus)
+	*p = x
+	return p
+}
+
+func (x CustomerStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomerStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[0].Descriptor()
+}
+
+func (CustomerStatus) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[0]
+}
+
+func (x CustomerStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomerStatus.Descriptor instead.
+func (CustomerStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{0}
+}
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CHANGE_TYPE_CREATED     ChangeType = 1
+	ChangeType_CHANGE_TYPE_UPDATED     ChangeType = 2
+	ChangeType_CHANGE_TYPE_DELETED     ChangeType = 3
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CHANGE_TYPE_CREATED",
+		2: "CHANGE_TYPE_UPDATED",
+		3: "CHANGE_TYPE_DELETED",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CHANGE_TYPE_CREATED":     1,
+		"CHANGE_TYPE_UPDATED":     2,
+		"CHANGE_TYPE_DELETED":     3,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[1].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[1]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{1}
+}
+
+type ImportRowStatus int32
+
+const (
+	ImportRowStatus_IMPORT_ROW_STATUS_UNSPECIFIED ImportRowStatus = 0
+	ImportRowStatus_IMPORT_ROW_STATUS_CREATED     ImportRowStatus = 1
+	ImportRowStatus_IMPORT_ROW_STATUS_FAILED      ImportRowStatus = 2
+	// dry run only: the row would have been created
+	ImportRowStatus_IMPORT_ROW_STATUS_VALID ImportRowStatus = 3
+)
+
+// Enum value maps for ImportRowStatus.
+var (
+	ImportRowStatus_name = map[int32]string{
+		0: "IMPORT_ROW_STATUS_UNSPECIFIED",
+		1: "IMPORT_ROW_STATUS_CREATED",
+		2: "IMPORT_ROW_STATUS_FAILED",
+		3: "IMPORT_ROW_STATUS_VALID",
+	}
+	ImportRowStatus_value = map[string]int32{
+		"IMPORT_ROW_STATUS_UNSPECIFIED": 0,
+		"IMPORT_ROW_STATUS_CREATED":     1,
+		"IMPORT_ROW_STATUS_FAILED":      2,
+		"IMPORT_ROW_STATUS_VALID":       3,
+	}
+)
+
+func (x ImportRowStatus) Enum() *ImportRowStatus {
+	p := new(ImportRowStatus)
+	*p = x
+	return p
+}
+
+func (x ImportRowStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportRowStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[2].Descriptor()
+}
+
+func (ImportRowStatus) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[2]
+}
+
+func (x ImportRowStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportRowStatus.Descriptor instead.
+func (ImportRowStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{2}
+}
+
+type ExportFormat int32
+
+const (
+	// defaults to CSV
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	ExportFormat_EXPORT_FORMAT_CSV         ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_NDJSON      ExportFormat = 2
+	ExportFormat_EXPORT_FORMAT_PARQUET     ExportFormat = 3
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_CSV",
+		2: "EXPORT_FORMAT_NDJSON",
+		3: "EXPORT_FORMAT_PARQUET",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_CSV":         1,
+		"EXPORT_FORMAT_NDJSON":      2,
+		"EXPORT_FORMAT_PARQUET":     3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[3].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[3]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{3}
+}
+
+type ErasureStatus int32
+
+const (
+	ErasureStatus_ERASURE_STATUS_UNSPECIFIED ErasureStatus = 0
+	ErasureStatus_ERASURE_STATUS_SCHEDULED   ErasureStatus = 1
+	ErasureStatus_ERASURE_STATUS_COMPLETED   ErasureStatus = 2
+	ErasureStatus_ERASURE_STATUS_CANCELLED   ErasureStatus = 3
+)
+
+// Enum value maps for ErasureStatus.
+var (
+	ErasureStatus_name = map[int32]string{
+		0: "ERASURE_STATUS_UNSPECIFIED",
+		1: "ERASURE_STATUS_SCHEDULED",
+		2: "ERASURE_STATUS_COMPLETED",
+		3: "ERASURE_STATUS_CANCELLED",
+	}
+	ErasureStatus_value = map[string]int32{
+		"ERASURE_STATUS_UNSPECIFIED": 0,
+		"ERASURE_STATUS_SCHEDULED":   1,
+		"ERASURE_STATUS_COMPLETED":   2,
+		"ERASURE_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x ErasureStatus) Enum() *ErasureStatus {
+	p := new(ErasureStatus)
+	*p = x
+	return p
+}
+
+func (x ErasureStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErasureStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[4].Descriptor()
+}
+
+func (ErasureStatus) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[4]
+}
+
+func (x ErasureStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErasureStatus.Descriptor instead.
+func (ErasureStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{4}
+}
+
+type ConsentChannel int32
+
+const (
+	ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED ConsentChannel = 0
+	// the email addresses of the customer
+	ConsentChannel_CONSENT_CHANNEL_EMAIL ConsentChannel = 1
+	// text messages to the phone numbers of the customer
+	ConsentChannel_CONSENT_CHANNEL_SMS ConsentChannel = 2
+)
+
+// Enum value maps for ConsentChannel.
+var (
+	ConsentChannel_name = map[int32]string{
+		0: "CONSENT_CHANNEL_UNSPECIFIED",
+		1: "CONSENT_CHANNEL_EMAIL",
+		2: "CONSENT_CHANNEL_SMS",
+	}
+	ConsentChannel_value = map[string]int32{
+		"CONSENT_CHANNEL_UNSPECIFIED": 0,
+		"CONSENT_CHANNEL_EMAIL":       1,
+		"CONSENT_CHANNEL_SMS":         2,
+	}
+)
+
+func (x ConsentChannel) Enum() *ConsentChannel {
+	p := new(ConsentChannel)
+	*p = x
+	return p
+}
+
+func (x ConsentChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConsentChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[5].Descriptor()
+}
+
+func (ConsentChannel) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[5]
+}
+
+func (x ConsentChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConsentChannel.Descriptor instead.
+func (ConsentChannel) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{5}
+}
+
+type ConsentStatus int32
+
+const (
+	ConsentStatus_CONSENT_STATUS_UNSPECIFIED ConsentStatus = 0
+	ConsentStatus_CONSENT_STATUS_GRANTED     ConsentStatus = 1
+	ConsentStatus_CONSENT_STATUS_WITHDRAWN   ConsentStatus = 2
+)
+
+// Enum value maps for ConsentStatus.
+var (
+	ConsentStatus_name = map[int32]string{
+		0: "CONSENT_STATUS_UNSPECIFIED",
+		1: "CONSENT_STATUS_GRANTED",
+		2: "CONSENT_STATUS_WITHDRAWN",
+	}
+	ConsentStatus_value = map[string]int32{
+		"CONSENT_STATUS_UNSPECIFIED": 0,
+		"CONSENT_STATUS_GRANTED":     1,
+		"CONSENT_STATUS_WITHDRAWN":   2,
+	}
+)
+
+func (x ConsentStatus) Enum() *ConsentStatus {
+	p := new(ConsentStatus)
+	*p = x
+	return p
+}
+
+func (x ConsentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConsentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_customer_v1_customer_proto_enumTypes[6].Descriptor()
+}
+
+func (ConsentStatus) Type() protoreflect.EnumType {
+	return &file_api_customer_v1_customer_proto_enumTypes[6]
+}
+
+func (x ConsentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConsentStatus.Descriptor instead.
+func (ConsentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{6}
+}
+
 type GetCustomerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -67,15 +437,20 @@ func (x *GetCustomerReq) GetId() int64 {
 }
 
 type GetCustomerReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PhoneNumbers  []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
-	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
-	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	DateOfBirth   string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PhoneNumbers []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Emails       []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	Addresses    []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	DateOfBirth  string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Status       CustomerStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=api.customer.v1.CustomerStatus" json:"status,omitempty"`
+	// the reason given for the last status change
+	StatusReason string `protobuf:"bytes,8,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	// when the status last changed, unset while it never did
+	StatusChangedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetCustomerReply) Reset() {
@@ -150,6 +525,27 @@ func (x *GetCustomerReply) GetDateOfBirth() string {
 	return ""
 }
 
+func (x *GetCustomerReply) GetStatus() CustomerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED
+}
+
+func (x *GetCustomerReply) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *GetCustomerReply) GetStatusChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return nil
+}
+
 type GetCustomerByEmailReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -195,15 +591,20 @@ func (x *GetCustomerByEmailReq) GetEmail() string {
 }
 
 type GetCustomerByEmailReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PhoneNumbers  []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
-	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
-	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	DateOfBirth   string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PhoneNumbers []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Emails       []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	Addresses    []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	DateOfBirth  string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Status       CustomerStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=api.customer.v1.CustomerStatus" json:"status,omitempty"`
+	// the reason given for the last status change
+	StatusReason string `protobuf:"bytes,8,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	// when the status last changed, unset while it never did
+	StatusChangedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetCustomerByEmailReply) Reset() {
@@ -278,6 +679,27 @@ func (x *GetCustomerByEmailReply) GetDateOfBirth() string {
 	return ""
 }
 
+func (x *GetCustomerByEmailReply) GetStatus() CustomerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED
+}
+
+func (x *GetCustomerByEmailReply) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *GetCustomerByEmailReply) GetStatusChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return nil
+}
+
 type GetCustomerByPhoneNumberReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
@@ -323,15 +745,20 @@ func (x *GetCustomerByPhoneNumberReq) GetPhoneNumber() string {
 }
 
 type GetCustomerByPhoneNumberReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PhoneNumbers  []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
-	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
-	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	DateOfBirth   string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PhoneNumbers []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Emails       []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	Addresses    []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	DateOfBirth  string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Status       CustomerStatus         `protobuf:"varint,7,opt,name=status,proto3,enum=api.customer.v1.CustomerStatus" json:"status,omitempty"`
+	// the reason given for the last status change
+	StatusReason string `protobuf:"bytes,8,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	// when the status last changed, unset while it never did
+	StatusChangedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetCustomerByPhoneNumberReply) Reset() {
@@ -406,6 +833,27 @@ func (x *GetCustomerByPhoneNumberReply) GetDateOfBirth() string {
 	return ""
 }
 
+func (x *GetCustomerByPhoneNumberReply) GetStatus() CustomerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED
+}
+
+func (x *GetCustomerByPhoneNumberReply) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *GetCustomerByPhoneNumberReply) GetStatusChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return nil
+}
+
 type CreateCustomerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -518,29 +966,31 @@ func (x *CreateCustomerReply) GetDateOfBirth() string {
 	return ""
 }
 
-type UpdateCustomerReq struct {
+type CreateCustomerWithDetailsReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	DateOfBirth   string                 `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,2,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Address       string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateCustomerReq) Reset() {
-	*x = UpdateCustomerReq{}
+func (x *CreateCustomerWithDetailsReq) Reset() {
+	*x = CreateCustomerWithDetailsReq{}
 	mi := &file_api_customer_v1_customer_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCustomerReq) String() string {
+func (x *CreateCustomerWithDetailsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCustomerReq) ProtoMessage() {}
+func (*CreateCustomerWithDetailsReq) ProtoMessage() {}
 
-func (x *UpdateCustomerReq) ProtoReflect() protoreflect.Message {
+func (x *CreateCustomerWithDetailsReq) ProtoReflect() protoreflect.Message {
 	mi := &file_api_customer_v1_customer_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -552,47 +1002,205 @@ func (x *UpdateCustomerReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCustomerReq.ProtoReflect.Descriptor instead.
-func (*UpdateCustomerReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateCustomerWithDetailsReq.ProtoReflect.Descriptor instead.
+func (*CreateCustomerWithDetailsReq) Descriptor() ([]byte, []int) {
 	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateCustomerReq) GetId() int64 {
+func (x *CreateCustomerWithDetailsReq) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *UpdateCustomerReq) GetName() string {
+func (x *CreateCustomerWithDetailsReq) GetDateOfBirth() string {
 	if x != nil {
-		return x.Name
+		return x.DateOfBirth
 	}
 	return ""
 }
 
-func (x *UpdateCustomerReq) GetDateOfBirth() string {
+func (x *CreateCustomerWithDetailsReq) GetEmail() string {
 	if x != nil {
-		return x.DateOfBirth
+		return x.Email
 	}
 	return ""
 }
 
-type UpdateCustomerReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PhoneNumbers  []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
-	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
-	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
-	DateOfBirth   string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+func (x *CreateCustomerWithDetailsReq) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *CreateCustomerWithDetailsReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type CreateCustomerWithDetailsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PhoneNumbers  []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerWithDetailsReply) Reset() {
+	*x = CreateCustomerWithDetailsReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerWithDetailsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerWithDetailsReply) ProtoMessage() {}
+
+func (x *CreateCustomerWithDetailsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerWithDetailsReply.ProtoReflect.Descriptor instead.
+func (*CreateCustomerWithDetailsReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateCustomerWithDetailsReply) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CreateCustomerWithDetailsReply) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCustomerWithDetailsReply) GetPhoneNumbers() []string {
+	if x != nil {
+		return x.PhoneNumbers
+	}
+	return nil
+}
+
+func (x *CreateCustomerWithDetailsReply) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *CreateCustomerWithDetailsReply) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *CreateCustomerWithDetailsReply) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+type UpdateCustomerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerReq) Reset() {
+	*x = UpdateCustomerReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerReq) ProtoMessage() {}
+
+func (x *UpdateCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerReq.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateCustomerReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCustomerReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCustomerReq) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+type UpdateCustomerReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PhoneNumbers  []string               `protobuf:"bytes,3,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,6,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateCustomerReply) Reset() {
 	*x = UpdateCustomerReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[9]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -604,7 +1212,7 @@ func (x *UpdateCustomerReply) String() string {
 func (*UpdateCustomerReply) ProtoMessage() {}
 
 func (x *UpdateCustomerReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[9]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +1225,7 @@ func (x *UpdateCustomerReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCustomerReply.ProtoReflect.Descriptor instead.
 func (*UpdateCustomerReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{9}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateCustomerReply) GetId() int64 {
@@ -671,7 +1279,7 @@ type DeleteCustomerReq struct {
 
 func (x *DeleteCustomerReq) Reset() {
 	*x = DeleteCustomerReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[10]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -683,7 +1291,7 @@ func (x *DeleteCustomerReq) String() string {
 func (*DeleteCustomerReq) ProtoMessage() {}
 
 func (x *DeleteCustomerReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[10]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,7 +1304,7 @@ func (x *DeleteCustomerReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCustomerReq.ProtoReflect.Descriptor instead.
 func (*DeleteCustomerReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{10}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteCustomerReq) GetId() int64 {
@@ -715,7 +1323,7 @@ type DeleteCustomerReply struct {
 
 func (x *DeleteCustomerReply) Reset() {
 	*x = DeleteCustomerReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[11]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -727,7 +1335,7 @@ func (x *DeleteCustomerReply) String() string {
 func (*DeleteCustomerReply) ProtoMessage() {}
 
 func (x *DeleteCustomerReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[11]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,7 +1348,7 @@ func (x *DeleteCustomerReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCustomerReply.ProtoReflect.Descriptor instead.
 func (*DeleteCustomerReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{11}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteCustomerReply) GetSuccess() bool {
@@ -760,7 +1368,7 @@ type AddPhoneNumberReq struct {
 
 func (x *AddPhoneNumberReq) Reset() {
 	*x = AddPhoneNumberReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[12]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -772,7 +1380,7 @@ func (x *AddPhoneNumberReq) String() string {
 func (*AddPhoneNumberReq) ProtoMessage() {}
 
 func (x *AddPhoneNumberReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[12]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -785,7 +1393,7 @@ func (x *AddPhoneNumberReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPhoneNumberReq.ProtoReflect.Descriptor instead.
 func (*AddPhoneNumberReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{12}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AddPhoneNumberReq) GetCustomerId() int64 {
@@ -813,7 +1421,7 @@ type AddPhoneNumberReply struct {
 
 func (x *AddPhoneNumberReply) Reset() {
 	*x = AddPhoneNumberReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[13]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -825,7 +1433,7 @@ func (x *AddPhoneNumberReply) String() string {
 func (*AddPhoneNumberReply) ProtoMessage() {}
 
 func (x *AddPhoneNumberReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[13]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -838,7 +1446,7 @@ func (x *AddPhoneNumberReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPhoneNumberReply.ProtoReflect.Descriptor instead.
 func (*AddPhoneNumberReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{13}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AddPhoneNumberReply) GetId() int64 {
@@ -871,7 +1479,7 @@ type ListPhoneNumberReq struct {
 
 func (x *ListPhoneNumberReq) Reset() {
 	*x = ListPhoneNumberReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[14]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -883,7 +1491,7 @@ func (x *ListPhoneNumberReq) String() string {
 func (*ListPhoneNumberReq) ProtoMessage() {}
 
 func (x *ListPhoneNumberReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[14]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,7 +1504,7 @@ func (x *ListPhoneNumberReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPhoneNumberReq.ProtoReflect.Descriptor instead.
 func (*ListPhoneNumberReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{14}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ListPhoneNumberReq) GetCustomerId() int64 {
@@ -915,7 +1523,7 @@ type ListPhoneNumberReply struct {
 
 func (x *ListPhoneNumberReply) Reset() {
 	*x = ListPhoneNumberReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[15]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -927,7 +1535,7 @@ func (x *ListPhoneNumberReply) String() string {
 func (*ListPhoneNumberReply) ProtoMessage() {}
 
 func (x *ListPhoneNumberReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[15]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -940,7 +1548,7 @@ func (x *ListPhoneNumberReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPhoneNumberReply.ProtoReflect.Descriptor instead.
 func (*ListPhoneNumberReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{15}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListPhoneNumberReply) GetPhoneNumbers() []string {
@@ -960,7 +1568,7 @@ type DeletePhoneNumberReq struct {
 
 func (x *DeletePhoneNumberReq) Reset() {
 	*x = DeletePhoneNumberReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[16]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -972,7 +1580,7 @@ func (x *DeletePhoneNumberReq) String() string {
 func (*DeletePhoneNumberReq) ProtoMessage() {}
 
 func (x *DeletePhoneNumberReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[16]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -985,7 +1593,7 @@ func (x *DeletePhoneNumberReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePhoneNumberReq.ProtoReflect.Descriptor instead.
 func (*DeletePhoneNumberReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{16}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *DeletePhoneNumberReq) GetCustomerId() int64 {
@@ -1011,7 +1619,7 @@ type DeletePhoneNumberReply struct {
 
 func (x *DeletePhoneNumberReply) Reset() {
 	*x = DeletePhoneNumberReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[17]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1023,7 +1631,7 @@ func (x *DeletePhoneNumberReply) String() string {
 func (*DeletePhoneNumberReply) ProtoMessage() {}
 
 func (x *DeletePhoneNumberReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[17]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,7 +1644,7 @@ func (x *DeletePhoneNumberReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePhoneNumberReply.ProtoReflect.Descriptor instead.
 func (*DeletePhoneNumberReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{17}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DeletePhoneNumberReply) GetSuccess() bool {
@@ -1056,7 +1664,7 @@ type AddEmailReq struct {
 
 func (x *AddEmailReq) Reset() {
 	*x = AddEmailReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[18]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1068,7 +1676,7 @@ func (x *AddEmailReq) String() string {
 func (*AddEmailReq) ProtoMessage() {}
 
 func (x *AddEmailReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[18]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1081,7 +1689,7 @@ func (x *AddEmailReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddEmailReq.ProtoReflect.Descriptor instead.
 func (*AddEmailReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{18}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *AddEmailReq) GetCustomerId() int64 {
@@ -1109,7 +1717,7 @@ type AddEmailReply struct {
 
 func (x *AddEmailReply) Reset() {
 	*x = AddEmailReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[19]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1121,7 +1729,7 @@ func (x *AddEmailReply) String() string {
 func (*AddEmailReply) ProtoMessage() {}
 
 func (x *AddEmailReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[19]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1134,7 +1742,7 @@ func (x *AddEmailReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddEmailReply.ProtoReflect.Descriptor instead.
 func (*AddEmailReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{19}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *AddEmailReply) GetId() int64 {
@@ -1167,7 +1775,7 @@ type ListEmailReq struct {
 
 func (x *ListEmailReq) Reset() {
 	*x = ListEmailReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[20]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1179,7 +1787,7 @@ func (x *ListEmailReq) String() string {
 func (*ListEmailReq) ProtoMessage() {}
 
 func (x *ListEmailReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[20]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1192,7 +1800,7 @@ func (x *ListEmailReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEmailReq.ProtoReflect.Descriptor instead.
 func (*ListEmailReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{20}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ListEmailReq) GetCustomerId() int64 {
@@ -1211,7 +1819,7 @@ type ListEmailReply struct {
 
 func (x *ListEmailReply) Reset() {
 	*x = ListEmailReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[21]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1223,7 +1831,7 @@ func (x *ListEmailReply) String() string {
 func (*ListEmailReply) ProtoMessage() {}
 
 func (x *ListEmailReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[21]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1236,7 +1844,7 @@ func (x *ListEmailReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListEmailReply.ProtoReflect.Descriptor instead.
 func (*ListEmailReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{21}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListEmailReply) GetEmails() []string {
@@ -1256,7 +1864,7 @@ type DeleteEmailReq struct {
 
 func (x *DeleteEmailReq) Reset() {
 	*x = DeleteEmailReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[22]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1268,7 +1876,7 @@ func (x *DeleteEmailReq) String() string {
 func (*DeleteEmailReq) ProtoMessage() {}
 
 func (x *DeleteEmailReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[22]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1281,7 +1889,7 @@ func (x *DeleteEmailReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteEmailReq.ProtoReflect.Descriptor instead.
 func (*DeleteEmailReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{22}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *DeleteEmailReq) GetCustomerId() int64 {
@@ -1307,7 +1915,7 @@ type DeleteEmailReply struct {
 
 func (x *DeleteEmailReply) Reset() {
 	*x = DeleteEmailReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[23]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1319,7 +1927,7 @@ func (x *DeleteEmailReply) String() string {
 func (*DeleteEmailReply) ProtoMessage() {}
 
 func (x *DeleteEmailReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[23]
+	mi := &file_api_customer_v1_customer_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1332,7 +1940,7 @@ func (x *DeleteEmailReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteEmailReply.ProtoReflect.Descriptor instead.
 func (*DeleteEmailReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{23}
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *DeleteEmailReply) GetSuccess() bool {
@@ -1342,29 +1950,29 @@ func (x *DeleteEmailReply) GetSuccess() bool {
 	return false
 }
 
-type AddAddressReq struct {
+type VerifyEmailReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddAddressReq) Reset() {
-	*x = AddAddressReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[24]
+func (x *VerifyEmailReq) Reset() {
+	*x = VerifyEmailReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddAddressReq) String() string {
+func (x *VerifyEmailReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddAddressReq) ProtoMessage() {}
+func (*VerifyEmailReq) ProtoMessage() {}
 
-func (x *AddAddressReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[24]
+func (x *VerifyEmailReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1375,49 +1983,3371 @@ func (x *AddAddressReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddAddressReq.ProtoReflect.Descriptor instead.
-func (*AddAddressReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use VerifyEmailReq.ProtoReflect.Descriptor instead.
+func (*VerifyEmailReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VerifyEmailReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *VerifyEmailReq) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type VerifyEmailReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailReply) Reset() {
+	*x = VerifyEmailReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailReply) ProtoMessage() {}
+
+func (x *VerifyEmailReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailReply.ProtoReflect.Descriptor instead.
+func (*VerifyEmailReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *VerifyEmailReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type VerifyPhoneNumberReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneNumberReq) Reset() {
+	*x = VerifyPhoneNumberReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneNumberReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneNumberReq) ProtoMessage() {}
+
+func (x *VerifyPhoneNumberReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneNumberReq.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneNumberReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *VerifyPhoneNumberReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *VerifyPhoneNumberReq) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type VerifyPhoneNumberReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneNumberReply) Reset() {
+	*x = VerifyPhoneNumberReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneNumberReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneNumberReply) ProtoMessage() {}
+
+func (x *VerifyPhoneNumberReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneNumberReply.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneNumberReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VerifyPhoneNumberReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type AddAddressReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAddressReq) Reset() {
+	*x = AddAddressReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAddressReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAddressReq) ProtoMessage() {}
+
+func (x *AddAddressReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAddressReq.ProtoReflect.Descriptor instead.
+func (*AddAddressReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *AddAddressReq) GetCustomerId() int64 {
 	if x != nil {
-		return x.CustomerId
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *AddAddressReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type AddAddressReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    int64                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAddressReply) Reset() {
+	*x = AddAddressReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAddressReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAddressReply) ProtoMessage() {}
+
+func (x *AddAddressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAddressReply.ProtoReflect.Descriptor instead.
+func (*AddAddressReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddAddressReply) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AddAddressReply) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *AddAddressReply) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ListAddressReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressReq) Reset() {
+	*x = ListAddressReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressReq) ProtoMessage() {}
+
+func (x *ListAddressReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressReq.ProtoReflect.Descriptor instead.
+func (*ListAddressReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListAddressReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+type ListAddressReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressReply) Reset() {
+	*x = ListAddressReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressReply) ProtoMessage() {}
+
+func (x *ListAddressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressReply.ProtoReflect.Descriptor instead.
+func (*ListAddressReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListAddressReply) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type DeleteAddressReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressReq) Reset() {
+	*x = DeleteAddressReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressReq) ProtoMessage() {}
+
+func (x *DeleteAddressReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressReq.ProtoReflect.Descriptor instead.
+func (*DeleteAddressReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteAddressReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *DeleteAddressReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type DeleteAddressReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressReply) Reset() {
+	*x = DeleteAddressReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressReply) ProtoMessage() {}
+
+func (x *DeleteAddressReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressReply.ProtoReflect.Descriptor instead.
+func (*DeleteAddressReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteAddressReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// CustomerFilter narrows ExportCustomers and FindDuplicateCustomers.
+// Unset fields match every customer.
+type CustomerFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// case-insensitive substring of the name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// inclusive date of birth bounds, YYYY-MM-DD
+	BornAfter  string `protobuf:"bytes,3,opt,name=born_after,json=bornAfter,proto3" json:"born_after,omitempty"`
+	BornBefore string `protobuf:"bytes,4,opt,name=born_before,json=bornBefore,proto3" json:"born_before,omitempty"`
+	// customers in any of these statuses
+	Statuses      []CustomerStatus `protobuf:"varint,5,rep,packed,name=statuses,proto3,enum=api.customer.v1.CustomerStatus" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerFilter) Reset() {
+	*x = CustomerFilter{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerFilter) ProtoMessage() {}
+
+func (x *CustomerFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerFilter.ProtoReflect.Descriptor instead.
+func (*CustomerFilter) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CustomerFilter) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *CustomerFilter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomerFilter) GetBornAfter() string {
+	if x != nil {
+		return x.BornAfter
+	}
+	return ""
+}
+
+func (x *CustomerFilter) GetBornBefore() string {
+	if x != nil {
+		return x.BornBefore
+	}
+	return ""
+}
+
+func (x *CustomerFilter) GetStatuses() []CustomerStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type ListCustomerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomerReq) Reset() {
+	*x = ListCustomerReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerReq) ProtoMessage() {}
+
+func (x *ListCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerReq.ProtoReflect.Descriptor instead.
+func (*ListCustomerReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{37}
+}
+
+type ListCustomerReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customers     []*GetCustomerReply    `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomerReply) Reset() {
+	*x = ListCustomerReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomerReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomerReply) ProtoMessage() {}
+
+func (x *ListCustomerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomerReply.ProtoReflect.Descriptor instead.
+func (*ListCustomerReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListCustomerReply) GetCustomers() []*GetCustomerReply {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type WatchCustomersReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only stream changes of these customers, all customers when empty
+	CustomerIds []int64 `protobuf:"varint,1,rep,packed,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	// only stream these change types, all types when empty
+	Types []ChangeType `protobuf:"varint,2,rep,packed,name=types,proto3,enum=api.customer.v1.ChangeType" json:"types,omitempty"`
+	// resume after this sequence, 0 streams only new changes
+	AfterSequence int64 `protobuf:"varint,3,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCustomersReq) Reset() {
+	*x = WatchCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCustomersReq) ProtoMessage() {}
+
+func (x *WatchCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCustomersReq.ProtoReflect.Descriptor instead.
+func (*WatchCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *WatchCustomersReq) GetCustomerIds() []int64 {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+func (x *WatchCustomersReq) GetTypes() []ChangeType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchCustomersReq) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+type WatchCustomersReply struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Sequence   int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	CustomerId int64                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Type       ChangeType             `protobuf:"varint,3,opt,name=type,proto3,enum=api.customer.v1.ChangeType" json:"type,omitempty"`
+	// the domain event behind the change, e.g. api.customer.v1.EmailAdded
+	Event         *anypb.Any             `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCustomersReply) Reset() {
+	*x = WatchCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCustomersReply) ProtoMessage() {}
+
+func (x *WatchCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCustomersReply.ProtoReflect.Descriptor instead.
+func (*WatchCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *WatchCustomersReply) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *WatchCustomersReply) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *WatchCustomersReply) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *WatchCustomersReply) GetEvent() *anypb.Any {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchCustomersReply) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type ImportCustomersReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportCustomersReq_Options
+	//	*ImportCustomersReq_Row
+	Payload       isImportCustomersReq_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCustomersReq) Reset() {
+	*x = ImportCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCustomersReq) ProtoMessage() {}
+
+func (x *ImportCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCustomersReq.ProtoReflect.Descriptor instead.
+func (*ImportCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ImportCustomersReq) GetPayload() isImportCustomersReq_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportCustomersReq) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportCustomersReq_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportCustomersReq) GetRow() *ImportCustomerRow {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportCustomersReq_Row); ok {
+			return x.Row
+		}
+	}
+	return nil
+}
+
+type isImportCustomersReq_Payload interface {
+	isImportCustomersReq_Payload()
+}
+
+type ImportCustomersReq_Options struct {
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportCustomersReq_Row struct {
+	Row *ImportCustomerRow `protobuf:"bytes,2,opt,name=row,proto3,oneof"`
+}
+
+func (*ImportCustomersReq_Options) isImportCustomersReq_Payload() {}
+
+func (*ImportCustomersReq_Row) isImportCustomersReq_Payload() {}
+
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// validate and insert every batch, then roll it back
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// rows committed per transaction, 500 when unset
+	BatchSize     int32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type ImportCustomerRow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// position in the source file, numbered from 1 when unset
+	Line          int64    `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Name          string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth   string   `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Emails        []string `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	PhoneNumbers  []string `protobuf:"bytes,5,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Addresses     []string `protobuf:"bytes,6,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCustomerRow) Reset() {
+	*x = ImportCustomerRow{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCustomerRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCustomerRow) ProtoMessage() {}
+
+func (x *ImportCustomerRow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCustomerRow.ProtoReflect.Descriptor instead.
+func (*ImportCustomerRow) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ImportCustomerRow) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportCustomerRow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportCustomerRow) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+func (x *ImportCustomerRow) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *ImportCustomerRow) GetPhoneNumbers() []string {
+	if x != nil {
+		return x.PhoneNumbers
+	}
+	return nil
+}
+
+func (x *ImportCustomerRow) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type ImportRowResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int64                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Status        ImportRowStatus        `protobuf:"varint,2,opt,name=status,proto3,enum=api.customer.v1.ImportRowStatus" json:"status,omitempty"`
+	CustomerId    int64                  `protobuf:"varint,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowResult) ProtoMessage() {}
+
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImportRowResult) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetStatus() ImportRowStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ImportRowStatus_IMPORT_ROW_STATUS_UNSPECIFIED
+}
+
+func (x *ImportRowResult) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportCustomersReply struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DryRun bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// the counts of the whole import, set on the last reply only
+	Total   int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Created int64 `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Valid   int64 `protobuf:"varint,4,opt,name=valid,proto3" json:"valid,omitempty"`
+	Failed  int64 `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	// the rows reported since the previous reply
+	Results       []*ImportRowResult `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCustomersReply) Reset() {
+	*x = ImportCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCustomersReply) ProtoMessage() {}
+
+func (x *ImportCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCustomersReply.ProtoReflect.Descriptor instead.
+func (*ImportCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ImportCustomersReply) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportCustomersReply) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportCustomersReply) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportCustomersReply) GetValid() int64 {
+	if x != nil {
+		return x.Valid
+	}
+	return 0
+}
+
+func (x *ImportCustomersReply) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportCustomersReply) GetResults() []*ImportRowResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ExportCustomersReq struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *CustomerFilter        `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=api.customer.v1.ExportFormat" json:"format,omitempty"`
+	// columns to leave out: id, name, date_of_birth, emails, phone_numbers, addresses
+	ExcludeColumns []string `protobuf:"bytes,3,rep,name=exclude_columns,json=excludeColumns,proto3" json:"exclude_columns,omitempty"`
+	// columns to keep with most of every value hidden
+	MaskColumns   []string `protobuf:"bytes,4,rep,name=mask_columns,json=maskColumns,proto3" json:"mask_columns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCustomersReq) Reset() {
+	*x = ExportCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCustomersReq) ProtoMessage() {}
+
+func (x *ExportCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCustomersReq.ProtoReflect.Descriptor instead.
+func (*ExportCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExportCustomersReq) GetFilter() *CustomerFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ExportCustomersReq) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ExportCustomersReq) GetExcludeColumns() []string {
+	if x != nil {
+		return x.ExcludeColumns
+	}
+	return nil
+}
+
+func (x *ExportCustomersReq) GetMaskColumns() []string {
+	if x != nil {
+		return x.MaskColumns
+	}
+	return nil
+}
+
+type ExportCustomersReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the next chunk of the file, concatenate all chunks in order
+	Data          []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCustomersReply) Reset() {
+	*x = ExportCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCustomersReply) ProtoMessage() {}
+
+func (x *ExportCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCustomersReply.ProtoReflect.Descriptor instead.
+func (*ExportCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ExportCustomersReply) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type FindDuplicateCustomersReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only compare customers matching the filter, all customers when unset
+	Filter *CustomerFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// smallest score reported, between 0 and 1, 0.5 when unset
+	MinScore float64 `protobuf:"fixed64,2,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// most pairs returned, 100 when unset
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicateCustomersReq) Reset() {
+	*x = FindDuplicateCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicateCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateCustomersReq) ProtoMessage() {}
+
+func (x *FindDuplicateCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateCustomersReq.ProtoReflect.Descriptor instead.
+func (*FindDuplicateCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FindDuplicateCustomersReq) GetFilter() *CustomerFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *FindDuplicateCustomersReq) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *FindDuplicateCustomersReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type DuplicateCandidate struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId  int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	DuplicateId int64                  `protobuf:"varint,2,opt,name=duplicate_id,json=duplicateId,proto3" json:"duplicate_id,omitempty"`
+	// 0 to 1, higher is more likely the same person
+	Score float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	// what matched, e.g. "name", "date_of_birth", "email"
+	Reasons       []string `protobuf:"bytes,4,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateCandidate) Reset() {
+	*x = DuplicateCandidate{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateCandidate) ProtoMessage() {}
+
+func (x *DuplicateCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateCandidate.ProtoReflect.Descriptor instead.
+func (*DuplicateCandidate) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DuplicateCandidate) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *DuplicateCandidate) GetDuplicateId() int64 {
+	if x != nil {
+		return x.DuplicateId
+	}
+	return 0
+}
+
+func (x *DuplicateCandidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *DuplicateCandidate) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type FindDuplicateCustomersReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidates    []*DuplicateCandidate  `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicateCustomersReply) Reset() {
+	*x = FindDuplicateCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicateCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateCustomersReply) ProtoMessage() {}
+
+func (x *FindDuplicateCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateCustomersReply.ProtoReflect.Descriptor instead.
+func (*FindDuplicateCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FindDuplicateCustomersReply) GetCandidates() []*DuplicateCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type MergeCustomersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SurvivorId    int64                  `protobuf:"varint,1,opt,name=survivor_id,json=survivorId,proto3" json:"survivor_id,omitempty"`
+	DuplicateIds  []int64                `protobuf:"varint,2,rep,packed,name=duplicate_ids,json=duplicateIds,proto3" json:"duplicate_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCustomersReq) Reset() {
+	*x = MergeCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCustomersReq) ProtoMessage() {}
+
+func (x *MergeCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCustomersReq.ProtoReflect.Descriptor instead.
+func (*MergeCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *MergeCustomersReq) GetSurvivorId() int64 {
+	if x != nil {
+		return x.SurvivorId
+	}
+	return 0
+}
+
+func (x *MergeCustomersReq) GetDuplicateIds() []int64 {
+	if x != nil {
+		return x.DuplicateIds
+	}
+	return nil
+}
+
+type MergeCustomersReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *GetCustomerReply      `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCustomersReply) Reset() {
+	*x = MergeCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCustomersReply) ProtoMessage() {}
+
+func (x *MergeCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCustomersReply.ProtoReflect.Descriptor instead.
+func (*MergeCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MergeCustomersReply) GetCustomer() *GetCustomerReply {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type SearchCustomersReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// hits per page, 20 when unset, at most 100
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, empty for the first page
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCustomersReq) Reset() {
+	*x = SearchCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCustomersReq) ProtoMessage() {}
+
+func (x *SearchCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCustomersReq.ProtoReflect.Descriptor instead.
+func (*SearchCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SearchCustomersReq) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCustomersReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchCustomersReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchHighlight struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name, email, phone_number or address
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// value with the matched words wrapped in <em></em>
+	Highlighted   string `protobuf:"bytes,3,opt,name=highlighted,proto3" json:"highlighted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHighlight) Reset() {
+	*x = SearchHighlight{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHighlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHighlight) ProtoMessage() {}
+
+func (x *SearchHighlight) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHighlight.ProtoReflect.Descriptor instead.
+func (*SearchHighlight) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SearchHighlight) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchHighlight) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SearchHighlight) GetHighlighted() string {
+	if x != nil {
+		return x.Highlighted
+	}
+	return ""
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customer      *GetCustomerReply      `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights    []*SearchHighlight     `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SearchHit) GetCustomer() *GetCustomerReply {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetHighlights() []*SearchHighlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type SearchCustomersReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hits  []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCustomersReply) Reset() {
+	*x = SearchCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCustomersReply) ProtoMessage() {}
+
+func (x *SearchCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCustomersReply.ProtoReflect.Descriptor instead.
+func (*SearchCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SearchCustomersReply) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchCustomersReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ExportCustomerDataReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCustomerDataReq) Reset() {
+	*x = ExportCustomerDataReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCustomerDataReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCustomerDataReq) ProtoMessage() {}
+
+func (x *ExportCustomerDataReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCustomerDataReq.ProtoReflect.Descriptor instead.
+func (*ExportCustomerDataReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ExportCustomerDataReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ExportCustomerDataReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CustomerDataBundle encoded as JSON
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// "sha256:" followed by the hex SHA-256 of bundle
+	Checksum      string `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCustomerDataReply) Reset() {
+	*x = ExportCustomerDataReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCustomerDataReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCustomerDataReply) ProtoMessage() {}
+
+func (x *ExportCustomerDataReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCustomerDataReply.ProtoReflect.Descriptor instead.
+func (*ExportCustomerDataReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ExportCustomerDataReply) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ExportCustomerDataReply) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// CustomerDataBundle is the document ExportCustomerData delivers.
+type CustomerDataBundle struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// format of the bundle, raised on incompatible changes
+	Version     int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	TenantId    string                 `protobuf:"bytes,2,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	GeneratedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	// the profile with all contact points
+	Customer *GetCustomerReply `protobuf:"bytes,4,opt,name=customer,proto3" json:"customer,omitempty"`
+	// every recorded change of the customer, oldest first
+	History []*CustomerDataChange `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	// customers merged into this one, as they were when merged
+	Merges []*CustomerDataMerge `protobuf:"bytes,6,rep,name=merges,proto3" json:"merges,omitempty"`
+	// every consent granted or withdrawn, oldest first
+	Consents      []*Consent `protobuf:"bytes,7,rep,name=consents,proto3" json:"consents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerDataBundle) Reset() {
+	*x = CustomerDataBundle{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerDataBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerDataBundle) ProtoMessage() {}
+
+func (x *CustomerDataBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerDataBundle.ProtoReflect.Descriptor instead.
+func (*CustomerDataBundle) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CustomerDataBundle) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CustomerDataBundle) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CustomerDataBundle) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *CustomerDataBundle) GetCustomer() *GetCustomerReply {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *CustomerDataBundle) GetHistory() []*CustomerDataChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *CustomerDataBundle) GetMerges() []*CustomerDataMerge {
+	if x != nil {
+		return x.Merges
+	}
+	return nil
+}
+
+func (x *CustomerDataBundle) GetConsents() []*Consent {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+type CustomerDataChange struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Sequence int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type     ChangeType             `protobuf:"varint,2,opt,name=type,proto3,enum=api.customer.v1.ChangeType" json:"type,omitempty"`
+	// the domain event behind the change, e.g. api.customer.v1.EmailAdded
+	Event      *anypb.Any             `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// subject of the caller that made the change, empty when unauthenticated
+	Actor         string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerDataChange) Reset() {
+	*x = CustomerDataChange{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerDataChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerDataChange) ProtoMessage() {}
+
+func (x *CustomerDataChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerDataChange.ProtoReflect.Descriptor instead.
+func (*CustomerDataChange) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CustomerDataChange) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CustomerDataChange) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *CustomerDataChange) GetEvent() *anypb.Any {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *CustomerDataChange) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *CustomerDataChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type CustomerDataMerge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MergedId      int64                  `protobuf:"varint,1,opt,name=merged_id,json=mergedId,proto3" json:"merged_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Emails        []string               `protobuf:"bytes,4,rep,name=emails,proto3" json:"emails,omitempty"`
+	PhoneNumbers  []string               `protobuf:"bytes,5,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Addresses     []string               `protobuf:"bytes,6,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	MergedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerDataMerge) Reset() {
+	*x = CustomerDataMerge{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerDataMerge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerDataMerge) ProtoMessage() {}
+
+func (x *CustomerDataMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerDataMerge.ProtoReflect.Descriptor instead.
+func (*CustomerDataMerge) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CustomerDataMerge) GetMergedId() int64 {
+	if x != nil {
+		return x.MergedId
+	}
+	return 0
+}
+
+func (x *CustomerDataMerge) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomerDataMerge) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+func (x *CustomerDataMerge) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *CustomerDataMerge) GetPhoneNumbers() []string {
+	if x != nil {
+		return x.PhoneNumbers
+	}
+	return nil
+}
+
+func (x *CustomerDataMerge) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *CustomerDataMerge) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+type CustomerErasure struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId int64                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Status     ErasureStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=api.customer.v1.ErasureStatus" json:"status,omitempty"`
+	Reason     string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// subject of the caller that asked for the erasure
+	RequestedBy string                 `protobuf:"bytes,5,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// when a scheduled erasure runs
+	EraseAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=erase_after,json=eraseAfter,proto3" json:"erase_after,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	CancelledAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	// hash chaining the completed erasure to the one before it, so
+	// erasure records cannot be changed or removed unnoticed
+	Hash          string `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerErasure) Reset() {
+	*x = CustomerErasure{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerErasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerErasure) ProtoMessage() {}
+
+func (x *CustomerErasure) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerErasure.ProtoReflect.Descriptor instead.
+func (*CustomerErasure) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CustomerErasure) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CustomerErasure) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerErasure) GetStatus() ErasureStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ErasureStatus_ERASURE_STATUS_UNSPECIFIED
+}
+
+func (x *CustomerErasure) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CustomerErasure) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *CustomerErasure) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *CustomerErasure) GetEraseAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EraseAfter
+	}
+	return nil
+}
+
+func (x *CustomerErasure) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *CustomerErasure) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *CustomerErasure) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type EraseCustomerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseCustomerReq) Reset() {
+	*x = EraseCustomerReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseCustomerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseCustomerReq) ProtoMessage() {}
+
+func (x *EraseCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseCustomerReq.ProtoReflect.Descriptor instead.
+func (*EraseCustomerReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *EraseCustomerReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EraseCustomerReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EraseCustomerReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Erasure       *CustomerErasure       `protobuf:"bytes,1,opt,name=erasure,proto3" json:"erasure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseCustomerReply) Reset() {
+	*x = EraseCustomerReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseCustomerReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseCustomerReply) ProtoMessage() {}
+
+func (x *EraseCustomerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseCustomerReply.ProtoReflect.Descriptor instead.
+func (*EraseCustomerReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *EraseCustomerReply) GetErasure() *CustomerErasure {
+	if x != nil {
+		return x.Erasure
+	}
+	return nil
+}
+
+type CancelCustomerErasureReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErasureId     int64                  `protobuf:"varint,1,opt,name=erasure_id,json=erasureId,proto3" json:"erasure_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelCustomerErasureReq) Reset() {
+	*x = CancelCustomerErasureReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelCustomerErasureReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCustomerErasureReq) ProtoMessage() {}
+
+func (x *CancelCustomerErasureReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCustomerErasureReq.ProtoReflect.Descriptor instead.
+func (*CancelCustomerErasureReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CancelCustomerErasureReq) GetErasureId() int64 {
+	if x != nil {
+		return x.ErasureId
+	}
+	return 0
+}
+
+type CancelCustomerErasureReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Erasure       *CustomerErasure       `protobuf:"bytes,1,opt,name=erasure,proto3" json:"erasure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelCustomerErasureReply) Reset() {
+	*x = CancelCustomerErasureReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelCustomerErasureReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCustomerErasureReply) ProtoMessage() {}
+
+func (x *CancelCustomerErasureReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCustomerErasureReply.ProtoReflect.Descriptor instead.
+func (*CancelCustomerErasureReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CancelCustomerErasureReply) GetErasure() *CustomerErasure {
+	if x != nil {
+		return x.Erasure
+	}
+	return nil
+}
+
+// Consent is one grant or withdrawal. A consent given on a contact point
+// takes precedence over the one given on the whole channel.
+type Consent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId int64                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Channel    ConsentChannel         `protobuf:"varint,3,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	// what the customer is contacted for, e.g. "marketing"
+	Purpose string        `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Status  ConsentStatus `protobuf:"varint,5,opt,name=status,proto3,enum=api.customer.v1.ConsentStatus" json:"status,omitempty"`
+	// the email or phone number it applies to, empty for all of them
+	Contact string `protobuf:"bytes,6,opt,name=contact,proto3" json:"contact,omitempty"`
+	// where it was collected, e.g. "signup_form"
+	Source string `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
+	// version of the policy the customer was shown
+	PolicyVersion string `protobuf:"bytes,8,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	// subject of the caller that recorded it
+	RecordedBy    string                 `protobuf:"bytes,9,opt,name=recorded_by,json=recordedBy,proto3" json:"recorded_by,omitempty"`
+	RecordedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Consent) Reset() {
+	*x = Consent{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Consent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Consent) ProtoMessage() {}
+
+func (x *Consent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Consent.ProtoReflect.Descriptor instead.
+func (*Consent) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *Consent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Consent) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *Consent) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *Consent) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *Consent) GetStatus() ConsentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ConsentStatus_CONSENT_STATUS_UNSPECIFIED
+}
+
+func (x *Consent) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *Consent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Consent) GetPolicyVersion() string {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return ""
+}
+
+func (x *Consent) GetRecordedBy() string {
+	if x != nil {
+		return x.RecordedBy
+	}
+	return ""
+}
+
+func (x *Consent) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+type GrantConsentReq struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Channel    ConsentChannel         `protobuf:"varint,2,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	Purpose    string                 `protobuf:"bytes,3,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	// an email (EMAIL) or phone number (SMS) of the customer, empty for all
+	Contact string `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
+	Source  string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	// required
+	PolicyVersion string `protobuf:"bytes,6,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantConsentReq) Reset() {
+	*x = GrantConsentReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantConsentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantConsentReq) ProtoMessage() {}
+
+func (x *GrantConsentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantConsentReq.ProtoReflect.Descriptor instead.
+func (*GrantConsentReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GrantConsentReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *GrantConsentReq) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *GrantConsentReq) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *GrantConsentReq) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *GrantConsentReq) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GrantConsentReq) GetPolicyVersion() string {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return ""
+}
+
+type GrantConsentReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *Consent               `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantConsentReply) Reset() {
+	*x = GrantConsentReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantConsentReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantConsentReply) ProtoMessage() {}
+
+func (x *GrantConsentReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantConsentReply.ProtoReflect.Descriptor instead.
+func (*GrantConsentReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GrantConsentReply) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+type WithdrawConsentReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Channel       ConsentChannel         `protobuf:"varint,2,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	Purpose       string                 `protobuf:"bytes,3,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Contact       string                 `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
+	Source        string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	PolicyVersion string                 `protobuf:"bytes,6,opt,name=policy_version,json=policyVersion,proto3" json:"policy_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawConsentReq) Reset() {
+	*x = WithdrawConsentReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawConsentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawConsentReq) ProtoMessage() {}
+
+func (x *WithdrawConsentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawConsentReq.ProtoReflect.Descriptor instead.
+func (*WithdrawConsentReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *WithdrawConsentReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *WithdrawConsentReq) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *WithdrawConsentReq) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *WithdrawConsentReq) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *WithdrawConsentReq) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *WithdrawConsentReq) GetPolicyVersion() string {
+	if x != nil {
+		return x.PolicyVersion
+	}
+	return ""
+}
+
+type WithdrawConsentReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *Consent               `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawConsentReply) Reset() {
+	*x = WithdrawConsentReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawConsentReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawConsentReply) ProtoMessage() {}
+
+func (x *WithdrawConsentReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawConsentReply.ProtoReflect.Descriptor instead.
+func (*WithdrawConsentReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *WithdrawConsentReply) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+type ListConsentsReq struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	// every grant and withdrawal, oldest first, instead of the consents in
+	// force
+	History       bool `protobuf:"varint,2,opt,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsentsReq) Reset() {
+	*x = ListConsentsReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsentsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsentsReq) ProtoMessage() {}
+
+func (x *ListConsentsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsentsReq.ProtoReflect.Descriptor instead.
+func (*ListConsentsReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListConsentsReq) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *ListConsentsReq) GetHistory() bool {
+	if x != nil {
+		return x.History
+	}
+	return false
+}
+
+type ListConsentsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consents      []*Consent             `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsentsReply) Reset() {
+	*x = ListConsentsReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsentsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsentsReply) ProtoMessage() {}
+
+func (x *ListConsentsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsentsReply.ProtoReflect.Descriptor instead.
+func (*ListConsentsReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListConsentsReply) GetConsents() []*Consent {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+type ListReachableContactsReq struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Purpose string                 `protobuf:"bytes,1,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	// both channels when unset
+	Channel ConsentChannel `protobuf:"varint,2,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	// customers per page, 100 when unset, at most 1000
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, empty for the first page
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReachableContactsReq) Reset() {
+	*x = ListReachableContactsReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReachableContactsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReachableContactsReq) ProtoMessage() {}
+
+func (x *ListReachableContactsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReachableContactsReq.ProtoReflect.Descriptor instead.
+func (*ListReachableContactsReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListReachableContactsReq) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *ListReachableContactsReq) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *ListReachableContactsReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReachableContactsReq) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ReachableContact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Channel       ConsentChannel         `protobuf:"varint,2,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	Contact       string                 `protobuf:"bytes,3,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReachableContact) Reset() {
+	*x = ReachableContact{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReachableContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReachableContact) ProtoMessage() {}
+
+func (x *ReachableContact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReachableContact.ProtoReflect.Descriptor instead.
+func (*ReachableContact) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ReachableContact) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *ReachableContact) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *ReachableContact) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+type ListReachableContactsReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by customer
+	Contacts []*ReachableContact `protobuf:"bytes,1,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReachableContactsReply) Reset() {
+	*x = ListReachableContactsReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReachableContactsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReachableContactsReply) ProtoMessage() {}
+
+func (x *ListReachableContactsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReachableContactsReply.ProtoReflect.Descriptor instead.
+func (*ListReachableContactsReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListReachableContactsReply) GetContacts() []*ReachableContact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+func (x *ListReachableContactsReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// CustomerKey identifies a customer by one of its id, an email or a phone
+// number.
+type CustomerKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*CustomerKey_Id
+	//	*CustomerKey_Email
+	//	*CustomerKey_PhoneNumber
+	Key           isCustomerKey_Key `protobuf_oneof:"key"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerKey) Reset() {
+	*x = CustomerKey{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerKey) ProtoMessage() {}
+
+func (x *CustomerKey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerKey.ProtoReflect.Descriptor instead.
+func (*CustomerKey) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CustomerKey) GetKey() isCustomerKey_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CustomerKey) GetId() int64 {
+	if x != nil {
+		if x, ok := x.Key.(*CustomerKey_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *CustomerKey) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Key.(*CustomerKey_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *CustomerKey) GetPhoneNumber() string {
+	if x != nil {
+		if x, ok := x.Key.(*CustomerKey_PhoneNumber); ok {
+			return x.PhoneNumber
+		}
+	}
+	return ""
+}
+
+type isCustomerKey_Key interface {
+	isCustomerKey_Key()
+}
+
+type CustomerKey_Id struct {
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type CustomerKey_Email struct {
+	Email string `protobuf:"bytes,2,opt,name=email,proto3,oneof"`
+}
+
+type CustomerKey_PhoneNumber struct {
+	PhoneNumber string `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3,oneof"`
+}
+
+func (*CustomerKey_Id) isCustomerKey_Key() {}
+
+func (*CustomerKey_Email) isCustomerKey_Key() {}
+
+func (*CustomerKey_PhoneNumber) isCustomerKey_Key() {}
+
+type BatchGetCustomersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*CustomerKey         `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCustomersReq) Reset() {
+	*x = BatchGetCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCustomersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCustomersReq) ProtoMessage() {}
+
+func (x *BatchGetCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCustomersReq.ProtoReflect.Descriptor instead.
+func (*BatchGetCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *BatchGetCustomersReq) GetKeys() []*CustomerKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type BatchGetCustomersResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *CustomerKey           `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Found bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	// unset when not found
+	Customer      *GetCustomerReply `protobuf:"bytes,3,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCustomersResult) Reset() {
+	*x = BatchGetCustomersResult{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCustomersResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCustomersResult) ProtoMessage() {}
+
+func (x *BatchGetCustomersResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCustomersResult.ProtoReflect.Descriptor instead.
+func (*BatchGetCustomersResult) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *BatchGetCustomersResult) GetKey() *CustomerKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *BatchGetCustomersResult) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *BatchGetCustomersResult) GetCustomer() *GetCustomerReply {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type BatchGetCustomersReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one per key, in the order of the keys
+	Results       []*BatchGetCustomersResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCustomersReply) Reset() {
+	*x = BatchGetCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCustomersReply) ProtoMessage() {}
+
+func (x *BatchGetCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *AddAddressReq) GetAddress() string {
+// Deprecated: Use BatchGetCustomersReply.ProtoReflect.Descriptor instead.
+func (*BatchGetCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *BatchGetCustomersReply) GetResults() []*BatchGetCustomersResult {
 	if x != nil {
-		return x.Address
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-type AddAddressReply struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	CustomerId    int64                  `protobuf:"varint,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+type BatchUpdateCustomersReq struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Updates []*UpdateCustomerReq   `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	// apply the updates that pass and report the others, instead of
+	// applying none when one fails
+	BestEffort    bool `protobuf:"varint,2,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddAddressReply) Reset() {
-	*x = AddAddressReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[25]
+func (x *BatchUpdateCustomersReq) Reset() {
+	*x = BatchUpdateCustomersReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddAddressReply) String() string {
+func (x *BatchUpdateCustomersReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddAddressReply) ProtoMessage() {}
+func (*BatchUpdateCustomersReq) ProtoMessage() {}
 
-func (x *AddAddressReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[25]
+func (x *BatchUpdateCustomersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,54 +5358,156 @@ func (x *AddAddressReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddAddressReply.ProtoReflect.Descriptor instead.
-func (*AddAddressReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use BatchUpdateCustomersReq.ProtoReflect.Descriptor instead.
+func (*BatchUpdateCustomersReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *AddAddressReply) GetId() int64 {
+func (x *BatchUpdateCustomersReq) GetUpdates() []*UpdateCustomerReq {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *BatchUpdateCustomersReq) GetBestEffort() bool {
+	if x != nil {
+		return x.BestEffort
+	}
+	return false
+}
+
+type BatchUpdateCustomersResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the id of the update
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the customer after the update, unset when it failed
+	Customer *GetCustomerReply `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	// why the update failed, empty when it applied
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateCustomersResult) Reset() {
+	*x = BatchUpdateCustomersResult{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateCustomersResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateCustomersResult) ProtoMessage() {}
+
+func (x *BatchUpdateCustomersResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateCustomersResult.ProtoReflect.Descriptor instead.
+func (*BatchUpdateCustomersResult) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *BatchUpdateCustomersResult) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *AddAddressReply) GetCustomerId() int64 {
+func (x *BatchUpdateCustomersResult) GetCustomer() *GetCustomerReply {
 	if x != nil {
-		return x.CustomerId
+		return x.Customer
 	}
-	return 0
+	return nil
 }
 
-func (x *AddAddressReply) GetAddress() string {
+func (x *BatchUpdateCustomersResult) GetError() string {
 	if x != nil {
-		return x.Address
+		return x.Error
 	}
 	return ""
 }
 
-type ListAddressReq struct {
+type BatchUpdateCustomersReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one per update, in the order of the updates
+	Results       []*BatchUpdateCustomersResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateCustomersReply) Reset() {
+	*x = BatchUpdateCustomersReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateCustomersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateCustomersReply) ProtoMessage() {}
+
+func (x *BatchUpdateCustomersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateCustomersReply.ProtoReflect.Descriptor instead.
+func (*BatchUpdateCustomersReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *BatchUpdateCustomersReply) GetResults() []*BatchUpdateCustomersResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ActivateCustomerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAddressReq) Reset() {
-	*x = ListAddressReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[26]
+func (x *ActivateCustomerReq) Reset() {
+	*x = ActivateCustomerReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAddressReq) String() string {
+func (x *ActivateCustomerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAddressReq) ProtoMessage() {}
+func (*ActivateCustomerReq) ProtoMessage() {}
 
-func (x *ListAddressReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[26]
+func (x *ActivateCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1486,40 +5518,47 @@ func (x *ListAddressReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAddressReq.ProtoReflect.Descriptor instead.
-func (*ListAddressReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ActivateCustomerReq.ProtoReflect.Descriptor instead.
+func (*ActivateCustomerReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *ListAddressReq) GetCustomerId() int64 {
+func (x *ActivateCustomerReq) GetId() int64 {
 	if x != nil {
-		return x.CustomerId
+		return x.Id
 	}
 	return 0
 }
 
-type ListAddressReply struct {
+func (x *ActivateCustomerReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ActivateCustomerReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Customer      *GetCustomerReply      `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAddressReply) Reset() {
-	*x = ListAddressReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[27]
+func (x *ActivateCustomerReply) Reset() {
+	*x = ActivateCustomerReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAddressReply) String() string {
+func (x *ActivateCustomerReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAddressReply) ProtoMessage() {}
+func (*ActivateCustomerReply) ProtoMessage() {}
 
-func (x *ListAddressReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[27]
+func (x *ActivateCustomerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1530,41 +5569,41 @@ func (x *ListAddressReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAddressReply.ProtoReflect.Descriptor instead.
-func (*ListAddressReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ActivateCustomerReply.ProtoReflect.Descriptor instead.
+func (*ActivateCustomerReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *ListAddressReply) GetAddresses() []string {
+func (x *ActivateCustomerReply) GetCustomer() *GetCustomerReply {
 	if x != nil {
-		return x.Addresses
+		return x.Customer
 	}
 	return nil
 }
 
-type DeleteAddressReq struct {
+type SuspendCustomerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAddressReq) Reset() {
-	*x = DeleteAddressReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[28]
+func (x *SuspendCustomerReq) Reset() {
+	*x = SuspendCustomerReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAddressReq) String() string {
+func (x *SuspendCustomerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAddressReq) ProtoMessage() {}
+func (*SuspendCustomerReq) ProtoMessage() {}
 
-func (x *DeleteAddressReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[28]
+func (x *SuspendCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1575,47 +5614,47 @@ func (x *DeleteAddressReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAddressReq.ProtoReflect.Descriptor instead.
-func (*DeleteAddressReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use SuspendCustomerReq.ProtoReflect.Descriptor instead.
+func (*SuspendCustomerReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *DeleteAddressReq) GetCustomerId() int64 {
+func (x *SuspendCustomerReq) GetId() int64 {
 	if x != nil {
-		return x.CustomerId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *DeleteAddressReq) GetAddress() string {
+func (x *SuspendCustomerReq) GetReason() string {
 	if x != nil {
-		return x.Address
+		return x.Reason
 	}
 	return ""
 }
 
-type DeleteAddressReply struct {
+type SuspendCustomerReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Customer      *GetCustomerReply      `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAddressReply) Reset() {
-	*x = DeleteAddressReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[29]
+func (x *SuspendCustomerReply) Reset() {
+	*x = SuspendCustomerReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAddressReply) String() string {
+func (x *SuspendCustomerReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAddressReply) ProtoMessage() {}
+func (*SuspendCustomerReply) ProtoMessage() {}
 
-func (x *DeleteAddressReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[29]
+func (x *SuspendCustomerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1626,39 +5665,41 @@ func (x *DeleteAddressReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAddressReply.ProtoReflect.Descriptor instead.
-func (*DeleteAddressReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use SuspendCustomerReply.ProtoReflect.Descriptor instead.
+func (*SuspendCustomerReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *DeleteAddressReply) GetSuccess() bool {
+func (x *SuspendCustomerReply) GetCustomer() *GetCustomerReply {
 	if x != nil {
-		return x.Success
+		return x.Customer
 	}
-	return false
+	return nil
 }
 
-type ListCustomerReq struct {
+type CloseCustomerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCustomerReq) Reset() {
-	*x = ListCustomerReq{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[30]
+func (x *CloseCustomerReq) Reset() {
+	*x = CloseCustomerReq{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCustomerReq) String() string {
+func (x *CloseCustomerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCustomerReq) ProtoMessage() {}
+func (*CloseCustomerReq) ProtoMessage() {}
 
-func (x *ListCustomerReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[30]
+func (x *CloseCustomerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1669,33 +5710,47 @@ func (x *ListCustomerReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCustomerReq.ProtoReflect.Descriptor instead.
-func (*ListCustomerReq) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use CloseCustomerReq.ProtoReflect.Descriptor instead.
+func (*CloseCustomerReq) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{88}
 }
 
-type ListCustomerReply struct {
+func (x *CloseCustomerReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CloseCustomerReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CloseCustomerReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Customers     []*GetCustomerReply    `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	Customer      *GetCustomerReply      `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCustomerReply) Reset() {
-	*x = ListCustomerReply{}
-	mi := &file_api_customer_v1_customer_proto_msgTypes[31]
+func (x *CloseCustomerReply) Reset() {
+	*x = CloseCustomerReply{}
+	mi := &file_api_customer_v1_customer_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCustomerReply) String() string {
+func (x *CloseCustomerReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCustomerReply) ProtoMessage() {}
+func (*CloseCustomerReply) ProtoMessage() {}
 
-func (x *ListCustomerReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_customer_v1_customer_proto_msgTypes[31]
+func (x *CloseCustomerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_customer_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1706,14 +5761,14 @@ func (x *ListCustomerReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCustomerReply.ProtoReflect.Descriptor instead.
-func (*ListCustomerReply) Descriptor() ([]byte, []int) {
-	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use CloseCustomerReply.ProtoReflect.Descriptor instead.
+func (*CloseCustomerReply) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_customer_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *ListCustomerReply) GetCustomers() []*GetCustomerReply {
+func (x *CloseCustomerReply) GetCustomer() *GetCustomerReply {
 	if x != nil {
-		return x.Customers
+		return x.Customer
 	}
 	return nil
 }
@@ -1722,41 +5777,63 @@ var File_api_customer_v1_customer_proto protoreflect.FileDescriptor
 
 const file_api_customer_v1_customer_proto_rawDesc = "" +
 	"\n" +
-	"\x1eapi/customer/v1/customer.proto\x12\x0fapi.customer.v1\x1a\x1cgoogle/api/annotations.proto\" \n" +
+	"\x1eapi/customer/v1/customer.proto\x12\x0fapi.customer.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
 	"\x0eGetCustomerReq\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\xb5\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xdb\x02\n" +
 	"\x10GetCustomerReply\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
 	"\rphone_numbers\x18\x03 \x03(\tR\fphoneNumbers\x12\x16\n" +
 	"\x06emails\x18\x04 \x03(\tR\x06emails\x12\x1c\n" +
 	"\taddresses\x18\x05 \x03(\tR\taddresses\x12\"\n" +
-	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\"-\n" +
+	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\x127\n" +
+	"\x06status\x18\a \x01(\x0e2\x1f.api.customer.v1.CustomerStatusR\x06status\x12#\n" +
+	"\rstatus_reason\x18\b \x01(\tR\fstatusReason\x12F\n" +
+	"\x11status_changed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0fstatusChangedAt\"-\n" +
 	"\x15GetCustomerByEmailReq\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"\xbc\x01\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\xe2\x02\n" +
 	"\x17GetCustomerByEmailReply\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
 	"\rphone_numbers\x18\x03 \x03(\tR\fphoneNumbers\x12\x16\n" +
 	"\x06emails\x18\x04 \x03(\tR\x06emails\x12\x1c\n" +
 	"\taddresses\x18\x05 \x03(\tR\taddresses\x12\"\n" +
-	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\"@\n" +
+	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\x127\n" +
+	"\x06status\x18\a \x01(\x0e2\x1f.api.customer.v1.CustomerStatusR\x06status\x12#\n" +
+	"\rstatus_reason\x18\b \x01(\tR\fstatusReason\x12F\n" +
+	"\x11status_changed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0fstatusChangedAt\"@\n" +
 	"\x1bGetCustomerByPhoneNumberReq\x12!\n" +
-	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\"\xc2\x01\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\"\xe8\x02\n" +
 	"\x1dGetCustomerByPhoneNumberReply\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
 	"\rphone_numbers\x18\x03 \x03(\tR\fphoneNumbers\x12\x16\n" +
 	"\x06emails\x18\x04 \x03(\tR\x06emails\x12\x1c\n" +
 	"\taddresses\x18\x05 \x03(\tR\taddresses\x12\"\n" +
-	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\"K\n" +
+	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\x127\n" +
+	"\x06status\x18\a \x01(\x0e2\x1f.api.customer.v1.CustomerStatusR\x06status\x12#\n" +
+	"\rstatus_reason\x18\b \x01(\tR\fstatusReason\x12F\n" +
+	"\x11status_changed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0fstatusChangedAt\"K\n" +
 	"\x11CreateCustomerReq\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
 	"\rdate_of_birth\x18\x02 \x01(\tR\vdateOfBirth\"]\n" +
 	"\x13CreateCustomerReply\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
-	"\rdate_of_birth\x18\x03 \x01(\tR\vdateOfBirth\"[\n" +
+	"\rdate_of_birth\x18\x03 \x01(\tR\vdateOfBirth\"\xa9\x01\n" +
+	"\x1cCreateCustomerWithDetailsReq\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\rdate_of_birth\x18\x02 \x01(\tR\vdateOfBirth\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\"\xc3\x01\n" +
+	"\x1eCreateCustomerWithDetailsReply\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rphone_numbers\x18\x03 \x03(\tR\fphoneNumbers\x12\x16\n" +
+	"\x06emails\x18\x04 \x03(\tR\x06emails\x12\x1c\n" +
+	"\taddresses\x18\x05 \x03(\tR\taddresses\x12\"\n" +
+	"\rdate_of_birth\x18\x06 \x01(\tR\vdateOfBirth\"[\n" +
 	"\x11UpdateCustomerReq\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
@@ -1811,6 +5888,18 @@ const file_api_customer_v1_customer_proto_rawDesc = "" +
 	"customerId\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\",\n" +
 	"\x10DeleteEmailReply\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"G\n" +
+	"\x0eVerifyEmailReq\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\",\n" +
+	"\x10VerifyEmailReply\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"Z\n" +
+	"\x14VerifyPhoneNumberReq\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\"2\n" +
+	"\x16VerifyPhoneNumberReply\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"J\n" +
 	"\rAddAddressReq\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
@@ -1831,12 +5920,286 @@ const file_api_customer_v1_customer_proto_rawDesc = "" +
 	"customerId\x12\x18\n" +
 	"\aaddress\x18\x02 \x01(\tR\aaddress\".\n" +
 	"\x12DeleteAddressReply\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x11\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb3\x01\n" +
+	"\x0eCustomerFilter\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"born_after\x18\x03 \x01(\tR\tbornAfter\x12\x1f\n" +
+	"\vborn_before\x18\x04 \x01(\tR\n" +
+	"bornBefore\x12;\n" +
+	"\bstatuses\x18\x05 \x03(\x0e2\x1f.api.customer.v1.CustomerStatusR\bstatuses\"\x11\n" +
 	"\x0fListCustomerReq\"T\n" +
 	"\x11ListCustomerReply\x12?\n" +
-	"\tcustomers\x18\x01 \x03(\v2!.api.customer.v1.GetCustomerReplyR\tcustomers2\xcf\v\n" +
+	"\tcustomers\x18\x01 \x03(\v2!.api.customer.v1.GetCustomerReplyR\tcustomers\"\x90\x01\n" +
+	"\x11WatchCustomersReq\x12!\n" +
+	"\fcustomer_ids\x18\x01 \x03(\x03R\vcustomerIds\x121\n" +
+	"\x05types\x18\x02 \x03(\x0e2\x1b.api.customer.v1.ChangeTypeR\x05types\x12%\n" +
+	"\x0eafter_sequence\x18\x03 \x01(\x03R\rafterSequence\"\xec\x01\n" +
+	"\x13WatchCustomersReply\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\x03R\n" +
+	"customerId\x12/\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1b.api.customer.v1.ChangeTypeR\x04type\x12*\n" +
+	"\x05event\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\x05event\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x93\x01\n" +
+	"\x12ImportCustomersReq\x12:\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1e.api.customer.v1.ImportOptionsH\x00R\aoptions\x126\n" +
+	"\x03row\x18\x02 \x01(\v2\".api.customer.v1.ImportCustomerRowH\x00R\x03rowB\t\n" +
+	"\apayload\"G\n" +
+	"\rImportOptions\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\"\xba\x01\n" +
+	"\x11ImportCustomerRow\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\rdate_of_birth\x18\x03 \x01(\tR\vdateOfBirth\x12\x16\n" +
+	"\x06emails\x18\x04 \x03(\tR\x06emails\x12#\n" +
+	"\rphone_numbers\x18\x05 \x03(\tR\fphoneNumbers\x12\x1c\n" +
+	"\taddresses\x18\x06 \x03(\tR\taddresses\"\x96\x01\n" +
+	"\x0fImportRowResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x128\n" +
+	"\x06status\x18\x02 \x01(\x0e2 .api.customer.v1.ImportRowStatusR\x06status\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\x03R\n" +
+	"customerId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xc9\x01\n" +
+	"\x14ImportCustomersReply\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x03R\acreated\x12\x14\n" +
+	"\x05valid\x18\x04 \x01(\x03R\x05valid\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x03R\x06failed\x12:\n" +
+	"\aresults\x18\x06 \x03(\v2 .api.customer.v1.ImportRowResultR\aresults\"\xd0\x01\n" +
+	"\x12ExportCustomersReq\x127\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1f.api.customer.v1.CustomerFilterR\x06filter\x125\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1d.api.customer.v1.ExportFormatR\x06format\x12'\n" +
+	"\x0fexclude_columns\x18\x03 \x03(\tR\x0eexcludeColumns\x12!\n" +
+	"\fmask_columns\x18\x04 \x03(\tR\vmaskColumns\"*\n" +
+	"\x14ExportCustomersReply\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x87\x01\n" +
+	"\x19FindDuplicateCustomersReq\x127\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1f.api.customer.v1.CustomerFilterR\x06filter\x12\x1b\n" +
+	"\tmin_score\x18\x02 \x01(\x01R\bminScore\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x88\x01\n" +
+	"\x12DuplicateCandidate\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12!\n" +
+	"\fduplicate_id\x18\x02 \x01(\x03R\vduplicateId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x18\n" +
+	"\areasons\x18\x04 \x03(\tR\areasons\"b\n" +
+	"\x1bFindDuplicateCustomersReply\x12C\n" +
+	"\n" +
+	"candidates\x18\x01 \x03(\v2#.api.customer.v1.DuplicateCandidateR\n" +
+	"candidates\"Y\n" +
+	"\x11MergeCustomersReq\x12\x1f\n" +
+	"\vsurvivor_id\x18\x01 \x01(\x03R\n" +
+	"survivorId\x12#\n" +
+	"\rduplicate_ids\x18\x02 \x03(\x03R\fduplicateIds\"T\n" +
+	"\x13MergeCustomersReply\x12=\n" +
+	"\bcustomer\x18\x01 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\"f\n" +
+	"\x12SearchCustomersReq\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"_\n" +
+	"\x0fSearchHighlight\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12 \n" +
+	"\vhighlighted\x18\x03 \x01(\tR\vhighlighted\"\xa2\x01\n" +
+	"\tSearchHit\x12=\n" +
+	"\bcustomer\x18\x01 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12@\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2 .api.customer.v1.SearchHighlightR\n" +
+	"highlights\"n\n" +
+	"\x14SearchCustomersReply\x12.\n" +
+	"\x04hits\x18\x01 \x03(\v2\x1a.api.customer.v1.SearchHitR\x04hits\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"'\n" +
+	"\x15ExportCustomerDataReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"M\n" +
+	"\x17ExportCustomerDataReply\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\fR\x06bundle\x12\x1a\n" +
+	"\bchecksum\x18\x02 \x01(\tR\bchecksum\"\xfa\x02\n" +
+	"\x12CustomerDataBundle\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1b\n" +
+	"\ttenant_id\x18\x02 \x01(\tR\btenantId\x12=\n" +
+	"\fgenerated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12=\n" +
+	"\bcustomer\x18\x04 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\x12=\n" +
+	"\ahistory\x18\x05 \x03(\v2#.api.customer.v1.CustomerDataChangeR\ahistory\x12:\n" +
+	"\x06merges\x18\x06 \x03(\v2\".api.customer.v1.CustomerDataMergeR\x06merges\x124\n" +
+	"\bconsents\x18\a \x03(\v2\x18.api.customer.v1.ConsentR\bconsents\"\xe0\x01\n" +
+	"\x12CustomerDataChange\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.api.customer.v1.ChangeTypeR\x04type\x12*\n" +
+	"\x05event\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\x05event\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\"\xfc\x01\n" +
+	"\x11CustomerDataMerge\x12\x1b\n" +
+	"\tmerged_id\x18\x01 \x01(\x03R\bmergedId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\rdate_of_birth\x18\x03 \x01(\tR\vdateOfBirth\x12\x16\n" +
+	"\x06emails\x18\x04 \x03(\tR\x06emails\x12#\n" +
+	"\rphone_numbers\x18\x05 \x03(\tR\fphoneNumbers\x12\x1c\n" +
+	"\taddresses\x18\x06 \x03(\tR\taddresses\x127\n" +
+	"\tmerged_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\"\xc3\x03\n" +
+	"\x0fCustomerErasure\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\x03R\n" +
+	"customerId\x126\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1e.api.customer.v1.ErasureStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12!\n" +
+	"\frequested_by\x18\x05 \x01(\tR\vrequestedBy\x12=\n" +
+	"\frequested_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12;\n" +
+	"\verase_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"eraseAfter\x12=\n" +
+	"\fcompleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12=\n" +
+	"\fcancelled_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12\x12\n" +
+	"\x04hash\x18\n" +
+	" \x01(\tR\x04hash\":\n" +
+	"\x10EraseCustomerReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"P\n" +
+	"\x12EraseCustomerReply\x12:\n" +
+	"\aerasure\x18\x01 \x01(\v2 .api.customer.v1.CustomerErasureR\aerasure\"9\n" +
+	"\x18CancelCustomerErasureReq\x12\x1d\n" +
+	"\n" +
+	"erasure_id\x18\x01 \x01(\x03R\terasureId\"X\n" +
+	"\x1aCancelCustomerErasureReply\x12:\n" +
+	"\aerasure\x18\x01 \x01(\v2 .api.customer.v1.CustomerErasureR\aerasure\"\xfe\x02\n" +
+	"\aConsent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\x03R\n" +
+	"customerId\x129\n" +
+	"\achannel\x18\x03 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x18\n" +
+	"\apurpose\x18\x04 \x01(\tR\apurpose\x126\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1e.api.customer.v1.ConsentStatusR\x06status\x12\x18\n" +
+	"\acontact\x18\x06 \x01(\tR\acontact\x12\x16\n" +
+	"\x06source\x18\a \x01(\tR\x06source\x12%\n" +
+	"\x0epolicy_version\x18\b \x01(\tR\rpolicyVersion\x12\x1f\n" +
+	"\vrecorded_by\x18\t \x01(\tR\n" +
+	"recordedBy\x12;\n" +
+	"\vrecorded_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\"\xe0\x01\n" +
+	"\x0fGrantConsentReq\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x129\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x18\n" +
+	"\apurpose\x18\x03 \x01(\tR\apurpose\x12\x18\n" +
+	"\acontact\x18\x04 \x01(\tR\acontact\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12%\n" +
+	"\x0epolicy_version\x18\x06 \x01(\tR\rpolicyVersion\"G\n" +
+	"\x11GrantConsentReply\x122\n" +
+	"\aconsent\x18\x01 \x01(\v2\x18.api.customer.v1.ConsentR\aconsent\"\xe3\x01\n" +
+	"\x12WithdrawConsentReq\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x129\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x18\n" +
+	"\apurpose\x18\x03 \x01(\tR\apurpose\x12\x18\n" +
+	"\acontact\x18\x04 \x01(\tR\acontact\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12%\n" +
+	"\x0epolicy_version\x18\x06 \x01(\tR\rpolicyVersion\"J\n" +
+	"\x14WithdrawConsentReply\x122\n" +
+	"\aconsent\x18\x01 \x01(\v2\x18.api.customer.v1.ConsentR\aconsent\"L\n" +
+	"\x0fListConsentsReq\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x18\n" +
+	"\ahistory\x18\x02 \x01(\bR\ahistory\"I\n" +
+	"\x11ListConsentsReply\x124\n" +
+	"\bconsents\x18\x01 \x03(\v2\x18.api.customer.v1.ConsentR\bconsents\"\xab\x01\n" +
+	"\x18ListReachableContactsReq\x12\x18\n" +
+	"\apurpose\x18\x01 \x01(\tR\apurpose\x129\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x88\x01\n" +
+	"\x10ReachableContact\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x129\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x18\n" +
+	"\acontact\x18\x03 \x01(\tR\acontact\"\x83\x01\n" +
+	"\x1aListReachableContactsReply\x12=\n" +
+	"\bcontacts\x18\x01 \x03(\v2!.api.customer.v1.ReachableContactR\bcontacts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"c\n" +
+	"\vCustomerKey\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x03H\x00R\x02id\x12\x16\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x12#\n" +
+	"\fphone_number\x18\x03 \x01(\tH\x00R\vphoneNumberB\x05\n" +
+	"\x03key\"H\n" +
+	"\x14BatchGetCustomersReq\x120\n" +
+	"\x04keys\x18\x01 \x03(\v2\x1c.api.customer.v1.CustomerKeyR\x04keys\"\x9e\x01\n" +
+	"\x17BatchGetCustomersResult\x12.\n" +
+	"\x03key\x18\x01 \x01(\v2\x1c.api.customer.v1.CustomerKeyR\x03key\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12=\n" +
+	"\bcustomer\x18\x03 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\"\\\n" +
+	"\x16BatchGetCustomersReply\x12B\n" +
+	"\aresults\x18\x01 \x03(\v2(.api.customer.v1.BatchGetCustomersResultR\aresults\"x\n" +
+	"\x17BatchUpdateCustomersReq\x12<\n" +
+	"\aupdates\x18\x01 \x03(\v2\".api.customer.v1.UpdateCustomerReqR\aupdates\x12\x1f\n" +
+	"\vbest_effort\x18\x02 \x01(\bR\n" +
+	"bestEffort\"\x81\x01\n" +
+	"\x1aBatchUpdateCustomersResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12=\n" +
+	"\bcustomer\x18\x02 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"b\n" +
+	"\x19BatchUpdateCustomersReply\x12E\n" +
+	"\aresults\x18\x01 \x03(\v2+.api.customer.v1.BatchUpdateCustomersResultR\aresults\"=\n" +
+	"\x13ActivateCustomerReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"V\n" +
+	"\x15ActivateCustomerReply\x12=\n" +
+	"\bcustomer\x18\x01 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\"<\n" +
+	"\x12SuspendCustomerReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"U\n" +
+	"\x14SuspendCustomerReply\x12=\n" +
+	"\bcustomer\x18\x01 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer\":\n" +
+	"\x10CloseCustomerReq\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"S\n" +
+	"\x12CloseCustomerReply\x12=\n" +
+	"\bcustomer\x18\x01 \x01(\v2!.api.customer.v1.GetCustomerReplyR\bcustomer*\xa6\x01\n" +
+	"\x0eCustomerStatus\x12\x1f\n" +
+	"\x1bCUSTOMER_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CUSTOMER_STATUS_PROSPECT\x10\x01\x12\x1a\n" +
+	"\x16CUSTOMER_STATUS_ACTIVE\x10\x02\x12\x1d\n" +
+	"\x19CUSTOMER_STATUS_SUSPENDED\x10\x03\x12\x1a\n" +
+	"\x16CUSTOMER_STATUS_CLOSED\x10\x04*t\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CHANGE_TYPE_CREATED\x10\x01\x12\x17\n" +
+	"\x13CHANGE_TYPE_UPDATED\x10\x02\x12\x17\n" +
+	"\x13CHANGE_TYPE_DELETED\x10\x03*\x8e\x01\n" +
+	"\x0fImportRowStatus\x12!\n" +
+	"\x1dIMPORT_ROW_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19IMPORT_ROW_STATUS_CREATED\x10\x01\x12\x1c\n" +
+	"\x18IMPORT_ROW_STATUS_FAILED\x10\x02\x12\x1b\n" +
+	"\x17IMPORT_ROW_STATUS_VALID\x10\x03*y\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x01\x12\x18\n" +
+	"\x14EXPORT_FORMAT_NDJSON\x10\x02\x12\x19\n" +
+	"\x15EXPORT_FORMAT_PARQUET\x10\x03*\x89\x01\n" +
+	"\rErasureStatus\x12\x1e\n" +
+	"\x1aERASURE_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ERASURE_STATUS_SCHEDULED\x10\x01\x12\x1c\n" +
+	"\x18ERASURE_STATUS_COMPLETED\x10\x02\x12\x1c\n" +
+	"\x18ERASURE_STATUS_CANCELLED\x10\x03*e\n" +
+	"\x0eConsentChannel\x12\x1f\n" +
+	"\x1bCONSENT_CHANNEL_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15CONSENT_CHANNEL_EMAIL\x10\x01\x12\x17\n" +
+	"\x13CONSENT_CHANNEL_SMS\x10\x02*i\n" +
+	"\rConsentStatus\x12\x1e\n" +
+	"\x1aCONSENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16CONSENT_STATUS_GRANTED\x10\x01\x12\x1c\n" +
+	"\x18CONSENT_STATUS_WITHDRAWN\x10\x022\x9a\x1c\n" +
 	"\bCustomer\x12\\\n" +
-	"\x0eCreateCustomer\x12\".api.customer.v1.CreateCustomerReq\x1a$.api.customer.v1.CreateCustomerReply\"\x00\x12J\n" +
+	"\x0eCreateCustomer\x12\".api.customer.v1.CreateCustomerReq\x1a$.api.customer.v1.CreateCustomerReply\"\x00\x12}\n" +
+	"\x19CreateCustomerWithDetails\x12-.api.customer.v1.CreateCustomerWithDetailsReq\x1a/.api.customer.v1.CreateCustomerWithDetailsReply\"\x00\x12J\n" +
 	"\bAddEmail\x12\x1c.api.customer.v1.AddEmailReq\x1a\x1e.api.customer.v1.AddEmailReply\"\x00\x12\\\n" +
 	"\x0eAddPhoneNumber\x12\".api.customer.v1.AddPhoneNumberReq\x1a$.api.customer.v1.AddPhoneNumberReply\"\x00\x12\\\n" +
 	"\x0eUpdateCustomer\x12\".api.customer.v1.UpdateCustomerReq\x1a$.api.customer.v1.UpdateCustomerReply\"\x00\x12\\\n" +
@@ -1852,7 +6215,27 @@ const file_api_customer_v1_customer_proto_rawDesc = "" +
 	"\x18GetCustomerByPhoneNumber\x12,.api.customer.v1.GetCustomerByPhoneNumberReq\x1a..api.customer.v1.GetCustomerByPhoneNumberReply\"\x00\x12e\n" +
 	"\x11DeletePhoneNumber\x12%.api.customer.v1.DeletePhoneNumberReq\x1a'.api.customer.v1.DeletePhoneNumberReply\"\x00\x12Y\n" +
 	"\rDeleteAddress\x12!.api.customer.v1.DeleteAddressReq\x1a#.api.customer.v1.DeleteAddressReply\"\x00\x12S\n" +
-	"\vDeleteEmail\x12\x1f.api.customer.v1.DeleteEmailReq\x1a!.api.customer.v1.DeleteEmailReply\"\x00B\x1dZ\x1bcustomer/api/customer/v1;v1b\x06proto3"
+	"\vDeleteEmail\x12\x1f.api.customer.v1.DeleteEmailReq\x1a!.api.customer.v1.DeleteEmailReply\"\x00\x12S\n" +
+	"\vVerifyEmail\x12\x1f.api.customer.v1.VerifyEmailReq\x1a!.api.customer.v1.VerifyEmailReply\"\x00\x12e\n" +
+	"\x11VerifyPhoneNumber\x12%.api.customer.v1.VerifyPhoneNumberReq\x1a'.api.customer.v1.VerifyPhoneNumberReply\"\x00\x12^\n" +
+	"\x0eWatchCustomers\x12\".api.customer.v1.WatchCustomersReq\x1a$.api.customer.v1.WatchCustomersReply\"\x000\x01\x12c\n" +
+	"\x0fImportCustomers\x12#.api.customer.v1.ImportCustomersReq\x1a%.api.customer.v1.ImportCustomersReply\"\x00(\x010\x01\x12a\n" +
+	"\x0fExportCustomers\x12#.api.customer.v1.ExportCustomersReq\x1a%.api.customer.v1.ExportCustomersReply\"\x000\x01\x12t\n" +
+	"\x16FindDuplicateCustomers\x12*.api.customer.v1.FindDuplicateCustomersReq\x1a,.api.customer.v1.FindDuplicateCustomersReply\"\x00\x12\\\n" +
+	"\x0eMergeCustomers\x12\".api.customer.v1.MergeCustomersReq\x1a$.api.customer.v1.MergeCustomersReply\"\x00\x12_\n" +
+	"\x0fSearchCustomers\x12#.api.customer.v1.SearchCustomersReq\x1a%.api.customer.v1.SearchCustomersReply\"\x00\x12h\n" +
+	"\x12ExportCustomerData\x12&.api.customer.v1.ExportCustomerDataReq\x1a(.api.customer.v1.ExportCustomerDataReply\"\x00\x12Y\n" +
+	"\rEraseCustomer\x12!.api.customer.v1.EraseCustomerReq\x1a#.api.customer.v1.EraseCustomerReply\"\x00\x12q\n" +
+	"\x15CancelCustomerErasure\x12).api.customer.v1.CancelCustomerErasureReq\x1a+.api.customer.v1.CancelCustomerErasureReply\"\x00\x12V\n" +
+	"\fGrantConsent\x12 .api.customer.v1.GrantConsentReq\x1a\".api.customer.v1.GrantConsentReply\"\x00\x12_\n" +
+	"\x0fWithdrawConsent\x12#.api.customer.v1.WithdrawConsentReq\x1a%.api.customer.v1.WithdrawConsentReply\"\x00\x12V\n" +
+	"\fListConsents\x12 .api.customer.v1.ListConsentsReq\x1a\".api.customer.v1.ListConsentsReply\"\x00\x12q\n" +
+	"\x15ListReachableContacts\x12).api.customer.v1.ListReachableContactsReq\x1a+.api.customer.v1.ListReachableContactsReply\"\x00\x12e\n" +
+	"\x11BatchGetCustomers\x12%.api.customer.v1.BatchGetCustomersReq\x1a'.api.customer.v1.BatchGetCustomersReply\"\x00\x12n\n" +
+	"\x14BatchUpdateCustomers\x12(.api.customer.v1.BatchUpdateCustomersReq\x1a*.api.customer.v1.BatchUpdateCustomersReply\"\x00\x12b\n" +
+	"\x10ActivateCustomer\x12$.api.customer.v1.ActivateCustomerReq\x1a&.api.customer.v1.ActivateCustomerReply\"\x00\x12_\n" +
+	"\x0fSuspendCustomer\x12#.api.customer.v1.SuspendCustomerReq\x1a%.api.customer.v1.SuspendCustomerReply\"\x00\x12Y\n" +
+	"\rCloseCustomer\x12!.api.customer.v1.CloseCustomerReq\x1a#.api.customer.v1.CloseCustomerReply\"\x00B\x1dZ\x1bcustomer/api/customer/v1;v1b\x06proto3"
 
 var (
 	file_api_customer_v1_customer_proto_rawDescOnce sync.Once
@@ -1866,80 +6249,250 @@ func file_api_customer_v1_customer_proto_rawDescGZIP() []byte {
 	return file_api_customer_v1_customer_proto_rawDescData
 }
 
-var file_api_customer_v1_customer_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_api_customer_v1_customer_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_api_customer_v1_customer_proto_msgTypes = make([]protoimpl.MessageInfo, 90)
 var file_api_customer_v1_customer_proto_goTypes = []any{
-	(*GetCustomerReq)(nil),                // 0: api.customer.v1.GetCustomerReq
-	(*GetCustomerReply)(nil),              // 1: api.customer.v1.GetCustomerReply
-	(*GetCustomerByEmailReq)(nil),         // 2: api.customer.v1.GetCustomerByEmailReq
-	(*GetCustomerByEmailReply)(nil),       // 3: api.customer.v1.GetCustomerByEmailReply
-	(*GetCustomerByPhoneNumberReq)(nil),   // 4: api.customer.v1.GetCustomerByPhoneNumberReq
-	(*GetCustomerByPhoneNumberReply)(nil), // 5: api.customer.v1.GetCustomerByPhoneNumberReply
-	(*CreateCustomerReq)(nil),             // 6: api.customer.v1.CreateCustomerReq
-	(*CreateCustomerReply)(nil),           // 7: api.customer.v1.CreateCustomerReply
-	(*UpdateCustomerReq)(nil),             // 8: api.customer.v1.UpdateCustomerReq
-	(*UpdateCustomerReply)(nil),           // 9: api.customer.v1.UpdateCustomerReply
-	(*DeleteCustomerReq)(nil),             // 10: api.customer.v1.DeleteCustomerReq
-	(*DeleteCustomerReply)(nil),           // 11: api.customer.v1.DeleteCustomerReply
-	(*AddPhoneNumberReq)(nil),             // 12: api.customer.v1.AddPhoneNumberReq
-	(*AddPhoneNumberReply)(nil),           // 13: api.customer.v1.AddPhoneNumberReply
-	(*ListPhoneNumberReq)(nil),            // 14: api.customer.v1.ListPhoneNumberReq
-	(*ListPhoneNumberReply)(nil),          // 15: api.customer.v1.ListPhoneNumberReply
-	(*DeletePhoneNumberReq)(nil),          // 16: api.customer.v1.DeletePhoneNumberReq
-	(*DeletePhoneNumberReply)(nil),        // 17: api.customer.v1.DeletePhoneNumberReply
-	(*AddEmailReq)(nil),                   // 18: api.customer.v1.AddEmailReq
-	(*AddEmailReply)(nil),                 // 19: api.customer.v1.AddEmailReply
-	(*ListEmailReq)(nil),                  // 20: api.customer.v1.ListEmailReq
-	(*ListEmailReply)(nil),                // 21: api.customer.v1.ListEmailReply
-	(*DeleteEmailReq)(nil),                // 22: api.customer.v1.DeleteEmailReq
-	(*DeleteEmailReply)(nil),              // 23: api.customer.v1.DeleteEmailReply
-	(*AddAddressReq)(nil),                 // 24: api.customer.v1.AddAddressReq
-	(*AddAddressReply)(nil),               // 25: api.customer.v1.AddAddressReply
-	(*ListAddressReq)(nil),                // 26: api.customer.v1.ListAddressReq
-	(*ListAddressReply)(nil),              // 27: api.customer.v1.ListAddressReply
-	(*DeleteAddressReq)(nil),              // 28: api.customer.v1.DeleteAddressReq
-	(*DeleteAddressReply)(nil),            // 29: api.customer.v1.DeleteAddressReply
-	(*ListCustomerReq)(nil),               // 30: api.customer.v1.ListCustomerReq
-	(*ListCustomerReply)(nil),             // 31: api.customer.v1.ListCustomerReply
+	(CustomerStatus)(0),                    // 0: api.customer.v1.CustomerStatus
+	(ChangeType)(0),                        // 1: api.customer.v1.ChangeType
+	(ImportRowStatus)(0),                   // 2: api.customer.v1.ImportRowStatus
+	(ExportFormat)(0),                      // 3: api.customer.v1.ExportFormat
+	(ErasureStatus)(0),                     // 4: api.customer.v1.ErasureStatus
+	(ConsentChannel)(0),                    // 5: api.customer.v1.ConsentChannel
+	(ConsentStatus)(0),                     // 6: api.customer.v1.ConsentStatus
+	(*GetCustomerReq)(nil),                 // 7: api.customer.v1.GetCustomerReq
+	(*GetCustomerReply)(nil),               // 8: api.customer.v1.GetCustomerReply
+	(*GetCustomerByEmailReq)(nil),          // 9: api.customer.v1.GetCustomerByEmailReq
+	(*GetCustomerByEmailReply)(nil),        // 10: api.customer.v1.GetCustomerByEmailReply
+	(*GetCustomerByPhoneNumberReq)(nil),    // 11: api.customer.v1.GetCustomerByPhoneNumberReq
+	(*GetCustomerByPhoneNumberReply)(nil),  // 12: api.customer.v1.GetCustomerByPhoneNumberReply
+	(*CreateCustomerReq)(nil),              // 13: api.customer.v1.CreateCustomerReq
+	(*CreateCustomerReply)(nil),            // 14: api.customer.v1.CreateCustomerReply
+	(*CreateCustomerWithDetailsReq)(nil),   // 15: api.customer.v1.CreateCustomerWithDetailsReq
+	(*CreateCustomerWithDetailsReply)(nil), // 16: api.customer.v1.CreateCustomerWithDetailsReply
+	(*UpdateCustomerReq)(nil),              // 17: api.customer.v1.UpdateCustomerReq
+	(*UpdateCustomerReply)(nil),            // 18: api.customer.v1.UpdateCustomerReply
+	(*DeleteCustomerReq)(nil),              // 19: api.customer.v1.DeleteCustomerReq
+	(*DeleteCustomerReply)(nil),            // 20: api.customer.v1.DeleteCustomerReply
+	(*AddPhoneNumberReq)(nil),              // 21: api.customer.v1.AddPhoneNumberReq
+	(*AddPhoneNumberReply)(nil),            // 22: api.customer.v1.AddPhoneNumberReply
+	(*ListPhoneNumberReq)(nil),             // 23: api.customer.v1.ListPhoneNumberReq
+	(*ListPhoneNumberReply)(nil),           // 24: api.customer.v1.ListPhoneNumberReply
+	(*DeletePhoneNumberReq)(nil),           // 25: api.customer.v1.DeletePhoneNumberReq
+	(*DeletePhoneNumberReply)(nil),         // 26: api.customer.v1.DeletePhoneNumberReply
+	(*AddEmailReq)(nil),                    // 27: api.customer.v1.AddEmailReq
+	(*AddEmailReply)(nil),                  // 28: api.customer.v1.AddEmailReply
+	(*ListEmailReq)(nil),                   // 29: api.customer.v1.ListEmailReq
+	(*ListEmailReply)(nil),                 // 30: api.customer.v1.ListEmailReply
+	(*DeleteEmailReq)(nil),                 // 31: api.customer.v1.DeleteEmailReq
+	(*DeleteEmailReply)(nil),               // 32: api.customer.v1.DeleteEmailReply
+	(*VerifyEmailReq)(nil),                 // 33: api.customer.v1.VerifyEmailReq
+	(*VerifyEmailReply)(nil),               // 34: api.customer.v1.VerifyEmailReply
+	(*VerifyPhoneNumberReq)(nil),           // 35: api.customer.v1.VerifyPhoneNumberReq
+	(*VerifyPhoneNumberReply)(nil),         // 36: api.customer.v1.VerifyPhoneNumberReply
+	(*AddAddressReq)(nil),                  // 37: api.customer.v1.AddAddressReq
+	(*AddAddressReply)(nil),                // 38: api.customer.v1.AddAddressReply
+	(*ListAddressReq)(nil),                 // 39: api.customer.v1.ListAddressReq
+	(*ListAddressReply)(nil),               // 40: api.customer.v1.ListAddressReply
+	(*DeleteAddressReq)(nil),               // 41: api.customer.v1.DeleteAddressReq
+	(*DeleteAddressReply)(nil),             // 42: api.customer.v1.DeleteAddressReply
+	(*CustomerFilter)(nil),                 // 43: api.customer.v1.CustomerFilter
+	(*ListCustomerReq)(nil),                // 44: api.customer.v1.ListCustomerReq
+	(*ListCustomerReply)(nil),              // 45: api.customer.v1.ListCustomerReply
+	(*WatchCustomersReq)(nil),              // 46: api.customer.v1.WatchCustomersReq
+	(*WatchCustomersReply)(nil),            // 47: api.customer.v1.WatchCustomersReply
+	(*ImportCustomersReq)(nil),             // 48: api.customer.v1.ImportCustomersReq
+	(*ImportOptions)(nil),                  // 49: api.customer.v1.ImportOptions
+	(*ImportCustomerRow)(nil),              // 50: api.customer.v1.ImportCustomerRow
+	(*ImportRowResult)(nil),                // 51: api.customer.v1.ImportRowResult
+	(*ImportCustomersReply)(nil),           // 52: api.customer.v1.ImportCustomersReply
+	(*ExportCustomersReq)(nil),             // 53: api.customer.v1.ExportCustomersReq
+	(*ExportCustomersReply)(nil),           // 54: api.customer.v1.ExportCustomersReply
+	(*FindDuplicateCustomersReq)(nil),      // 55: api.customer.v1.FindDuplicateCustomersReq
+	(*DuplicateCandidate)(nil),             // 56: api.customer.v1.DuplicateCandidate
+	(*FindDuplicateCustomersReply)(nil),    // 57: api.customer.v1.FindDuplicateCustomersReply
+	(*MergeCustomersReq)(nil),              // 58: api.customer.v1.MergeCustomersReq
+	(*MergeCustomersReply)(nil),            // 59: api.customer.v1.MergeCustomersReply
+	(*SearchCustomersReq)(nil),             // 60: api.customer.v1.SearchCustomersReq
+	(*SearchHighlight)(nil),                // 61: api.customer.v1.SearchHighlight
+	(*SearchHit)(nil),                      // 62: api.customer.v1.SearchHit
+	(*SearchCustomersReply)(nil),           // 63: api.customer.v1.SearchCustomersReply
+	(*ExportCustomerDataReq)(nil),          // 64: api.customer.v1.ExportCustomerDataReq
+	(*ExportCustomerDataReply)(nil),        // 65: api.customer.v1.ExportCustomerDataReply
+	(*CustomerDataBundle)(nil),             // 66: api.customer.v1.CustomerDataBundle
+	(*CustomerDataChange)(nil),             // 67: api.customer.v1.CustomerDataChange
+	(*CustomerDataMerge)(nil),              // 68: api.customer.v1.CustomerDataMerge
+	(*CustomerErasure)(nil),                // 69: api.customer.v1.CustomerErasure
+	(*EraseCustomerReq)(nil),               // 70: api.customer.v1.EraseCustomerReq
+	(*EraseCustomerReply)(nil),             // 71: api.customer.v1.EraseCustomerReply
+	(*CancelCustomerErasureReq)(nil),       // 72: api.customer.v1.CancelCustomerErasureReq
+	(*CancelCustomerErasureReply)(nil),     // 73: api.customer.v1.CancelCustomerErasureReply
+	(*Consent)(nil),                        // 74: api.customer.v1.Consent
+	(*GrantConsentReq)(nil),                // 75: api.customer.v1.GrantConsentReq
+	(*GrantConsentReply)(nil),              // 76: api.customer.v1.GrantConsentReply
+	(*WithdrawConsentReq)(nil),             // 77: api.customer.v1.WithdrawConsentReq
+	(*WithdrawConsentReply)(nil),           // 78: api.customer.v1.WithdrawConsentReply
+	(*ListConsentsReq)(nil),                // 79: api.customer.v1.ListConsentsReq
+	(*ListConsentsReply)(nil),              // 80: api.customer.v1.ListConsentsReply
+	(*ListReachableContactsReq)(nil),       // 81: api.customer.v1.ListReachableContactsReq
+	(*ReachableContact)(nil),               // 82: api.customer.v1.ReachableContact
+	(*ListReachableContactsReply)(nil),     // 83: api.customer.v1.ListReachableContactsReply
+	(*CustomerKey)(nil),                    // 84: api.customer.v1.CustomerKey
+	(*BatchGetCustomersReq)(nil),           // 85: api.customer.v1.BatchGetCustomersReq
+	(*BatchGetCustomersResult)(nil),        // 86: api.customer.v1.BatchGetCustomersResult
+	(*BatchGetCustomersReply)(nil),         // 87: api.customer.v1.BatchGetCustomersReply
+	(*BatchUpdateCustomersReq)(nil),        // 88: api.customer.v1.BatchUpdateCustomersReq
+	(*BatchUpdateCustomersResult)(nil),     // 89: api.customer.v1.BatchUpdateCustomersResult
+	(*BatchUpdateCustomersReply)(nil),      // 90: api.customer.v1.BatchUpdateCustomersReply
+	(*ActivateCustomerReq)(nil),            // 91: api.customer.v1.ActivateCustomerReq
+	(*ActivateCustomerReply)(nil),          // 92: api.customer.v1.ActivateCustomerReply
+	(*SuspendCustomerReq)(nil),             // 93: api.customer.v1.SuspendCustomerReq
+	(*SuspendCustomerReply)(nil),           // 94: api.customer.v1.SuspendCustomerReply
+	(*CloseCustomerReq)(nil),               // 95: api.customer.v1.CloseCustomerReq
+	(*CloseCustomerReply)(nil),             // 96: api.customer.v1.CloseCustomerReply
+	(*timestamppb.Timestamp)(nil),          // 97: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                      // 98: google.protobuf.Any
 }
 var file_api_customer_v1_customer_proto_depIdxs = []int32{
-	1,  // 0: api.customer.v1.ListCustomerReply.customers:type_name -> api.customer.v1.GetCustomerReply
-	6,  // 1: api.customer.v1.Customer.CreateCustomer:input_type -> api.customer.v1.CreateCustomerReq
-	18, // 2: api.customer.v1.Customer.AddEmail:input_type -> api.customer.v1.AddEmailReq
-	12, // 3: api.customer.v1.Customer.AddPhoneNumber:input_type -> api.customer.v1.AddPhoneNumberReq
-	8,  // 4: api.customer.v1.Customer.UpdateCustomer:input_type -> api.customer.v1.UpdateCustomerReq
-	10, // 5: api.customer.v1.Customer.DeleteCustomer:input_type -> api.customer.v1.DeleteCustomerReq
-	30, // 6: api.customer.v1.Customer.ListCustomer:input_type -> api.customer.v1.ListCustomerReq
-	24, // 7: api.customer.v1.Customer.AddAddress:input_type -> api.customer.v1.AddAddressReq
-	26, // 8: api.customer.v1.Customer.ListAddress:input_type -> api.customer.v1.ListAddressReq
-	14, // 9: api.customer.v1.Customer.ListPhoneNumber:input_type -> api.customer.v1.ListPhoneNumberReq
-	20, // 10: api.customer.v1.Customer.ListEmail:input_type -> api.customer.v1.ListEmailReq
-	0,  // 11: api.customer.v1.Customer.GetCustomer:input_type -> api.customer.v1.GetCustomerReq
-	2,  // 12: api.customer.v1.Customer.GetCustomerByEmail:input_type -> api.customer.v1.GetCustomerByEmailReq
-	4,  // 13: api.customer.v1.Customer.GetCustomerByPhoneNumber:input_type -> api.customer.v1.GetCustomerByPhoneNumberReq
-	16, // 14: api.customer.v1.Customer.DeletePhoneNumber:input_type -> api.customer.v1.DeletePhoneNumberReq
-	28, // 15: api.customer.v1.Customer.DeleteAddress:input_type -> api.customer.v1.DeleteAddressReq
-	22, // 16: api.customer.v1.Customer.DeleteEmail:input_type -> api.customer.v1.DeleteEmailReq
-	7,  // 17: api.customer.v1.Customer.CreateCustomer:output_type -> api.customer.v1.CreateCustomerReply
-	19, // 18: api.customer.v1.Customer.AddEmail:output_type -> api.customer.v1.AddEmailReply
-	13, // 19: api.customer.v1.Customer.AddPhoneNumber:output_type -> api.customer.v1.AddPhoneNumberReply
-	9,  // 20: api.customer.v1.Customer.UpdateCustomer:output_type -> api.customer.v1.UpdateCustomerReply
-	11, // 21: api.customer.v1.Customer.DeleteCustomer:output_type -> api.customer.v1.DeleteCustomerReply
-	31, // 22: api.customer.v1.Customer.ListCustomer:output_type -> api.customer.v1.ListCustomerReply
-	25, // 23: api.customer.v1.Customer.AddAddress:output_type -> api.customer.v1.AddAddressReply
-	27, // 24: api.customer.v1.Customer.ListAddress:output_type -> api.customer.v1.ListAddressReply
-	15, // 25: api.customer.v1.Customer.ListPhoneNumber:output_type -> api.customer.v1.ListPhoneNumberReply
-	21, // 26: api.customer.v1.Customer.ListEmail:output_type -> api.customer.v1.ListEmailReply
-	1,  // 27: api.customer.v1.Customer.GetCustomer:output_type -> api.customer.v1.GetCustomerReply
-	3,  // 28: api.customer.v1.Customer.GetCustomerByEmail:output_type -> api.customer.v1.GetCustomerByEmailReply
-	5,  // 29: api.customer.v1.Customer.GetCustomerByPhoneNumber:output_type -> api.customer.v1.GetCustomerByPhoneNumberReply
-	17, // 30: api.customer.v1.Customer.DeletePhoneNumber:output_type -> api.customer.v1.DeletePhoneNumberReply
-	29, // 31: api.customer.v1.Customer.DeleteAddress:output_type -> api.customer.v1.DeleteAddressReply
-	23, // 32: api.customer.v1.Customer.DeleteEmail:output_type -> api.customer.v1.DeleteEmailReply
-	17, // [17:33] is the sub-list for method output_type
-	1,  // [1:17] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	0,  // 0: api.customer.v1.GetCustomerReply.status:type_name -> api.customer.v1.CustomerStatus
+	97, // 1: api.customer.v1.GetCustomerReply.status_changed_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: api.customer.v1.GetCustomerByEmailReply.status:type_name -> api.customer.v1.CustomerStatus
+	97, // 3: api.customer.v1.GetCustomerByEmailReply.status_changed_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: api.customer.v1.GetCustomerByPhoneNumberReply.status:type_name -> api.customer.v1.CustomerStatus
+	97, // 5: api.customer.v1.GetCustomerByPhoneNumberReply.status_changed_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: api.customer.v1.CustomerFilter.statuses:type_name -> api.customer.v1.CustomerStatus
+	8,  // 7: api.customer.v1.ListCustomerReply.customers:type_name -> api.customer.v1.GetCustomerReply
+	1,  // 8: api.customer.v1.WatchCustomersReq.types:type_name -> api.customer.v1.ChangeType
+	1,  // 9: api.customer.v1.WatchCustomersReply.type:type_name -> api.customer.v1.ChangeType
+	98, // 10: api.customer.v1.WatchCustomersReply.event:type_name -> google.protobuf.Any
+	97, // 11: api.customer.v1.WatchCustomersReply.occurred_at:type_name -> google.protobuf.Timestamp
+	49, // 12: api.customer.v1.ImportCustomersReq.options:type_name -> api.customer.v1.ImportOptions
+	50, // 13: api.customer.v1.ImportCustomersReq.row:type_name -> api.customer.v1.ImportCustomerRow
+	2,  // 14: api.customer.v1.ImportRowResult.status:type_name -> api.customer.v1.ImportRowStatus
+	51, // 15: api.customer.v1.ImportCustomersReply.results:type_name -> api.customer.v1.ImportRowResult
+	43, // 16: api.customer.v1.ExportCustomersReq.filter:type_name -> api.customer.v1.CustomerFilter
+	3,  // 17: api.customer.v1.ExportCustomersReq.format:type_name -> api.customer.v1.ExportFormat
+	43, // 18: api.customer.v1.FindDuplicateCustomersReq.filter:type_name -> api.customer.v1.CustomerFilter
+	56, // 19: api.customer.v1.FindDuplicateCustomersReply.candidates:type_name -> api.customer.v1.DuplicateCandidate
+	8,  // 20: api.customer.v1.MergeCustomersReply.customer:type_name -> api.customer.v1.GetCustomerReply
+	8,  // 21: api.customer.v1.SearchHit.customer:type_name -> api.customer.v1.GetCustomerReply
+	61, // 22: api.customer.v1.SearchHit.highlights:type_name -> api.customer.v1.SearchHighlight
+	62, // 23: api.customer.v1.SearchCustomersReply.hits:type_name -> api.customer.v1.SearchHit
+	97, // 24: api.customer.v1.CustomerDataBundle.generated_at:type_name -> google.protobuf.Timestamp
+	8,  // 25: api.customer.v1.CustomerDataBundle.customer:type_name -> api.customer.v1.GetCustomerReply
+	67, // 26: api.customer.v1.CustomerDataBundle.history:type_name -> api.customer.v1.CustomerDataChange
+	68, // 27: api.customer.v1.CustomerDataBundle.merges:type_name -> api.customer.v1.CustomerDataMerge
+	74, // 28: api.customer.v1.CustomerDataBundle.consents:type_name -> api.customer.v1.Consent
+	1,  // 29: api.customer.v1.CustomerDataChange.type:type_name -> api.customer.v1.ChangeType
+	98, // 30: api.customer.v1.CustomerDataChange.event:type_name -> google.protobuf.Any
+	97, // 31: api.customer.v1.CustomerDataChange.occurred_at:type_name -> google.protobuf.Timestamp
+	97, // 32: api.customer.v1.CustomerDataMerge.merged_at:type_name -> google.protobuf.Timestamp
+	4,  // 33: api.customer.v1.CustomerErasure.status:type_name -> api.customer.v1.ErasureStatus
+	97, // 34: api.customer.v1.CustomerErasure.requested_at:type_name -> google.protobuf.Timestamp
+	97, // 35: api.customer.v1.CustomerErasure.erase_after:type_name -> google.protobuf.Timestamp
+	97, // 36: api.customer.v1.CustomerErasure.completed_at:type_name -> google.protobuf.Timestamp
+	97, // 37: api.customer.v1.CustomerErasure.cancelled_at:type_name -> google.protobuf.Timestamp
+	69, // 38: api.customer.v1.EraseCustomerReply.erasure:type_name -> api.customer.v1.CustomerErasure
+	69, // 39: api.customer.v1.CancelCustomerErasureReply.erasure:type_name -> api.customer.v1.CustomerErasure
+	5,  // 40: api.customer.v1.Consent.channel:type_name -> api.customer.v1.ConsentChannel
+	6,  // 41: api.customer.v1.Consent.status:type_name -> api.customer.v1.ConsentStatus
+	97, // 42: api.customer.v1.Consent.recorded_at:type_name -> google.protobuf.Timestamp
+	5,  // 43: api.customer.v1.GrantConsentReq.channel:type_name -> api.customer.v1.ConsentChannel
+	74, // 44: api.customer.v1.GrantConsentReply.consent:type_name -> api.customer.v1.Consent
+	5,  // 45: api.customer.v1.WithdrawConsentReq.channel:type_name -> api.customer.v1.ConsentChannel
+	74, // 46: api.customer.v1.WithdrawConsentReply.consent:type_name -> api.customer.v1.Consent
+	74, // 47: api.customer.v1.ListConsentsReply.consents:type_name -> api.customer.v1.Consent
+	5,  // 48: api.customer.v1.ListReachableContactsReq.channel:type_name -> api.customer.v1.ConsentChannel
+	5,  // 49: api.customer.v1.ReachableContact.channel:type_name -> api.customer.v1.ConsentChannel
+	82, // 50: api.customer.v1.ListReachableContactsReply.contacts:type_name -> api.customer.v1.ReachableContact
+	84, // 51: api.customer.v1.BatchGetCustomersReq.keys:type_name -> api.customer.v1.CustomerKey
+	84, // 52: api.customer.v1.BatchGetCustomersResult.key:type_name -> api.customer.v1.CustomerKey
+	8,  // 53: api.customer.v1.BatchGetCustomersResult.customer:type_name -> api.customer.v1.GetCustomerReply
+	86, // 54: api.customer.v1.BatchGetCustomersReply.results:type_name -> api.customer.v1.BatchGetCustomersResult
+	17, // 55: api.customer.v1.BatchUpdateCustomersReq.updates:type_name -> api.customer.v1.UpdateCustomerReq
+	8,  // 56: api.customer.v1.BatchUpdateCustomersResult.customer:type_name -> api.customer.v1.GetCustomerReply
+	89, // 57: api.customer.v1.BatchUpdateCustomersReply.results:type_name -> api.customer.v1.BatchUpdateCustomersResult
+	8,  // 58: api.customer.v1.ActivateCustomerReply.customer:type_name -> api.customer.v1.GetCustomerReply
+	8,  // 59: api.customer.v1.SuspendCustomerReply.customer:type_name -> api.customer.v1.GetCustomerReply
+	8,  // 60: api.customer.v1.CloseCustomerReply.customer:type_name -> api.customer.v1.GetCustomerReply
+	13, // 61: api.customer.v1.Customer.CreateCustomer:input_type -> api.customer.v1.CreateCustomerReq
+	15, // 62: api.customer.v1.Customer.CreateCustomerWithDetails:input_type -> api.customer.v1.CreateCustomerWithDetailsReq
+	27, // 63: api.customer.v1.Customer.AddEmail:input_type -> api.customer.v1.AddEmailReq
+	21, // 64: api.customer.v1.Customer.AddPhoneNumber:input_type -> api.customer.v1.AddPhoneNumberReq
+	17, // 65: api.customer.v1.Customer.UpdateCustomer:input_type -> api.customer.v1.UpdateCustomerReq
+	19, // 66: api.customer.v1.Customer.DeleteCustomer:input_type -> api.customer.v1.DeleteCustomerReq
+	44, // 67: api.customer.v1.Customer.ListCustomer:input_type -> api.customer.v1.ListCustomerReq
+	37, // 68: api.customer.v1.Customer.AddAddress:input_type -> api.customer.v1.AddAddressReq
+	39, // 69: api.customer.v1.Customer.ListAddress:input_type -> api.customer.v1.ListAddressReq
+	23, // 70: api.customer.v1.Customer.ListPhoneNumber:input_type -> api.customer.v1.ListPhoneNumberReq
+	29, // 71: api.customer.v1.Customer.ListEmail:input_type -> api.customer.v1.ListEmailReq
+	7,  // 72: api.customer.v1.Customer.GetCustomer:input_type -> api.customer.v1.GetCustomerReq
+	9,  // 73: api.customer.v1.Customer.GetCustomerByEmail:input_type -> api.customer.v1.GetCustomerByEmailReq
+	11, // 74: api.customer.v1.Customer.GetCustomerByPhoneNumber:input_type -> api.customer.v1.GetCustomerByPhoneNumberReq
+	25, // 75: api.customer.v1.Customer.DeletePhoneNumber:input_type -> api.customer.v1.DeletePhoneNumberReq
+	41, // 76: api.customer.v1.Customer.DeleteAddress:input_type -> api.customer.v1.DeleteAddressReq
+	31, // 77: api.customer.v1.Customer.DeleteEmail:input_type -> api.customer.v1.DeleteEmailReq
+	33, // 78: api.customer.v1.Customer.VerifyEmail:input_type -> api.customer.v1.VerifyEmailReq
+	35, // 79: api.customer.v1.Customer.VerifyPhoneNumber:input_type -> api.customer.v1.VerifyPhoneNumberReq
+	46, // 80: api.customer.v1.Customer.WatchCustomers:input_type -> api.customer.v1.WatchCustomersReq
+	48, // 81: api.customer.v1.Customer.ImportCustomers:input_type -> api.customer.v1.ImportCustomersReq
+	53, // 82: api.customer.v1.Customer.ExportCustomers:input_type -> api.customer.v1.ExportCustomersReq
+	55, // 83: api.customer.v1.Customer.FindDuplicateCustomers:input_type -> api.customer.v1.FindDuplicateCustomersReq
+	58, // 84: api.customer.v1.Customer.MergeCustomers:input_type -> api.customer.v1.MergeCustomersReq
+	60, // 85: api.customer.v1.Customer.SearchCustomers:input_type -> api.customer.v1.SearchCustomersReq
+	64, // 86: api.customer.v1.Customer.ExportCustomerData:input_type -> api.customer.v1.ExportCustomerDataReq
+	70, // 87: api.customer.v1.Customer.EraseCustomer:input_type -> api.customer.v1.EraseCustomerReq
+	72, // 88: api.customer.v1.Customer.CancelCustomerErasure:input_type -> api.customer.v1.CancelCustomerErasureReq
+	75, // 89: api.customer.v1.Customer.GrantConsent:input_type -> api.customer.v1.GrantConsentReq
+	77, // 90: api.customer.v1.Customer.WithdrawConsent:input_type -> api.customer.v1.WithdrawConsentReq
+	79, // 91: api.customer.v1.Customer.ListConsents:input_type -> api.customer.v1.ListConsentsReq
+	81, // 92: api.customer.v1.Customer.ListReachableContacts:input_type -> api.customer.v1.ListReachableContactsReq
+	85, // 93: api.customer.v1.Customer.BatchGetCustomers:input_type -> api.customer.v1.BatchGetCustomersReq
+	88, // 94: api.customer.v1.Customer.BatchUpdateCustomers:input_type -> api.customer.v1.BatchUpdateCustomersReq
+	91, // 95: api.customer.v1.Customer.ActivateCustomer:input_type -> api.customer.v1.ActivateCustomerReq
+	93, // 96: api.customer.v1.Customer.SuspendCustomer:input_type -> api.customer.v1.SuspendCustomerReq
+	95, // 97: api.customer.v1.Customer.CloseCustomer:input_type -> api.customer.v1.CloseCustomerReq
+	14, // 98: api.customer.v1.Customer.CreateCustomer:output_type -> api.customer.v1.CreateCustomerReply
+	16, // 99: api.customer.v1.Customer.CreateCustomerWithDetails:output_type -> api.customer.v1.CreateCustomerWithDetailsReply
+	28, // 100: api.customer.v1.Customer.AddEmail:output_type -> api.customer.v1.AddEmailReply
+	22, // 101: api.customer.v1.Customer.AddPhoneNumber:output_type -> api.customer.v1.AddPhoneNumberReply
+	18, // 102: api.customer.v1.Customer.UpdateCustomer:output_type -> api.customer.v1.UpdateCustomerReply
+	20, // 103: api.customer.v1.Customer.DeleteCustomer:output_type -> api.customer.v1.DeleteCustomerReply
+	45, // 104: api.customer.v1.Customer.ListCustomer:output_type -> api.customer.v1.ListCustomerReply
+	38, // 105: api.customer.v1.Customer.AddAddress:output_type -> api.customer.v1.AddAddressReply
+	40, // 106: api.customer.v1.Customer.ListAddress:output_type -> api.customer.v1.ListAddressReply
+	24, // 107: api.customer.v1.Customer.ListPhoneNumber:output_type -> api.customer.v1.ListPhoneNumberReply
+	30, // 108: api.customer.v1.Customer.ListEmail:output_type -> api.customer.v1.ListEmailReply
+	8,  // 109: api.customer.v1.Customer.GetCustomer:output_type -> api.customer.v1.GetCustomerReply
+	10, // 110: api.customer.v1.Customer.GetCustomerByEmail:output_type -> api.customer.v1.GetCustomerByEmailReply
+	12, // 111: api.customer.v1.Customer.GetCustomerByPhoneNumber:output_type -> api.customer.v1.GetCustomerByPhoneNumberReply
+	26, // 112: api.customer.v1.Customer.DeletePhoneNumber:output_type -> api.customer.v1.DeletePhoneNumberReply
+	42, // 113: api.customer.v1.Customer.DeleteAddress:output_type -> api.customer.v1.DeleteAddressReply
+	32, // 114: api.customer.v1.Customer.DeleteEmail:output_type -> api.customer.v1.DeleteEmailReply
+	34, // 115: api.customer.v1.Customer.VerifyEmail:output_type -> api.customer.v1.VerifyEmailReply
+	36, // 116: api.customer.v1.Customer.VerifyPhoneNumber:output_type -> api.customer.v1.VerifyPhoneNumberReply
+	47, // 117: api.customer.v1.Customer.WatchCustomers:output_type -> api.customer.v1.WatchCustomersReply
+	52, // 118: api.customer.v1.Customer.ImportCustomers:output_type -> api.customer.v1.ImportCustomersReply
+	54, // 119: api.customer.v1.Customer.ExportCustomers:output_type -> api.customer.v1.ExportCustomersReply
+	57, // 120: api.customer.v1.Customer.FindDuplicateCustomers:output_type -> api.customer.v1.FindDuplicateCustomersReply
+	59, // 121: api.customer.v1.Customer.MergeCustomers:output_type -> api.customer.v1.MergeCustomersReply
+	63, // 122: api.customer.v1.Customer.SearchCustomers:output_type -> api.customer.v1.SearchCustomersReply
+	65, // 123: api.customer.v1.Customer.ExportCustomerData:output_type -> api.customer.v1.ExportCustomerDataReply
+	71, // 124: api.customer.v1.Customer.EraseCustomer:output_type -> api.customer.v1.EraseCustomerReply
+	73, // 125: api.customer.v1.Customer.CancelCustomerErasure:output_type -> api.customer.v1.CancelCustomerErasureReply
+	76, // 126: api.customer.v1.Customer.GrantConsent:output_type -> api.customer.v1.GrantConsentReply
+	78, // 127: api.customer.v1.Customer.WithdrawConsent:output_type -> api.customer.v1.WithdrawConsentReply
+	80, // 128: api.customer.v1.Customer.ListConsents:output_type -> api.customer.v1.ListConsentsReply
+	83, // 129: api.customer.v1.Customer.ListReachableContacts:output_type -> api.customer.v1.ListReachableContactsReply
+	87, // 130: api.customer.v1.Customer.BatchGetCustomers:output_type -> api.customer.v1.BatchGetCustomersReply
+	90, // 131: api.customer.v1.Customer.BatchUpdateCustomers:output_type -> api.customer.v1.BatchUpdateCustomersReply
+	92, // 132: api.customer.v1.Customer.ActivateCustomer:output_type -> api.customer.v1.ActivateCustomerReply
+	94, // 133: api.customer.v1.Customer.SuspendCustomer:output_type -> api.customer.v1.SuspendCustomerReply
+	96, // 134: api.customer.v1.Customer.CloseCustomer:output_type -> api.customer.v1.CloseCustomerReply
+	98, // [98:135] is the sub-list for method output_type
+	61, // [61:98] is the sub-list for method input_type
+	61, // [61:61] is the sub-list for extension type_name
+	61, // [61:61] is the sub-list for extension extendee
+	0,  // [0:61] is the sub-list for field type_name
 }
 
 func init() { file_api_customer_v1_customer_proto_init() }
@@ -1947,18 +6500,28 @@ func file_api_customer_v1_customer_proto_init() {
 	if File_api_customer_v1_customer_proto != nil {
 		return
 	}
+	file_api_customer_v1_customer_proto_msgTypes[41].OneofWrappers = []any{
+		(*ImportCustomersReq_Options)(nil),
+		(*ImportCustomersReq_Row)(nil),
+	}
+	file_api_customer_v1_customer_proto_msgTypes[77].OneofWrappers = []any{
+		(*CustomerKey_Id)(nil),
+		(*CustomerKey_Email)(nil),
+		(*CustomerKey_PhoneNumber)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_customer_v1_customer_proto_rawDesc), len(file_api_customer_v1_customer_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   32,
+			NumEnums:      7,
+			NumMessages:   90,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_customer_v1_customer_proto_goTypes,
 		DependencyIndexes: file_api_customer_v1_customer_proto_depIdxs,
+		EnumInfos:         file_api_customer_v1_customer_proto_enumTypes,
 		MessageInfos:      file_api_customer_v1_customer_proto_msgTypes,
 	}.Build()
 	File_api_customer_v1_customer_proto = out.File
@@ -19,22 +19,43 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Customer_CreateCustomer_FullMethodName           = "/api.customer.v1.Customer/CreateCustomer"
-	Customer_AddEmail_FullMethodName                 = "/api.customer.v1.Customer/AddEmail"
-	Customer_AddPhoneNumber_FullMethodName           = "/api.customer.v1.Customer/AddPhoneNumber"
-	Customer_UpdateCustomer_FullMethodName           = "/api.customer.v1.Customer/UpdateCustomer"
-	Customer_DeleteCustomer_FullMethodName           = "/api.customer.v1.Customer/DeleteCustomer"
-	Customer_ListCustomer_FullMethodName             = "/api.customer.v1.Customer/ListCustomer"
-	Customer_AddAddress_FullMethodName               = "/api.customer.v1.Customer/AddAddress"
-	Customer_ListAddress_FullMethodName              = "/api.customer.v1.Customer/ListAddress"
-	Customer_ListPhoneNumber_FullMethodName          = "/api.customer.v1.Customer/ListPhoneNumber"
-	Customer_ListEmail_FullMethodName                = "/api.customer.v1.Customer/ListEmail"
-	Customer_GetCustomer_FullMethodName              = "/api.customer.v1.Customer/GetCustomer"
-	Customer_GetCustomerByEmail_FullMethodName       = "/api.customer.v1.Customer/GetCustomerByEmail"
-	Customer_GetCustomerByPhoneNumber_FullMethodName = "/api.customer.v1.Customer/GetCustomerByPhoneNumber"
-	Customer_DeletePhoneNumber_FullMethodName        = "/api.customer.v1.Customer/DeletePhoneNumber"
-	Customer_DeleteAddress_FullMethodName            = "/api.customer.v1.Customer/DeleteAddress"
-	Customer_DeleteEmail_FullMethodName              = "/api.customer.v1.Customer/DeleteEmail"
+	Customer_CreateCustomer_FullMethodName            = "/api.customer.v1.Customer/CreateCustomer"
+	Customer_CreateCustomerWithDetails_FullMethodName = "/api.customer.v1.Customer/CreateCustomerWithDetails"
+	Customer_AddEmail_FullMethodName                  = "/api.customer.v1.Customer/AddEmail"
+	Customer_AddPhoneNumber_FullMethodName            = "/api.customer.v1.Customer/AddPhoneNumber"
+	Customer_UpdateCustomer_FullMethodName            = "/api.customer.v1.Customer/UpdateCustomer"
+	Customer_DeleteCustomer_FullMethodName            = "/api.customer.v1.Customer/DeleteCustomer"
+	Customer_ListCustomer_FullMethodName              = "/api.customer.v1.Customer/ListCustomer"
+	Customer_AddAddress_FullMethodName                = "/api.customer.v1.Customer/AddAddress"
+	Customer_ListAddress_FullMethodName               = "/api.customer.v1.Customer/ListAddress"
+	Customer_ListPhoneNumber_FullMethodName           = "/api.customer.v1.Customer/ListPhoneNumber"
+	Customer_ListEmail_FullMethodName                 = "/api.customer.v1.Customer/ListEmail"
+	Customer_GetCustomer_FullMethodName               = "/api.customer.v1.Customer/GetCustomer"
+	Customer_GetCustomerByEmail_FullMethodName        = "/api.customer.v1.Customer/GetCustomerByEmail"
+	Customer_GetCustomerByPhoneNumber_FullMethodName  = "/api.customer.v1.Customer/GetCustomerByPhoneNumber"
+	Customer_DeletePhoneNumber_FullMethodName         = "/api.customer.v1.Customer/DeletePhoneNumber"
+	Customer_DeleteAddress_FullMethodName             = "/api.customer.v1.Customer/DeleteAddress"
+	Customer_DeleteEmail_FullMethodName               = "/api.customer.v1.Customer/DeleteEmail"
+	Customer_VerifyEmail_FullMethodName               = "/api.customer.v1.Customer/VerifyEmail"
+	Customer_VerifyPhoneNumber_FullMethodName         = "/api.customer.v1.Customer/VerifyPhoneNumber"
+	Customer_WatchCustomers_FullMethodName            = "/api.customer.v1.Customer/WatchCustomers"
+	Customer_ImportCustomers_FullMethodName           = "/api.customer.v1.Customer/ImportCustomers"
+	Customer_ExportCustomers_FullMethodName           = "/api.customer.v1.Customer/ExportCustomers"
+	Customer_FindDuplicateCustomers_FullMethodName    = "/api.customer.v1.Customer/FindDuplicateCustomers"
+	Customer_MergeCustomers_FullMethodName            = "/api.customer.v1.Customer/MergeCustomers"
+	Customer_SearchCustomers_FullMethodName           = "/api.customer.v1.Customer/SearchCustomers"
+	Customer_ExportCustomerData_FullMethodName        = "/api.customer.v1.Customer/ExportCustomerData"
+	Customer_EraseCustomer_FullMethodName             = "/api.customer.v1.Customer/EraseCustomer"
+	Customer_CancelCustomerErasure_FullMethodName     = "/api.customer.v1.Customer/CancelCustomerErasure"
+	Customer_GrantConsent_FullMethodName              = "/api.customer.v1.Customer/GrantConsent"
+	Customer_WithdrawConsent_FullMethodName           = "/api.customer.v1.Customer/WithdrawConsent"
+	Customer_ListConsents_FullMethodName              = "/api.customer.v1.Customer/ListConsents"
+	Customer_ListReachableContacts_FullMethodName     = "/api.customer.v1.Customer/ListReachableContacts"
+	Customer_BatchGetCustomers_FullMethodName         = "/api.customer.v1.Customer/BatchGetCustomers"
+	Customer_BatchUpdateCustomers_FullMethodName      = "/api.customer.v1.Customer/BatchUpdateCustomers"
+	Customer_ActivateCustomer_FullMethodName          = "/api.customer.v1.Customer/ActivateCustomer"
+	Customer_SuspendCustomer_FullMethodName           = "/api.customer.v1.Customer/SuspendCustomer"
+	Customer_CloseCustomer_FullMethodName             = "/api.customer.v1.Customer/CloseCustomer"
 )
 
 // CustomerClient is the client API for Customer service.
@@ -42,6 +63,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CustomerClient interface {
 	CreateCustomer(ctx context.Context, in *CreateCustomerReq, opts ...grpc.CallOption) (*CreateCustomerReply, error)
+	CreateCustomerWithDetails(ctx context.Context, in *CreateCustomerWithDetailsReq, opts ...grpc.CallOption) (*CreateCustomerWithDetailsReply, error)
 	AddEmail(ctx context.Context, in *AddEmailReq, opts ...grpc.CallOption) (*AddEmailReply, error)
 	AddPhoneNumber(ctx context.Context, in *AddPhoneNumberReq, opts ...grpc.CallOption) (*AddPhoneNumberReply, error)
 	UpdateCustomer(ctx context.Context, in *UpdateCustomerReq, opts ...grpc.CallOption) (*UpdateCustomerReply, error)
@@ -57,6 +79,78 @@ type CustomerClient interface {
 	DeletePhoneNumber(ctx context.Context, in *DeletePhoneNumberReq, opts ...grpc.CallOption) (*DeletePhoneNumberReply, error)
 	DeleteAddress(ctx context.Context, in *DeleteAddressReq, opts ...grpc.CallOption) (*DeleteAddressReply, error)
 	DeleteEmail(ctx context.Context, in *DeleteEmailReq, opts ...grpc.CallOption) (*DeleteEmailReply, error)
+	// VerifyEmail records that the customer proved to own the email.
+	// Emails never verified are removed once the unverified contacts
+	// retention period is over.
+	VerifyEmail(ctx context.Context, in *VerifyEmailReq, opts ...grpc.CallOption) (*VerifyEmailReply, error)
+	// VerifyPhoneNumber does the same for a phone number.
+	VerifyPhoneNumber(ctx context.Context, in *VerifyPhoneNumberReq, opts ...grpc.CallOption) (*VerifyPhoneNumberReply, error)
+	// WatchCustomers streams customer changes as they are committed. Pass
+	// the last sequence you saw as after_sequence to resume after a
+	// reconnect without missing changes.
+	WatchCustomers(ctx context.Context, in *WatchCustomersReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCustomersReply], error)
+	// ImportCustomers bulk-creates customers. Send ImportOptions first
+	// (optional), then one row per customer. Rows are reported back while
+	// the import runs, in replies of at most 500 rows, so the rows of the
+	// committed batches are known even when the import fails midway.
+	ImportCustomers(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportCustomersReq, ImportCustomersReply], error)
+	// ExportCustomers streams the customers matching the filter as a file
+	// in the requested format, split into chunks.
+	ExportCustomers(ctx context.Context, in *ExportCustomersReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCustomersReply], error)
+	// FindDuplicateCustomers lists pairs of customers that probably are the
+	// same person, best match first.
+	FindDuplicateCustomers(ctx context.Context, in *FindDuplicateCustomersReq, opts ...grpc.CallOption) (*FindDuplicateCustomersReply, error)
+	// MergeCustomers moves the contact points of the duplicates onto the
+	// survivor and removes the duplicates. GetCustomer on a merged id
+	// returns the survivor afterwards.
+	MergeCustomers(ctx context.Context, in *MergeCustomersReq, opts ...grpc.CallOption) (*MergeCustomersReply, error)
+	// SearchCustomers finds customers by partial, possibly misspelled name,
+	// email, phone number or address, best match first.
+	SearchCustomers(ctx context.Context, in *SearchCustomersReq, opts ...grpc.CallOption) (*SearchCustomersReply, error)
+	// ExportCustomerData returns everything stored about one customer as a
+	// JSON bundle with its checksum, for data subject access requests.
+	// The checksum is kept on record to prove what was delivered.
+	ExportCustomerData(ctx context.Context, in *ExportCustomerDataReq, opts ...grpc.CallOption) (*ExportCustomerDataReply, error)
+	// EraseCustomer replaces the personal data of a customer, its contact
+	// points and its history with irreversible tokens, keeping the rows.
+	// With a grace period configured the erasure is scheduled and can be
+	// cancelled until it runs.
+	EraseCustomer(ctx context.Context, in *EraseCustomerReq, opts ...grpc.CallOption) (*EraseCustomerReply, error)
+	// CancelCustomerErasure cancels a scheduled erasure.
+	CancelCustomerErasure(ctx context.Context, in *CancelCustomerErasureReq, opts ...grpc.CallOption) (*CancelCustomerErasureReply, error)
+	// GrantConsent records that a customer agreed to be contacted on a
+	// channel for a purpose, on all contact points of the channel or on
+	// one of them. Consents are never overwritten: every grant and
+	// withdrawal is kept and the latest one counts.
+	GrantConsent(ctx context.Context, in *GrantConsentReq, opts ...grpc.CallOption) (*GrantConsentReply, error)
+	// WithdrawConsent records that a customer no longer agrees to be
+	// contacted on a channel for a purpose.
+	WithdrawConsent(ctx context.Context, in *WithdrawConsentReq, opts ...grpc.CallOption) (*WithdrawConsentReply, error)
+	// ListConsents returns the consents of a customer currently in force,
+	// or their whole history.
+	ListConsents(ctx context.Context, in *ListConsentsReq, opts ...grpc.CallOption) (*ListConsentsReply, error)
+	// ListReachableContacts returns the contact points that may currently
+	// be contacted for a purpose.
+	ListReachableContacts(ctx context.Context, in *ListReachableContactsReq, opts ...grpc.CallOption) (*ListReachableContactsReply, error)
+	// BatchGetCustomers looks up to 500 customers at once by id, email or
+	// phone number. Results come in the order of the keys; keys no
+	// customer matches are marked not found instead of failing the call.
+	BatchGetCustomers(ctx context.Context, in *BatchGetCustomersReq, opts ...grpc.CallOption) (*BatchGetCustomersReply, error)
+	// BatchUpdateCustomers applies up to 500 updates like UpdateCustomer.
+	// By default they apply all or none: the first failing update fails
+	// the call. In best effort mode every update applies on its own and
+	// the reply reports the ones that failed.
+	BatchUpdateCustomers(ctx context.Context, in *BatchUpdateCustomersReq, opts ...grpc.CallOption) (*BatchUpdateCustomersReply, error)
+	// ActivateCustomer makes a prospect a customer, or lifts the
+	// suspension of a suspended one. A reason is required.
+	ActivateCustomer(ctx context.Context, in *ActivateCustomerReq, opts ...grpc.CallOption) (*ActivateCustomerReply, error)
+	// SuspendCustomer suspends an active customer until it is activated
+	// again. A reason is required.
+	SuspendCustomer(ctx context.Context, in *SuspendCustomerReq, opts ...grpc.CallOption) (*SuspendCustomerReply, error)
+	// CloseCustomer closes a customer for good. Closed customers keep
+	// their data but refuse updates and new contact points. A reason is
+	// required.
+	CloseCustomer(ctx context.Context, in *CloseCustomerReq, opts ...grpc.CallOption) (*CloseCustomerReply, error)
 }
 
 type customerClient struct {
@@ -77,6 +171,16 @@ func (c *customerClient) CreateCustomer(ctx context.Context, in *CreateCustomerR
 	return out, nil
 }
 
+func (c *customerClient) CreateCustomerWithDetails(ctx context.Context, in *CreateCustomerWithDetailsReq, opts ...grpc.CallOption) (*CreateCustomerWithDetailsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCustomerWithDetailsReply)
+	err := c.cc.Invoke(ctx, Customer_CreateCustomerWithDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *customerClient) AddEmail(ctx context.Context, in *AddEmailReq, opts ...grpc.CallOption) (*AddEmailReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddEmailReply)
@@ -227,11 +331,233 @@ func (c *customerClient) DeleteEmail(ctx context.Context, in *DeleteEmailReq, op
 	return out, nil
 }
 
+func (c *customerClient) VerifyEmail(ctx context.Context, in *VerifyEmailReq, opts ...grpc.CallOption) (*VerifyEmailReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyEmailReply)
+	err := c.cc.Invoke(ctx, Customer_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) VerifyPhoneNumber(ctx context.Context, in *VerifyPhoneNumberReq, opts ...grpc.CallOption) (*VerifyPhoneNumberReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyPhoneNumberReply)
+	err := c.cc.Invoke(ctx, Customer_VerifyPhoneNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) WatchCustomers(ctx context.Context, in *WatchCustomersReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchCustomersReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Customer_ServiceDesc.Streams[0], Customer_WatchCustomers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCustomersReq, WatchCustomersReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Customer_WatchCustomersClient = grpc.ServerStreamingClient[WatchCustomersReply]
+
+func (c *customerClient) ImportCustomers(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ImportCustomersReq, ImportCustomersReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Customer_ServiceDesc.Streams[1], Customer_ImportCustomers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportCustomersReq, ImportCustomersReply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Customer_ImportCustomersClient = grpc.BidiStreamingClient[ImportCustomersReq, ImportCustomersReply]
+
+func (c *customerClient) ExportCustomers(ctx context.Context, in *ExportCustomersReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportCustomersReply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Customer_ServiceDesc.Streams[2], Customer_ExportCustomers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCustomersReq, ExportCustomersReply]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Customer_ExportCustomersClient = grpc.ServerStreamingClient[ExportCustomersReply]
+
+func (c *customerClient) FindDuplicateCustomers(ctx context.Context, in *FindDuplicateCustomersReq, opts ...grpc.CallOption) (*FindDuplicateCustomersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindDuplicateCustomersReply)
+	err := c.cc.Invoke(ctx, Customer_FindDuplicateCustomers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) MergeCustomers(ctx context.Context, in *MergeCustomersReq, opts ...grpc.CallOption) (*MergeCustomersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeCustomersReply)
+	err := c.cc.Invoke(ctx, Customer_MergeCustomers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) SearchCustomers(ctx context.Context, in *SearchCustomersReq, opts ...grpc.CallOption) (*SearchCustomersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCustomersReply)
+	err := c.cc.Invoke(ctx, Customer_SearchCustomers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ExportCustomerData(ctx context.Context, in *ExportCustomerDataReq, opts ...grpc.CallOption) (*ExportCustomerDataReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportCustomerDataReply)
+	err := c.cc.Invoke(ctx, Customer_ExportCustomerData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) EraseCustomer(ctx context.Context, in *EraseCustomerReq, opts ...grpc.CallOption) (*EraseCustomerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseCustomerReply)
+	err := c.cc.Invoke(ctx, Customer_EraseCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) CancelCustomerErasure(ctx context.Context, in *CancelCustomerErasureReq, opts ...grpc.CallOption) (*CancelCustomerErasureReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelCustomerErasureReply)
+	err := c.cc.Invoke(ctx, Customer_CancelCustomerErasure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) GrantConsent(ctx context.Context, in *GrantConsentReq, opts ...grpc.CallOption) (*GrantConsentReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantConsentReply)
+	err := c.cc.Invoke(ctx, Customer_GrantConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) WithdrawConsent(ctx context.Context, in *WithdrawConsentReq, opts ...grpc.CallOption) (*WithdrawConsentReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WithdrawConsentReply)
+	err := c.cc.Invoke(ctx, Customer_WithdrawConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ListConsents(ctx context.Context, in *ListConsentsReq, opts ...grpc.CallOption) (*ListConsentsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConsentsReply)
+	err := c.cc.Invoke(ctx, Customer_ListConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ListReachableContacts(ctx context.Context, in *ListReachableContactsReq, opts ...grpc.CallOption) (*ListReachableContactsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReachableContactsReply)
+	err := c.cc.Invoke(ctx, Customer_ListReachableContacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) BatchGetCustomers(ctx context.Context, in *BatchGetCustomersReq, opts ...grpc.CallOption) (*BatchGetCustomersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetCustomersReply)
+	err := c.cc.Invoke(ctx, Customer_BatchGetCustomers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) BatchUpdateCustomers(ctx context.Context, in *BatchUpdateCustomersReq, opts ...grpc.CallOption) (*BatchUpdateCustomersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateCustomersReply)
+	err := c.cc.Invoke(ctx, Customer_BatchUpdateCustomers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) ActivateCustomer(ctx context.Context, in *ActivateCustomerReq, opts ...grpc.CallOption) (*ActivateCustomerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivateCustomerReply)
+	err := c.cc.Invoke(ctx, Customer_ActivateCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) SuspendCustomer(ctx context.Context, in *SuspendCustomerReq, opts ...grpc.CallOption) (*SuspendCustomerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuspendCustomerReply)
+	err := c.cc.Invoke(ctx, Customer_SuspendCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *customerClient) CloseCustomer(ctx context.Context, in *CloseCustomerReq, opts ...grpc.CallOption) (*CloseCustomerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseCustomerReply)
+	err := c.cc.Invoke(ctx, Customer_CloseCustomer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CustomerServer is the server API for Customer service.
 // All implementations must embed UnimplementedCustomerServer
 // for forward compatibility.
 type CustomerServer interface {
 	CreateCustomer(context.Context, *CreateCustomerReq) (*CreateCustomerReply, error)
+	CreateCustomerWithDetails(context.Context, *CreateCustomerWithDetailsReq) (*CreateCustomerWithDetailsReply, error)
 	AddEmail(context.Context, *AddEmailReq) (*AddEmailReply, error)
 	AddPhoneNumber(context.Context, *AddPhoneNumberReq) (*AddPhoneNumberReply, error)
 	UpdateCustomer(context.Context, *UpdateCustomerReq) (*UpdateCustomerReply, error)
@@ -247,6 +573,78 @@ type CustomerServer interface {
 	DeletePhoneNumber(context.Context, *DeletePhoneNumberReq) (*DeletePhoneNumberReply, error)
 	DeleteAddress(context.Context, *DeleteAddressReq) (*DeleteAddressReply, error)
 	DeleteEmail(context.Context, *DeleteEmailReq) (*DeleteEmailReply, error)
+	// VerifyEmail records that the customer proved to own the email.
+	// Emails never verified are removed once the unverified contacts
+	// retention period is over.
+	VerifyEmail(context.Context, *VerifyEmailReq) (*VerifyEmailReply, error)
+	// VerifyPhoneNumber does the same for a phone number.
+	VerifyPhoneNumber(context.Context, *VerifyPhoneNumberReq) (*VerifyPhoneNumberReply, error)
+	// WatchCustomers streams customer changes as they are committed. Pass
+	// the last sequence you saw as after_sequence to resume after a
+	// reconnect without missing changes.
+	WatchCustomers(*WatchCustomersReq, grpc.ServerStreamingServer[WatchCustomersReply]) error
+	// ImportCustomers bulk-creates customers. Send ImportOptions first
+	// (optional), then one row per customer. Rows are reported back while
+	// the import runs, in replies of at most 500 rows, so the rows of the
+	// committed batches are known even when the import fails midway.
+	ImportCustomers(grpc.BidiStreamingServer[ImportCustomersReq, ImportCustomersReply]) error
+	// ExportCustomers streams the customers matching the filter as a file
+	// in the requested format, split into chunks.
+	ExportCustomers(*ExportCustomersReq, grpc.ServerStreamingServer[ExportCustomersReply]) error
+	// FindDuplicateCustomers lists pairs of customers that probably are the
+	// same person, best match first.
+	FindDuplicateCustomers(context.Context, *FindDuplicateCustomersReq) (*FindDuplicateCustomersReply, error)
+	// MergeCustomers moves the contact points of the duplicates onto the
+	// survivor and removes the duplicates. GetCustomer on a merged id
+	// returns the survivor afterwards.
+	MergeCustomers(context.Context, *MergeCustomersReq) (*MergeCustomersReply, error)
+	// SearchCustomers finds customers by partial, possibly misspelled name,
+	// email, phone number or address, best match first.
+	SearchCustomers(context.Context, *SearchCustomersReq) (*SearchCustomersReply, error)
+	// ExportCustomerData returns everything stored about one customer as a
+	// JSON bundle with its checksum, for data subject access requests.
+	// The checksum is kept on record to prove what was delivered.
+	ExportCustomerData(context.Context, *ExportCustomerDataReq) (*ExportCustomerDataReply, error)
+	// EraseCustomer replaces the personal data of a customer, its contact
+	// points and its history with irreversible tokens, keeping the rows.
+	// With a grace period configured the erasure is scheduled and can be
+	// cancelled until it runs.
+	EraseCustomer(context.Context, *EraseCustomerReq) (*EraseCustomerReply, error)
+	// CancelCustomerErasure cancels a scheduled erasure.
+	CancelCustomerErasure(context.Context, *CancelCustomerErasureReq) (*CancelCustomerErasureReply, error)
+	// GrantConsent records that a customer agreed to be contacted on a
+	// channel for a purpose, on all contact points of the channel or on
+	// one of them. Consents are never overwritten: every grant and
+	// withdrawal is kept and the latest one counts.
+	GrantConsent(context.Context, *GrantConsentReq) (*GrantConsentReply, error)
+	// WithdrawConsent records that a customer no longer agrees to be
+	// contacted on a channel for a purpose.
+	WithdrawConsent(context.Context, *WithdrawConsentReq) (*WithdrawConsentReply, error)
+	// ListConsents returns the consents of a customer currently in force,
+	// or their whole history.
+	ListConsents(context.Context, *ListConsentsReq) (*ListConsentsReply, error)
+	// ListReachableContacts returns the contact points that may currently
+	// be contacted for a purpose.
+	ListReachableContacts(context.Context, *ListReachableContactsReq) (*ListReachableContactsReply, error)
+	// BatchGetCustomers looks up to 500 customers at once by id, email or
+	// phone number. Results come in the order of the keys; keys no
+	// customer matches are marked not found instead of failing the call.
+	BatchGetCustomers(context.Context, *BatchGetCustomersReq) (*BatchGetCustomersReply, error)
+	// BatchUpdateCustomers applies up to 500 updates like UpdateCustomer.
+	// By default they apply all or none: the first failing update fails
+	// the call. In best effort mode every update applies on its own and
+	// the reply reports the ones that failed.
+	BatchUpdateCustomers(context.Context, *BatchUpdateCustomersReq) (*BatchUpdateCustomersReply, error)
+	// ActivateCustomer makes a prospect a customer, or lifts the
+	// suspension of a suspended one. A reason is required.
+	ActivateCustomer(context.Context, *ActivateCustomerReq) (*ActivateCustomerReply, error)
+	// SuspendCustomer suspends an active customer until it is activated
+	// again. A reason is required.
+	SuspendCustomer(context.Context, *SuspendCustomerReq) (*SuspendCustomerReply, error)
+	// CloseCustomer closes a customer for good. Closed customers keep
+	// their data but refuse updates and new contact points. A reason is
+	// required.
+	CloseCustomer(context.Context, *CloseCustomerReq) (*CloseCustomerReply, error)
 	mustEmbedUnimplementedCustomerServer()
 }
 
@@ -260,6 +658,9 @@ type UnimplementedCustomerServer struct{}
 func (UnimplementedCustomerServer) CreateCustomer(context.Context, *CreateCustomerReq) (*CreateCustomerReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateCustomer not implemented")
 }
+func (UnimplementedCustomerServer) CreateCustomerWithDetails(context.Context, *CreateCustomerWithDetailsReq) (*CreateCustomerWithDetailsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCustomerWithDetails not implemented")
+}
 func (UnimplementedCustomerServer) AddEmail(context.Context, *AddEmailReq) (*AddEmailReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddEmail not implemented")
 }
@@ -305,6 +706,66 @@ func (UnimplementedCustomerServer) DeleteAddress(context.Context, *DeleteAddress
 func (UnimplementedCustomerServer) DeleteEmail(context.Context, *DeleteEmailReq) (*DeleteEmailReply, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteEmail not implemented")
 }
+func (UnimplementedCustomerServer) VerifyEmail(context.Context, *VerifyEmailReq) (*VerifyEmailReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedCustomerServer) VerifyPhoneNumber(context.Context, *VerifyPhoneNumberReq) (*VerifyPhoneNumberReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyPhoneNumber not implemented")
+}
+func (UnimplementedCustomerServer) WatchCustomers(*WatchCustomersReq, grpc.ServerStreamingServer[WatchCustomersReply]) error {
+	return status.Error(codes.Unimplemented, "method WatchCustomers not implemented")
+}
+func (UnimplementedCustomerServer) ImportCustomers(grpc.BidiStreamingServer[ImportCustomersReq, ImportCustomersReply]) error {
+	return status.Error(codes.Unimplemented, "method ImportCustomers not implemented")
+}
+func (UnimplementedCustomerServer) ExportCustomers(*ExportCustomersReq, grpc.ServerStreamingServer[ExportCustomersReply]) error {
+	return status.Error(codes.Unimplemented, "method ExportCustomers not implemented")
+}
+func (UnimplementedCustomerServer) FindDuplicateCustomers(context.Context, *FindDuplicateCustomersReq) (*FindDuplicateCustomersReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindDuplicateCustomers not implemented")
+}
+func (UnimplementedCustomerServer) MergeCustomers(context.Context, *MergeCustomersReq) (*MergeCustomersReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeCustomers not implemented")
+}
+func (UnimplementedCustomerServer) SearchCustomers(context.Context, *SearchCustomersReq) (*SearchCustomersReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchCustomers not implemented")
+}
+func (UnimplementedCustomerServer) ExportCustomerData(context.Context, *ExportCustomerDataReq) (*ExportCustomerDataReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportCustomerData not implemented")
+}
+func (UnimplementedCustomerServer) EraseCustomer(context.Context, *EraseCustomerReq) (*EraseCustomerReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseCustomer not implemented")
+}
+func (UnimplementedCustomerServer) CancelCustomerErasure(context.Context, *CancelCustomerErasureReq) (*CancelCustomerErasureReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelCustomerErasure not implemented")
+}
+func (UnimplementedCustomerServer) GrantConsent(context.Context, *GrantConsentReq) (*GrantConsentReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method GrantConsent not implemented")
+}
+func (UnimplementedCustomerServer) WithdrawConsent(context.Context, *WithdrawConsentReq) (*WithdrawConsentReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method WithdrawConsent not implemented")
+}
+func (UnimplementedCustomerServer) ListConsents(context.Context, *ListConsentsReq) (*ListConsentsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConsents not implemented")
+}
+func (UnimplementedCustomerServer) ListReachableContacts(context.Context, *ListReachableContactsReq) (*ListReachableContactsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListReachableContacts not implemented")
+}
+func (UnimplementedCustomerServer) BatchGetCustomers(context.Context, *BatchGetCustomersReq) (*BatchGetCustomersReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetCustomers not implemented")
+}
+func (UnimplementedCustomerServer) BatchUpdateCustomers(context.Context, *BatchUpdateCustomersReq) (*BatchUpdateCustomersReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateCustomers not implemented")
+}
+func (UnimplementedCustomerServer) ActivateCustomer(context.Context, *ActivateCustomerReq) (*ActivateCustomerReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ActivateCustomer not implemented")
+}
+func (UnimplementedCustomerServer) SuspendCustomer(context.Context, *SuspendCustomerReq) (*SuspendCustomerReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuspendCustomer not implemented")
+}
+func (UnimplementedCustomerServer) CloseCustomer(context.Context, *CloseCustomerReq) (*CloseCustomerReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloseCustomer not implemented")
+}
 func (UnimplementedCustomerServer) mustEmbedUnimplementedCustomerServer() {}
 func (UnimplementedCustomerServer) testEmbeddedByValue()                  {}
 
@@ -344,6 +805,24 @@ func _Customer_CreateCustomer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_CreateCustomerWithDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCustomerWithDetailsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).CreateCustomerWithDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_CreateCustomerWithDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).CreateCustomerWithDetails(ctx, req.(*CreateCustomerWithDetailsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Customer_AddEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddEmailReq)
 	if err := dec(in); err != nil {
@@ -614,6 +1093,341 @@ func _Customer_DeleteEmail_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Customer_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).VerifyEmail(ctx, req.(*VerifyEmailReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_VerifyPhoneNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPhoneNumberReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).VerifyPhoneNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_VerifyPhoneNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).VerifyPhoneNumber(ctx, req.(*VerifyPhoneNumberReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_WatchCustomers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCustomersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CustomerServer).WatchCustomers(m, &grpc.GenericServerStream[WatchCustomersReq, WatchCustomersReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Customer_WatchCustomersServer = grpc.ServerStreamingServer[WatchCustomersReply]
+
+func _Customer_ImportCustomers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CustomerServer).ImportCustomers(&grpc.GenericServerStream[ImportCustomersReq, ImportCustomersReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Customer_ImportCustomersServer = grpc.BidiStreamingServer[ImportCustomersReq, ImportCustomersReply]
+
+func _Customer_ExportCustomers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCustomersReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CustomerServer).ExportCustomers(m, &grpc.GenericServerStream[ExportCustomersReq, ExportCustomersReply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Customer_ExportCustomersServer = grpc.ServerStreamingServer[ExportCustomersReply]
+
+func _Customer_FindDuplicateCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicateCustomersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).FindDuplicateCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_FindDuplicateCustomers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).FindDuplicateCustomers(ctx, req.(*FindDuplicateCustomersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_MergeCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCustomersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).MergeCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_MergeCustomers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).MergeCustomers(ctx, req.(*MergeCustomersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_SearchCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCustomersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).SearchCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_SearchCustomers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).SearchCustomers(ctx, req.(*SearchCustomersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ExportCustomerData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCustomerDataReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ExportCustomerData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_ExportCustomerData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ExportCustomerData(ctx, req.(*ExportCustomerDataReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_EraseCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).EraseCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_EraseCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).EraseCustomer(ctx, req.(*EraseCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_CancelCustomerErasure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCustomerErasureReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).CancelCustomerErasure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_CancelCustomerErasure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).CancelCustomerErasure(ctx, req.(*CancelCustomerErasureReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_GrantConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantConsentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).GrantConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_GrantConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).GrantConsent(ctx, req.(*GrantConsentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_WithdrawConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawConsentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).WithdrawConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_WithdrawConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).WithdrawConsent(ctx, req.(*WithdrawConsentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ListConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConsentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ListConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_ListConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ListConsents(ctx, req.(*ListConsentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ListReachableContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReachableContactsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ListReachableContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_ListReachableContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ListReachableContacts(ctx, req.(*ListReachableContactsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_BatchGetCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetCustomersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).BatchGetCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_BatchGetCustomers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).BatchGetCustomers(ctx, req.(*BatchGetCustomersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_BatchUpdateCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateCustomersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).BatchUpdateCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_BatchUpdateCustomers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).BatchUpdateCustomers(ctx, req.(*BatchUpdateCustomersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_ActivateCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).ActivateCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_ActivateCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).ActivateCustomer(ctx, req.(*ActivateCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_SuspendCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).SuspendCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_SuspendCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).SuspendCustomer(ctx, req.(*SuspendCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Customer_CloseCustomer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseCustomerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CustomerServer).CloseCustomer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Customer_CloseCustomer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CustomerServer).CloseCustomer(ctx, req.(*CloseCustomerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Customer_ServiceDesc is the grpc.ServiceDesc for Customer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -625,6 +1439,10 @@ var Customer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateCustomer",
 			Handler:    _Customer_CreateCustomer_Handler,
 		},
+		{
+			MethodName: "CreateCustomerWithDetails",
+			Handler:    _Customer_CreateCustomerWithDetails_Handler,
+		},
 		{
 			MethodName: "AddEmail",
 			Handler:    _Customer_AddEmail_Handler,
@@ -685,7 +1503,92 @@ var Customer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEmail",
 			Handler:    _Customer_DeleteEmail_Handler,
 		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _Customer_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "VerifyPhoneNumber",
+			Handler:    _Customer_VerifyPhoneNumber_Handler,
+		},
+		{
+			MethodName: "FindDuplicateCustomers",
+			Handler:    _Customer_FindDuplicateCustomers_Handler,
+		},
+		{
+			MethodName: "MergeCustomers",
+			Handler:    _Customer_MergeCustomers_Handler,
+		},
+		{
+			MethodName: "SearchCustomers",
+			Handler:    _Customer_SearchCustomers_Handler,
+		},
+		{
+			MethodName: "ExportCustomerData",
+			Handler:    _Customer_ExportCustomerData_Handler,
+		},
+		{
+			MethodName: "EraseCustomer",
+			Handler:    _Customer_EraseCustomer_Handler,
+		},
+		{
+			MethodName: "CancelCustomerErasure",
+			Handler:    _Customer_CancelCustomerErasure_Handler,
+		},
+		{
+			MethodName: "GrantConsent",
+			Handler:    _Customer_GrantConsent_Handler,
+		},
+		{
+			MethodName: "WithdrawConsent",
+			Handler:    _Customer_WithdrawConsent_Handler,
+		},
+		{
+			MethodName: "ListConsents",
+			Handler:    _Customer_ListConsents_Handler,
+		},
+		{
+			MethodName: "ListReachableContacts",
+			Handler:    _Customer_ListReachableContacts_Handler,
+		},
+		{
+			MethodName: "BatchGetCustomers",
+			Handler:    _Customer_BatchGetCustomers_Handler,
+		},
+		{
+			MethodName: "BatchUpdateCustomers",
+			Handler:    _Customer_BatchUpdateCustomers_Handler,
+		},
+		{
+			MethodName: "ActivateCustomer",
+			Handler:    _Customer_ActivateCustomer_Handler,
+		},
+		{
+			MethodName: "SuspendCustomer",
+			Handler:    _Customer_SuspendCustomer_Handler,
+		},
+		{
+			MethodName: "CloseCustomer",
+			Handler:    _Customer_CloseCustomer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCustomers",
+			Handler:       _Customer_WatchCustomers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCustomers",
+			Handler:       _Customer_ImportCustomers_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCustomers",
+			Handler:       _Customer_ExportCustomers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/customer/v1/customer.proto",
 }
@@ -0,0 +1,1141 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: api/customer/v1/events.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CustomerCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerCreated) Reset() {
+	*x = CustomerCreated{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerCreated) ProtoMessage() {}
+
+func (x *CustomerCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerCreated.ProtoReflect.Descriptor instead.
+func (*CustomerCreated) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CustomerCreated) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerCreated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomerCreated) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+type CustomerUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DateOfBirth   string                 `protobuf:"bytes,3,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerUpdated) Reset() {
+	*x = CustomerUpdated{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerUpdated) ProtoMessage() {}
+
+func (x *CustomerUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerUpdated.ProtoReflect.Descriptor instead.
+func (*CustomerUpdated) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CustomerUpdated) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerUpdated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomerUpdated) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+// CustomerStatusChanged is recorded for every lifecycle transition.
+type CustomerStatusChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	From          CustomerStatus         `protobuf:"varint,2,opt,name=from,proto3,enum=api.customer.v1.CustomerStatus" json:"from,omitempty"`
+	To            CustomerStatus         `protobuf:"varint,3,opt,name=to,proto3,enum=api.customer.v1.CustomerStatus" json:"to,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerStatusChanged) Reset() {
+	*x = CustomerStatusChanged{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerStatusChanged) ProtoMessage() {}
+
+func (x *CustomerStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerStatusChanged.ProtoReflect.Descriptor instead.
+func (*CustomerStatusChanged) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CustomerStatusChanged) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerStatusChanged) GetFrom() CustomerStatus {
+	if x != nil {
+		return x.From
+	}
+	return CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED
+}
+
+func (x *CustomerStatusChanged) GetTo() CustomerStatus {
+	if x != nil {
+		return x.To
+	}
+	return CustomerStatus_CUSTOMER_STATUS_UNSPECIFIED
+}
+
+func (x *CustomerStatusChanged) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CustomerDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerDeleted) Reset() {
+	*x = CustomerDeleted{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerDeleted) ProtoMessage() {}
+
+func (x *CustomerDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerDeleted.ProtoReflect.Descriptor instead.
+func (*CustomerDeleted) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CustomerDeleted) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+type EmailAdded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	EmailId       int64                  `protobuf:"varint,2,opt,name=email_id,json=emailId,proto3" json:"email_id,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailAdded) Reset() {
+	*x = EmailAdded{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailAdded) ProtoMessage() {}
+
+func (x *EmailAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailAdded.ProtoReflect.Descriptor instead.
+func (*EmailAdded) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EmailAdded) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *EmailAdded) GetEmailId() int64 {
+	if x != nil {
+		return x.EmailId
+	}
+	return 0
+}
+
+func (x *EmailAdded) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type EmailRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailRemoved) Reset() {
+	*x = EmailRemoved{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailRemoved) ProtoMessage() {}
+
+func (x *EmailRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailRemoved.ProtoReflect.Descriptor instead.
+func (*EmailRemoved) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EmailRemoved) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *EmailRemoved) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type EmailVerified struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailVerified) Reset() {
+	*x = EmailVerified{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailVerified) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailVerified) ProtoMessage() {}
+
+func (x *EmailVerified) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailVerified.ProtoReflect.Descriptor instead.
+func (*EmailVerified) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EmailVerified) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *EmailVerified) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type PhoneNumberAdded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PhoneNumberId int64                  `protobuf:"varint,2,opt,name=phone_number_id,json=phoneNumberId,proto3" json:"phone_number_id,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhoneNumberAdded) Reset() {
+	*x = PhoneNumberAdded{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhoneNumberAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhoneNumberAdded) ProtoMessage() {}
+
+func (x *PhoneNumberAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhoneNumberAdded.ProtoReflect.Descriptor instead.
+func (*PhoneNumberAdded) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PhoneNumberAdded) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *PhoneNumberAdded) GetPhoneNumberId() int64 {
+	if x != nil {
+		return x.PhoneNumberId
+	}
+	return 0
+}
+
+func (x *PhoneNumberAdded) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type PhoneNumberRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhoneNumberRemoved) Reset() {
+	*x = PhoneNumberRemoved{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhoneNumberRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhoneNumberRemoved) ProtoMessage() {}
+
+func (x *PhoneNumberRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhoneNumberRemoved.ProtoReflect.Descriptor instead.
+func (*PhoneNumberRemoved) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PhoneNumberRemoved) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *PhoneNumberRemoved) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type PhoneNumberVerified struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,2,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhoneNumberVerified) Reset() {
+	*x = PhoneNumberVerified{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhoneNumberVerified) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhoneNumberVerified) ProtoMessage() {}
+
+func (x *PhoneNumberVerified) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhoneNumberVerified.ProtoReflect.Descriptor instead.
+func (*PhoneNumberVerified) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PhoneNumberVerified) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *PhoneNumberVerified) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type AddressAdded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	AddressId     int64                  `protobuf:"varint,2,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressAdded) Reset() {
+	*x = AddressAdded{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressAdded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressAdded) ProtoMessage() {}
+
+func (x *AddressAdded) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressAdded.ProtoReflect.Descriptor instead.
+func (*AddressAdded) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddressAdded) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *AddressAdded) GetAddressId() int64 {
+	if x != nil {
+		return x.AddressId
+	}
+	return 0
+}
+
+func (x *AddressAdded) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type AddressRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressRemoved) Reset() {
+	*x = AddressRemoved{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressRemoved) ProtoMessage() {}
+
+func (x *AddressRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressRemoved.ProtoReflect.Descriptor instead.
+func (*AddressRemoved) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddressRemoved) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *AddressRemoved) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// CustomersMerged is recorded on the survivor when a duplicate is merged
+// into it. The contact points listed were moved over from the duplicate.
+type CustomersMerged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MergedId      int64                  `protobuf:"varint,2,opt,name=merged_id,json=mergedId,proto3" json:"merged_id,omitempty"`
+	Emails        []string               `protobuf:"bytes,3,rep,name=emails,proto3" json:"emails,omitempty"`
+	PhoneNumbers  []string               `protobuf:"bytes,4,rep,name=phone_numbers,json=phoneNumbers,proto3" json:"phone_numbers,omitempty"`
+	Addresses     []string               `protobuf:"bytes,5,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomersMerged) Reset() {
+	*x = CustomersMerged{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomersMerged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomersMerged) ProtoMessage() {}
+
+func (x *CustomersMerged) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomersMerged.ProtoReflect.Descriptor instead.
+func (*CustomersMerged) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CustomersMerged) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomersMerged) GetMergedId() int64 {
+	if x != nil {
+		return x.MergedId
+	}
+	return 0
+}
+
+func (x *CustomersMerged) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *CustomersMerged) GetPhoneNumbers() []string {
+	if x != nil {
+		return x.PhoneNumbers
+	}
+	return nil
+}
+
+func (x *CustomersMerged) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+// CustomerErased is recorded when the personal data of a customer was
+// replaced with tokens. Consumers holding copies should erase them too.
+type CustomerErased struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ErasureId     int64                  `protobuf:"varint,2,opt,name=erasure_id,json=erasureId,proto3" json:"erasure_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerErased) Reset() {
+	*x = CustomerErased{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerErased) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerErased) ProtoMessage() {}
+
+func (x *CustomerErased) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerErased.ProtoReflect.Descriptor instead.
+func (*CustomerErased) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CustomerErased) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CustomerErased) GetErasureId() int64 {
+	if x != nil {
+		return x.ErasureId
+	}
+	return 0
+}
+
+// ConsentGranted and ConsentWithdrawn are recorded for every consent
+// change; contact is empty for consents on the whole channel.
+type ConsentGranted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ConsentId     int64                  `protobuf:"varint,2,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	Channel       ConsentChannel         `protobuf:"varint,3,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	Purpose       string                 `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentGranted) Reset() {
+	*x = ConsentGranted{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentGranted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentGranted) ProtoMessage() {}
+
+func (x *ConsentGranted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentGranted.ProtoReflect.Descriptor instead.
+func (*ConsentGranted) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ConsentGranted) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *ConsentGranted) GetConsentId() int64 {
+	if x != nil {
+		return x.ConsentId
+	}
+	return 0
+}
+
+func (x *ConsentGranted) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *ConsentGranted) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *ConsentGranted) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+type ConsentWithdrawn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    int64                  `protobuf:"varint,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ConsentId     int64                  `protobuf:"varint,2,opt,name=consent_id,json=consentId,proto3" json:"consent_id,omitempty"`
+	Channel       ConsentChannel         `protobuf:"varint,3,opt,name=channel,proto3,enum=api.customer.v1.ConsentChannel" json:"channel,omitempty"`
+	Purpose       string                 `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Contact       string                 `protobuf:"bytes,5,opt,name=contact,proto3" json:"contact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentWithdrawn) Reset() {
+	*x = ConsentWithdrawn{}
+	mi := &file_api_customer_v1_events_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentWithdrawn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentWithdrawn) ProtoMessage() {}
+
+func (x *ConsentWithdrawn) ProtoReflect() protoreflect.Message {
+	mi := &file_api_customer_v1_events_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentWithdrawn.ProtoReflect.Descriptor instead.
+func (*ConsentWithdrawn) Descriptor() ([]byte, []int) {
+	return file_api_customer_v1_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConsentWithdrawn) GetCustomerId() int64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *ConsentWithdrawn) GetConsentId() int64 {
+	if x != nil {
+		return x.ConsentId
+	}
+	return 0
+}
+
+func (x *ConsentWithdrawn) GetChannel() ConsentChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED
+}
+
+func (x *ConsentWithdrawn) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *ConsentWithdrawn) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+var File_api_customer_v1_events_proto protoreflect.FileDescriptor
+
+const file_api_customer_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1capi/customer/v1/events.proto\x12\x0fapi.customer.v1\x1a\x1eapi/customer/v1/customer.proto\"j\n" +
+	"\x0fCustomerCreated\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\rdate_of_birth\x18\x03 \x01(\tR\vdateOfBirth\"j\n" +
+	"\x0fCustomerUpdated\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
+	"\rdate_of_birth\x18\x03 \x01(\tR\vdateOfBirth\"\xb6\x01\n" +
+	"\x15CustomerStatusChanged\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x123\n" +
+	"\x04from\x18\x02 \x01(\x0e2\x1f.api.customer.v1.CustomerStatusR\x04from\x12/\n" +
+	"\x02to\x18\x03 \x01(\x0e2\x1f.api.customer.v1.CustomerStatusR\x02to\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"2\n" +
+	"\x0fCustomerDeleted\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\"^\n" +
+	"\n" +
+	"EmailAdded\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x19\n" +
+	"\bemail_id\x18\x02 \x01(\x03R\aemailId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"E\n" +
+	"\fEmailRemoved\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"F\n" +
+	"\rEmailVerified\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"~\n" +
+	"\x10PhoneNumberAdded\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12&\n" +
+	"\x0fphone_number_id\x18\x02 \x01(\x03R\rphoneNumberId\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\"X\n" +
+	"\x12PhoneNumberRemoved\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\"Y\n" +
+	"\x13PhoneNumberVerified\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12!\n" +
+	"\fphone_number\x18\x02 \x01(\tR\vphoneNumber\"h\n" +
+	"\fAddressAdded\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x02 \x01(\x03R\taddressId\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"K\n" +
+	"\x0eAddressRemoved\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"\xaa\x01\n" +
+	"\x0fCustomersMerged\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x1b\n" +
+	"\tmerged_id\x18\x02 \x01(\x03R\bmergedId\x12\x16\n" +
+	"\x06emails\x18\x03 \x03(\tR\x06emails\x12#\n" +
+	"\rphone_numbers\x18\x04 \x03(\tR\fphoneNumbers\x12\x1c\n" +
+	"\taddresses\x18\x05 \x03(\tR\taddresses\"P\n" +
+	"\x0eCustomerErased\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"erasure_id\x18\x02 \x01(\x03R\terasureId\"\xbf\x01\n" +
+	"\x0eConsentGranted\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x02 \x01(\x03R\tconsentId\x129\n" +
+	"\achannel\x18\x03 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x18\n" +
+	"\apurpose\x18\x04 \x01(\tR\apurpose\x12\x18\n" +
+	"\acontact\x18\x05 \x01(\tR\acontact\"\xc1\x01\n" +
+	"\x10ConsentWithdrawn\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\x03R\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"consent_id\x18\x02 \x01(\x03R\tconsentId\x129\n" +
+	"\achannel\x18\x03 \x01(\x0e2\x1f.api.customer.v1.ConsentChannelR\achannel\x12\x18\n" +
+	"\apurpose\x18\x04 \x01(\tR\apurpose\x12\x18\n" +
+	"\acontact\x18\x05 \x01(\tR\acontactB\x1dZ\x1bcustomer/api/customer/v1;v1b\x06proto3"
+
+var (
+	file_api_customer_v1_events_proto_rawDescOnce sync.Once
+	file_api_customer_v1_events_proto_rawDescData []byte
+)
+
+func file_api_customer_v1_events_proto_rawDescGZIP() []byte {
+	file_api_customer_v1_events_proto_rawDescOnce.Do(func() {
+		file_api_customer_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_customer_v1_events_proto_rawDesc), len(file_api_customer_v1_events_proto_rawDesc)))
+	})
+	return file_api_customer_v1_events_proto_rawDescData
+}
+
+var file_api_customer_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_customer_v1_events_proto_goTypes = []any{
+	(*CustomerCreated)(nil),       // 0: api.customer.v1.CustomerCreated
+	(*CustomerUpdated)(nil),       // 1: api.customer.v1.CustomerUpdated
+	(*CustomerStatusChanged)(nil), // 2: api.customer.v1.CustomerStatusChanged
+	(*CustomerDeleted)(nil),       // 3: api.customer.v1.CustomerDeleted
+	(*EmailAdded)(nil),            // 4: api.customer.v1.EmailAdded
+	(*EmailRemoved)(nil),          // 5: api.customer.v1.EmailRemoved
+	(*EmailVerified)(nil),         // 6: api.customer.v1.EmailVerified
+	(*PhoneNumberAdded)(nil),      // 7: api.customer.v1.PhoneNumberAdded
+	(*PhoneNumberRemoved)(nil),    // 8: api.customer.v1.PhoneNumberRemoved
+	(*PhoneNumberVerified)(nil),   // 9: api.customer.v1.PhoneNumberVerified
+	(*AddressAdded)(nil),          // 10: api.customer.v1.AddressAdded
+	(*AddressRemoved)(nil),        // 11: api.customer.v1.AddressRemoved
+	(*CustomersMerged)(nil),       // 12: api.customer.v1.CustomersMerged
+	(*CustomerErased)(nil),        // 13: api.customer.v1.CustomerErased
+	(*ConsentGranted)(nil),        // 14: api.customer.v1.ConsentGranted
+	(*ConsentWithdrawn)(nil),      // 15: api.customer.v1.ConsentWithdrawn
+	(CustomerStatus)(0),           // 16: api.customer.v1.CustomerStatus
+	(ConsentChannel)(0),           // 17: api.customer.v1.ConsentChannel
+}
+var file_api_customer_v1_events_proto_depIdxs = []int32{
+	16, // 0: api.customer.v1.CustomerStatusChanged.from:type_name -> api.customer.v1.CustomerStatus
+	16, // 1: api.customer.v1.CustomerStatusChanged.to:type_name -> api.customer.v1.CustomerStatus
+	17, // 2: api.customer.v1.ConsentGranted.channel:type_name -> api.customer.v1.ConsentChannel
+	17, // 3: api.customer.v1.ConsentWithdrawn.channel:type_name -> api.customer.v1.ConsentChannel
+	4,  // [4:4] is the sub-list for method output_type
+	4,  // [4:4] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_customer_v1_events_proto_init() }
+func file_api_customer_v1_events_proto_init() {
+	if File_api_customer_v1_events_proto != nil {
+		return
+	}
+	file_api_customer_v1_customer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_customer_v1_events_proto_rawDesc), len(file_api_customer_v1_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_customer_v1_events_proto_goTypes,
+		DependencyIndexes: file_api_customer_v1_events_proto_depIdxs,
+		MessageInfos:      file_api_customer_v1_events_proto_msgTypes,
+	}.Build()
+	File_api_customer_v1_events_proto = out.File
+	file_api_customer_v1_events_proto_goTypes = nil
+	file_api_customer_v1_events_proto_depIdxs = nil
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/env"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// envPrefix starts the environment variables overriding the config file:
+// CUSTOMER_DATA_DATABASE_SOURCE sets data.database.source. The value can
+// also come from the file named by the variable with a _FILE suffix, e.g.
+// CUSTOMER_DATA_DATABASE_SOURCE_FILE=/run/secrets/database-source, so
+// secrets mounted as files stay out of the environment. Lists of strings
+// are separated by commas, other lists are only read from the config file.
+const envPrefix = "CUSTOMER_"
+
+var durationName = (&durationpb.Duration{}).ProtoReflect().Descriptor().FullName()
+
+// loadConfig reads the bootstrap config from path, lays the environment
+// over it and validates the outcome.
+func loadConfig(path string) (*conf.Bootstrap, func(), error) {
+	c := config.New(
+		config.WithSource(
+			file.NewSource(path),
+			&envSource{lookup: os.LookupEnv, readFile: os.ReadFile},
+		),
+		config.WithDecoder(decodeConfig),
+	)
+	if err := c.Load(); err != nil {
+		c.Close()
+		return nil, nil, fmt.Errorf("config: %w", err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		c.Close()
+		return nil, nil, fmt.Errorf("config: %w", err)
+	}
+	if err := validateConfig(&bc); err != nil {
+		c.Close()
+		return nil, nil, err
+	}
+	return &bc, func() { c.Close() }, nil
+}
+
+// decodeConfig decodes a config source like the kratos default decoder,
+// except that durations may be written the Go way, e.g. 5m or 72h, and
+// ones that cannot be parsed are reported by their path.
+func decodeConfig(kv *config.KeyValue, target map[string]interface{}) error {
+	codec := encoding.GetCodec(kv.Format)
+	if codec == nil {
+		return fmt.Errorf("%s: unsupported format %q", kv.Key, kv.Format)
+	}
+	if err := codec.Unmarshal(kv.Value, &target); err != nil {
+		return fmt.Errorf("%s: %w", kv.Key, err)
+	}
+	return normalizeDurations((&conf.Bootstrap{}).ProtoReflect().Descriptor(), target, "")
+}
+
+// normalizeDurations rewrites the durations in m, a decoded message of
+// type md, to the seconds protojson expects.
+func normalizeDurations(md protoreflect.MessageDescriptor, m map[string]interface{}, prefix string) error {
+	for key, v := range m {
+		fd := md.Fields().ByName(protoreflect.Name(key))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(key)
+		}
+		if fd == nil || fd.Message() == nil || fd.IsMap() {
+			continue
+		}
+		path := prefix + string(fd.Name())
+
+		if fd.Message().FullName() == durationName {
+			s, ok := v.(string)
+			if !ok || fd.IsList() {
+				continue
+			}
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return fmt.Errorf("%s: invalid duration %q, e.g. 1.5s, 5m or 72h", path, s)
+			}
+			m[key] = strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+			continue
+		}
+
+		var elems []interface{}
+		if fd.IsList() {
+			elems, _ = v.([]interface{})
+		} else {
+			elems = []interface{}{v}
+		}
+		for _, e := range elems {
+			if sub, ok := e.(map[string]interface{}); ok {
+				if err := normalizeDurations(fd.Message(), sub, path+"."); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// envSource is a config.Source of the environment variables named after
+// the fields of conf.Bootstrap.
+type envSource struct {
+	lookup   func(string) (string, bool)
+	readFile func(string) ([]byte, error)
+}
+
+func (s *envSource) Load() ([]*config.KeyValue, error) {
+	doc := map[string]interface{}{}
+	if err := s.load((&conf.Bootstrap{}).ProtoReflect().Descriptor(), envPrefix, doc); err != nil {
+		return nil, err
+	}
+	b, err := encoding.GetCodec("json").Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return []*config.KeyValue{{Key: "environment", Value: b, Format: "json"}}, nil
+}
+
+// Watch never reports changes, the environment is read once.
+func (s *envSource) Watch() (config.Watcher, error) {
+	return env.NewWatcher()
+}
+
+func (s *envSource) load(md protoreflect.MessageDescriptor, prefix string, doc map[string]interface{}) error {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() || (fd.IsList() && fd.Kind() != protoreflect.StringKind) {
+			continue
+		}
+		name := prefix + strings.ToUpper(string(fd.Name()))
+
+		if fd.Message() != nil && fd.Message().FullName() != durationName {
+			sub := map[string]interface{}{}
+			if err := s.load(fd.Message(), name+"_", sub); err != nil {
+				return err
+			}
+			if len(sub) > 0 {
+				doc[string(fd.Name())] = sub
+			}
+			continue
+		}
+
+		raw, ok, err := s.value(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if fd.IsList() {
+			doc[string(fd.Name())] = strings.Split(raw, ",")
+			continue
+		}
+		v, err := parseEnvValue(fd, raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		doc[string(fd.Name())] = v
+	}
+	return nil
+}
+
+// value returns the value of the variable name, or the content of the
+// file named by name_FILE.
+func (s *envSource) value(name string) (string, bool, error) {
+	v, ok := s.lookup(name)
+	path, fromFile := s.lookup(name + "_FILE")
+	switch {
+	case ok && fromFile:
+		return "", false, fmt.Errorf("%s and %s_FILE are both set", name, name)
+	case fromFile:
+		b, err := s.readFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_FILE: %w", name, err)
+		}
+		// editors and kubectl leave a trailing newline
+		return strings.TrimRight(string(b), "\r\n"), true, nil
+	}
+	return v, ok, nil
+}
+
+func parseEnvValue(fd protoreflect.FieldDescriptor, raw string) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", raw)
+		}
+		return b, nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", raw)
+		}
+		return n, nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", raw)
+		}
+		return n, nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", raw)
+		}
+		return f, nil
+	}
+	// strings, and durations which decodeConfig parses
+	return raw, nil
+}
+
+// validateConfig reports everything wrong with bc at once.
+func validateConfig(bc *conf.Bootstrap) error {
+	var errs []error
+	db := bc.GetData().GetDatabase()
+	if db.GetSource() == "" {
+		errs = append(errs, fmt.Errorf("data.database.source is required, set it in the config file or %sDATA_DATABASE_SOURCE", envPrefix))
+	}
+	if d := db.GetDriver(); d != "" && d != "postgres" {
+		errs = append(errs, fmt.Errorf("data.database.driver: unsupported driver %q, only postgres is", d))
+	}
+	errs = append(errs, validateDurations(bc.ProtoReflect(), "")...)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validateDurations rejects negative durations, and intervals of zero the
+// jobs could not tick with.
+func validateDurations(m protoreflect.Message, prefix string) []error {
+	var errs []error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		path := prefix + string(fd.Name())
+		if fd.Message().FullName() == durationName {
+			if fd.IsList() {
+				return true
+			}
+			d := v.Message().Interface().(*durationpb.Duration).AsDuration()
+			switch {
+			case d < 0:
+				errs = append(errs, fmt.Errorf("%s: must not be negative, got %s", path, d))
+			case d == 0 && fd.Name() == "interval":
+				errs = append(errs, fmt.Errorf("%s: must be positive", path))
+			}
+			return true
+		}
+		if fd.IsList() {
+			for i := 0; i < v.List().Len(); i++ {
+				errs = append(errs, validateDurations(v.List().Get(i).Message(), fmt.Sprintf("%s[%d].", path, i))...)
+			}
+			return true
+		}
+		errs = append(errs, validateDurations(v.Message(), path+".")...)
+		return true
+	})
+	return errs
+}
+
+// secretParam matches the parameters of URLs and key=value DSNs that
+// carry secrets.
+var secretParam = regexp.MustCompile(`(?i)(^|[\s?&])((?:password|token|secret|key|sig|signature)=)[^\s&]+`)
+
+// redactConfig returns a copy of bc without the secrets in it, for
+// printing.
+func redactConfig(bc *conf.Bootstrap) *conf.Bootstrap {
+	bc = proto.Clone(bc).(*conf.Bootstrap)
+	if db := bc.GetData().GetDatabase(); db != nil {
+		db.Source = redactSecret(db.Source)
+		for i, r := range db.Replicas {
+			db.Replicas[i] = redactSecret(r)
+		}
+	}
+	if p := bc.GetData().GetPublisher(); p != nil {
+		p.WebhookUrl = redactSecret(p.WebhookUrl)
+	}
+	return bc
+}
+
+// redactSecret hides the password of a URL and the secret parameters of a
+// URL or DSN.
+func redactSecret(s string) string {
+	if u, err := url.Parse(s); err == nil && u.User != nil {
+		s = u.Redacted()
+	}
+	return secretParam.ReplaceAllString(s, "${1}${2}***")
+}
+
+// printConfig writes the effective config of bc with secrets redacted.
+func printConfig(w io.Writer, bc *conf.Bootstrap) error {
+	b, err := protojson.MarshalOptions{Multiline: true, UseProtoNames: true}.Marshal(redactConfig(bc))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"customer/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+var exportFormats = map[string]biz.ExportFormat{
+	"csv":     biz.ExportCSV,
+	"ndjson":  biz.ExportNDJSON,
+	"jsonl":   biz.ExportNDJSON,
+	"parquet": biz.ExportParquet,
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var (
+		confPath   = fs.String("conf", "../../configs", "config path, eg: -conf config.yaml")
+		format     = fs.String("format", "", "output format: csv, ndjson or parquet, guessed from -o when empty")
+		output     = fs.String("o", "-", "output file, - for stdout")
+		ids        = fs.String("ids", "", "comma separated customer ids to export")
+		name       = fs.String("name", "", "only customers whose name contains this")
+		bornAfter  = fs.String("born-after", "", "only customers born on or after this date (YYYY-MM-DD)")
+		bornBefore = fs.String("born-before", "", "only customers born on or before this date (YYYY-MM-DD)")
+		exclude    = fs.String("exclude", "", "comma separated columns to leave out")
+		mask       = fs.String("mask", "", "comma separated columns to mask")
+		tenant     = fs.String("tenant", "", "tenant to export, the default tenant when empty")
+	)
+	_ = fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("unexpected arguments")
+	}
+
+	if *format == "" {
+		*format = "csv"
+		if ext := strings.TrimPrefix(filepath.Ext(*output), "."); ext != "" {
+			*format = strings.ToLower(ext)
+		}
+	}
+	f, ok := exportFormats[*format]
+	if !ok {
+		return fmt.Errorf("unsupported format %q, use csv, ndjson or parquet", *format)
+	}
+
+	filter := &biz.CustomerFilter{
+		Name:       *name,
+		BornAfter:  *bornAfter,
+		BornBefore: *bornBefore,
+	}
+	for _, s := range splitList(*ids) {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid customer id %q", s)
+		}
+		filter.IDs = append(filter.IDs, id)
+	}
+
+	bc, closeConfig, err := loadConfig(*confPath)
+	if err != nil {
+		return err
+	}
+	defer closeConfig()
+
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	uc, cleanup, err := wireCustomerUsecase(bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	out := os.Stdout
+	if *output != "-" {
+		if out, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	w := bufio.NewWriter(out)
+
+	ctx := biz.NewTenantContext(context.Background(), *tenant)
+	n, err := uc.ExportCustomers(ctx, filter, biz.ExportOptions{
+		Format:  f,
+		Exclude: splitList(*exclude),
+		Mask:    splitList(*mask),
+	}, w)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "export: %d customers written\n", n)
+	return nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
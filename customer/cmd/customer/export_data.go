@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"customer/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func runExportData(args []string) error {
+	fs := flag.NewFlagSet("export-data", flag.ExitOnError)
+	var (
+		confPath = fs.String("conf", "../../configs", "config path, eg: -conf config.yaml")
+		output   = fs.String("o", "-", "output file, - for stdout; a file gets a .sha256 file next to it")
+		tenant   = fs.String("tenant", "", "tenant of the customer, the default tenant when empty")
+		subject  = fs.String("subject", "", "who the export is made by, kept on record with the checksum")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: customer export-data [flags] <customer id>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("exactly one customer id is required")
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid customer id %q", fs.Arg(0))
+	}
+
+	bc, closeConfig, err := loadConfig(*confPath)
+	if err != nil {
+		return err
+	}
+	defer closeConfig()
+
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	uc, cleanup, err := wireCustomerUsecase(bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := biz.NewTenantContext(context.Background(), *tenant)
+	if *subject != "" {
+		ctx = biz.NewSubjectContext(ctx, *subject)
+	}
+	e, err := uc.ExportCustomerData(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if *output == "-" {
+		if _, err := os.Stdout.Write(e.Bundle); err != nil {
+			return err
+		}
+	} else {
+		if err := os.WriteFile(*output, e.Bundle, 0o600); err != nil {
+			return err
+		}
+		// the format of sha256sum, so `sha256sum -c` verifies the bundle
+		sum := strings.TrimPrefix(e.Checksum, "sha256:") + "  " + filepath.Base(*output) + "\n"
+		if err := os.WriteFile(*output+".sha256", []byte(sum), 0o600); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "export-data: customer %d, %s\n", id, e.Checksum)
+	return nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"customer/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// importFields are the record fields a source column can be mapped to.
+var importFields = []string{"name", "date_of_birth", "emails", "phone_numbers", "addresses"}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	var (
+		confPath  = fs.String("conf", "../../configs", "config path, eg: -conf config.yaml")
+		format    = fs.String("format", "", "input format: csv or ndjson, guessed from the file extension when empty")
+		mapping   = fs.String("map", "", "column mapping, eg: name=full_name,emails=email|work_email")
+		separator = fs.String("sep", ";", "separator of several values in one cell")
+		dryRun    = fs.Bool("dry-run", false, "validate and roll back instead of committing")
+		batchSize = fs.Int("batch-size", 500, "rows committed per transaction")
+		report    = fs.String("report", "-", "where to write the per-row report (NDJSON), - for stdout")
+		tenant    = fs.String("tenant", "", "tenant to import into, the default tenant when empty")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: customer import [flags] <file>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("exactly one input file is required")
+	}
+	path := fs.Arg(0)
+
+	columns, err := parseImportMapping(*mapping)
+	if err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if *format == "jsonl" || *format == "json" {
+			*format = "ndjson"
+		}
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var next func() (*biz.ImportRecord, error)
+	switch *format {
+	case "csv":
+		next, err = csvImportReader(in, columns, *separator)
+	case "ndjson":
+		next = ndjsonImportReader(in, columns, *separator)
+	default:
+		return fmt.Errorf("unsupported format %q, use csv or ndjson", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *report != "-" {
+		if out, err = os.Create(*report); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	bc, closeConfig, err := loadConfig(*confPath)
+	if err != nil {
+		return err
+	}
+	defer closeConfig()
+
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	uc, cleanup, err := wireCustomerUsecase(bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	enc := json.NewEncoder(out)
+	ctx := biz.NewTenantContext(context.Background(), *tenant)
+	summary, err := uc.ImportCustomers(ctx, biz.ImportOptions{
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+	}, next, func(r *biz.ImportResult) error {
+		row := importReportRow{Line: r.Line, CustomerID: r.CustomerID}
+		switch r.Status {
+		case biz.ImportCreated:
+			row.Status = "created"
+		case biz.ImportValid:
+			row.Status = "valid"
+		default:
+			row.Status = "failed"
+		}
+		if r.Err != nil {
+			row.Error = r.Err.Error()
+		}
+		return enc.Encode(row)
+	})
+	if summary != nil {
+		fmt.Fprintf(os.Stderr, "import: %d rows, %d created, %d valid, %d failed (dry run: %t)\n",
+			summary.Total, summary.Created, summary.Valid, summary.Failed, *dryRun)
+	}
+	return err
+}
+
+type importReportRow struct {
+	Line       int64  `json:"line"`
+	Status     string `json:"status"`
+	CustomerID int64  `json:"customer_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// parseImportMapping parses "field=col1|col2,..." into the source columns
+// of every field. Unmapped fields read the column of the same name.
+func parseImportMapping(s string) (map[string][]string, error) {
+	columns := make(map[string][]string, len(importFields))
+	for _, f := range importFields {
+		columns[f] = []string{f}
+	}
+	if s == "" {
+		return columns, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		field, cols, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || cols == "" {
+			return nil, fmt.Errorf("invalid mapping %q, want field=column", pair)
+		}
+		if _, known := columns[field]; !known {
+			return nil, fmt.Errorf("unknown field %q in mapping, want one of %s", field, strings.Join(importFields, ", "))
+		}
+		columns[field] = strings.Split(cols, "|")
+	}
+	return columns, nil
+}
+
+// toImportRecord builds a record from the raw values of each mapped field.
+func toImportRecord(values map[string][]string) *biz.ImportRecord {
+	first := func(vs []string) string {
+		if len(vs) == 0 {
+			return ""
+		}
+		return vs[0]
+	}
+	return &biz.ImportRecord{
+		Customer: biz.Customer{
+			Name:        first(values["name"]),
+			DateOfBirth: first(values["date_of_birth"]),
+		},
+		Emails:       values["emails"],
+		PhoneNumbers: values["phone_numbers"],
+		Addresses:    values["addresses"],
+	}
+}
+
+func splitCell(v, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(v, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func csvImportReader(r io.Reader, columns map[string][]string, sep string) (func() (*biz.ImportRecord, error), error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.TrimSpace(h)] = i
+	}
+	if _, ok := index[columns["name"][0]]; !ok {
+		return nil, fmt.Errorf("csv header has no %q column for name", columns["name"][0])
+	}
+
+	var line int64 = 1
+	return func() (*biz.ImportRecord, error) {
+		row, err := cr.Read()
+		line++
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return &biz.ImportRecord{Line: line, Err: err}, nil
+		}
+		values := make(map[string][]string, len(columns))
+		for field, cols := range columns {
+			for _, col := range cols {
+				if i, ok := index[col]; ok && i < len(row) {
+					values[field] = append(values[field], splitCell(row[i], sep)...)
+				}
+			}
+		}
+		rec := toImportRecord(values)
+		rec.Line = line
+		return rec, nil
+	}, nil
+}
+
+func ndjsonImportReader(r io.Reader, columns map[string][]string, sep string) func() (*biz.ImportRecord, error) {
+	dec := json.NewDecoder(r)
+	var line int64
+	return func() (*biz.ImportRecord, error) {
+		var obj map[string]any
+		err := dec.Decode(&obj)
+		line++
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil {
+			// the decoder cannot resync after a syntax error, stop here
+			return nil, fmt.Errorf("ndjson record %d: %w", line, err)
+		}
+		values := make(map[string][]string, len(columns))
+		for field, cols := range columns {
+			for _, col := range cols {
+				switch v := obj[col].(type) {
+				case string:
+					values[field] = append(values[field], splitCell(v, sep)...)
+				case []any:
+					for _, item := range v {
+						if s, ok := item.(string); ok && s != "" {
+							values[field] = append(values[field], s)
+						}
+					}
+				case nil:
+				default:
+					return &biz.ImportRecord{Line: line, Err: fmt.Errorf("field %q must be a string or a list of strings", col)}, nil
+				}
+			}
+		}
+		rec := toImportRecord(values)
+		rec.Line = line
+		return rec, nil
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// newLogger builds the service logger c asks for: logfmt or json lines,
+// without the ones below the configured level.
+func newLogger(c *conf.Log, w io.Writer) (log.Logger, error) {
+	var logger log.Logger
+	switch c.GetFormat() {
+	case "", "logfmt":
+		logger = log.NewStdLogger(w)
+	case "json":
+		logger = &jsonLogger{enc: json.NewEncoder(w)}
+	default:
+		return nil, fmt.Errorf("log: unknown format %q", c.GetFormat())
+	}
+
+	level := log.LevelInfo
+	switch c.GetLevel() {
+	case "":
+	case "debug", "info", "warn", "error":
+		level = log.ParseLevel(c.GetLevel())
+	default:
+		return nil, fmt.Errorf("log: unknown level %q", c.GetLevel())
+	}
+	return log.NewFilter(logger, log.FilterLevel(level)), nil
+}
+
+// jsonLogger writes every line as one JSON object.
+type jsonLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (l *jsonLogger) Log(level log.Level, keyvals ...interface{}) error {
+	line := make(map[string]interface{}, len(keyvals)/2+1)
+	line["level"] = level.String()
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		v := keyvals[i+1]
+		switch x := v.(type) {
+		case error:
+			v = x.Error()
+		case fmt.Stringer:
+			v = x.String()
+		}
+		line[fmt.Sprint(keyvals[i])] = v
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(line)
+}
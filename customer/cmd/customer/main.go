@@ -2,13 +2,12 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 
-	"customer/internal/conf"
+	"customer/internal/server"
 
 	"github.com/go-kratos/kratos/v2"
-	"github.com/go-kratos/kratos/v2/config"
-	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
@@ -24,28 +23,68 @@ var (
 	Version string
 	// flagconf is the config flag.
 	flagconf string
+	// flagprint prints the effective config instead of serving.
+	flagprint bool
 
 	id, _ = os.Hostname()
 )
 
 func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.BoolVar(&flagprint, "print-config", false, "print the effective config, secrets redacted, and exit")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, hs *server.HealthServer, ob *server.OutboxServer, es *server.ErasureServer, ps *server.PurgeServer, ms *server.MetricsServer) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
 		kratos.Version(Version),
 		kratos.Metadata(map[string]string{}),
 		kratos.Logger(logger),
-		kratos.Server(gs),
+		kratos.Server(gs, hs, ob, es, ps, ms),
+		kratos.BeforeStop(hs.Shutdown),
 	)
 }
 
+// commands are run instead of the server when named as the first
+// argument, e.g. `customer import -conf ../../configs customers.csv`.
+var commands = map[string]func(args []string) error{
+	"import":      runImport,
+	"export":      runExport,
+	"export-data": runExportData,
+	"rotate-keys": runRotateKeys,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	flag.Parse()
-	logger := log.With(log.NewStdLogger(os.Stdout),
+	bc, closeConfig, err := loadConfig(flagconf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer closeConfig()
+	if flagprint {
+		if err := printConfig(os.Stdout, bc); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	base, err := newLogger(bc.Log, os.Stdout)
+	if err != nil {
+		panic(err)
+	}
+	logger := log.With(base,
 		"ts", log.DefaultTimestamp,
 		"caller", log.DefaultCaller,
 		"service.id", id,
@@ -53,22 +92,8 @@ func main() {
 		"service.version", Version,
 		"trace.id", tracing.TraceID(),
 		"span.id", tracing.SpanID(),
+		"request.id", server.RequestID(),
 	)
-	c := config.New(
-		config.WithSource(
-			file.NewSource(flagconf),
-		),
-	)
-	defer c.Close()
-
-	if err := c.Load(); err != nil {
-		panic(err)
-	}
-
-	var bc conf.Bootstrap
-	if err := c.Scan(&bc); err != nil {
-		panic(err)
-	}
 
 	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
 	if err != nil {
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"customer/internal/data"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func runRotateKeys(args []string) error {
+	fs := flag.NewFlagSet("rotate-keys", flag.ExitOnError)
+	var (
+		confPath  = fs.String("conf", "../../configs", "config path, eg: -conf config.yaml")
+		batchSize = fs.Int("batch-size", 500, "rows re-encrypted per transaction")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: customer rotate-keys [flags]")
+		fmt.Fprintln(fs.Output(), "re-encrypts every row not under the current key of the key provider")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("unexpected arguments")
+	}
+	if *batchSize <= 0 {
+		return errors.New("batch-size must be positive")
+	}
+
+	bc, closeConfig, err := loadConfig(*confPath)
+	if err != nil {
+		return err
+	}
+	defer closeConfig()
+
+	logger := log.NewFilter(log.NewStdLogger(os.Stderr), log.FilterLevel(log.LevelWarn))
+	d, cleanup, err := data.NewData(bc.Data, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	totals := map[string]int{}
+	var order []string
+	err = d.RotateKeys(context.Background(), *batchSize, func(table string, rotated int) {
+		if _, ok := totals[table]; !ok {
+			order = append(order, table)
+		}
+		totals[table] += rotated
+	})
+	for _, table := range order {
+		fmt.Fprintf(os.Stderr, "rotate-keys: %s: %d rows re-encrypted\n", table, totals[table])
+	}
+	if err != nil {
+		return err
+	}
+	if len(order) == 0 {
+		fmt.Fprintln(os.Stderr, "rotate-keys: every row is under the current key")
+	}
+	return nil
+}
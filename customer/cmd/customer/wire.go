@@ -20,3 +20,8 @@ import (
 func wireApp(*conf.Server, *conf.Data, log.Logger) (*kratos.App, func(), error) {
 	panic(wire.Build(server.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, newApp))
 }
+
+// wireCustomerUsecase init the customer usecase for command line tools.
+func wireCustomerUsecase(*conf.Data, log.Logger) (*biz.CustomerUsecase, func(), error) {
+	panic(wire.Build(data.ProviderSet, biz.ProviderSet))
+}
@@ -29,11 +29,87 @@ func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*
 		return nil, nil, err
 	}
 	customerRepo := data.NewCustomerRepo(dataData)
-	customerUsecase := biz.NewCustomerUsecase(customerRepo)
-	customerService := service.NewCustomerService(customerUsecase)
-	grpcServer := server.NewGRPCServer(confServer, customerService, logger)
-	app := newApp(logger, grpcServer)
+	outboxRepo := data.NewOutboxRepo(dataData)
+	mergeRepo := data.NewMergeRepo(dataData)
+	searchRepo := data.NewSearchRepo(dataData, logger)
+	changeFeedRepo := data.NewChangeFeedRepo(dataData)
+	dataExportRepo := data.NewDataExportRepo(dataData)
+	consentRepo := data.NewConsentRepo(dataData)
+	erasureRepo := data.NewErasureRepo(dataData)
+	ruleEngine := biz.NewRuleEngine()
+	customerUsecase := biz.NewCustomerUsecase(customerRepo, outboxRepo, mergeRepo, searchRepo, changeFeedRepo, dataExportRepo, consentRepo, erasureRepo, ruleEngine)
+	changeFeed := biz.NewChangeFeed(changeFeedRepo)
+	idempotencyRepo, err := data.NewIdempotencyRepo(dataData, confData)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	erasureUsecase := biz.NewErasureUsecase(confData, customerRepo, outboxRepo, mergeRepo, erasureRepo, consentRepo, idempotencyRepo, logger)
+	customerService := service.NewCustomerService(customerUsecase, changeFeed, erasureUsecase)
+	healthRepo := data.NewHealthRepo(dataData)
+	healthUsecase := biz.NewHealthUsecase(healthRepo, ruleEngine)
+	healthServer := server.NewHealthServer(confServer, healthUsecase, logger)
+	idempotencyUsecase := biz.NewIdempotencyUsecase(confData, idempotencyRepo, logger)
+	rateLimitRepo, err := data.NewRateLimitRepo(dataData, confData)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	rateLimitUsecase := biz.NewRateLimitUsecase(rateLimitRepo, logger)
+	tracerProvider, cleanup2, err := server.NewTracerProvider(confServer)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	grpcServer, err := server.NewGRPCServer(confServer, customerService, healthServer, idempotencyUsecase, rateLimitUsecase, tracerProvider, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	eventPublisher, err := data.NewEventPublisher(confData, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	leaseRepo := data.NewLeaseRepo(dataData)
+	outboxRelay := biz.NewOutboxRelay(outboxRepo, eventPublisher, changeFeed, leaseRepo, logger)
+	outboxServer := server.NewOutboxServer(confServer, outboxRelay, logger)
+	erasureServer := server.NewErasureServer(confServer, erasureUsecase, logger)
+	retentionRepo := data.NewRetentionRepo(dataData)
+	retentionUsecase := biz.NewRetentionUsecase(confData, customerRepo, outboxRepo, retentionRepo, leaseRepo, logger)
+	purgeServer := server.NewPurgeServer(confServer, retentionUsecase, idempotencyUsecase, logger)
+	metricsServer, err := server.NewMetricsServer(confServer, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	app := newApp(logger, grpcServer, healthServer, outboxServer, erasureServer, purgeServer, metricsServer)
 	return app, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wireCustomerUsecase init the customer usecase for command line tools.
+func wireCustomerUsecase(confData *conf.Data, logger log.Logger) (*biz.CustomerUsecase, func(), error) {
+	dataData, cleanup, err := data.NewData(confData, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	customerRepo := data.NewCustomerRepo(dataData)
+	outboxRepo := data.NewOutboxRepo(dataData)
+	mergeRepo := data.NewMergeRepo(dataData)
+	searchRepo := data.NewSearchRepo(dataData, logger)
+	changeFeedRepo := data.NewChangeFeedRepo(dataData)
+	dataExportRepo := data.NewDataExportRepo(dataData)
+	consentRepo := data.NewConsentRepo(dataData)
+	erasureRepo := data.NewErasureRepo(dataData)
+	ruleEngine := biz.NewRuleEngine()
+	customerUsecase := biz.NewCustomerUsecase(customerRepo, outboxRepo, mergeRepo, searchRepo, changeFeedRepo, dataExportRepo, consentRepo, erasureRepo, ruleEngine)
+	return customerUsecase, func() {
 		cleanup()
 	}, nil
 }
@@ -0,0 +1,187 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+
+	v1 "customer/api/customer/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// maxBatchSize is the most customers one batch call reads or writes.
+const maxBatchSize = 500
+
+// ErrCustomerNotFound is reported for batch items no customer matches.
+var ErrCustomerNotFound = errors.NotFound("CUSTOMER_NOT_FOUND", "customer not found")
+
+// CustomerKey identifies a customer by one of its ID, an email or a phone
+// number.
+type CustomerKey struct {
+	ID          int64
+	Email       string
+	PhoneNumber string
+}
+
+// CustomerUpdateResult is the outcome of one update of a batch: the
+// customer as updated, or why the update failed.
+type CustomerUpdateResult struct {
+	ID       int64
+	Customer *Customer
+	Err      error
+}
+
+// BatchGetCustomers returns the customer of every key, in the order of
+// keys, nil where none matches. Like GetCustomer, ids merged into another
+// customer return the survivor.
+func (uc *CustomerUsecase) BatchGetCustomers(ctx context.Context, keys []*CustomerKey) ([]*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.BatchGetCustomers")
+	defer span.End()
+	if len(keys) > maxBatchSize {
+		return nil, fmt.Errorf("at most %d customers can be read at once", maxBatchSize)
+	}
+	for i, k := range keys {
+		if k.ID == 0 && k.Email == "" && k.PhoneNumber == "" {
+			return nil, fmt.Errorf("key %d: an id, email or phone number is required", i)
+		}
+	}
+	return uc.loadCustomers(ctx, keys)
+}
+
+// loadCustomers loads the customers of keys, following merges of the ids
+// not found.
+func (uc *CustomerUsecase) loadCustomers(ctx context.Context, keys []*CustomerKey) ([]*Customer, error) {
+	customers, err := uc.repo.GetCustomersByKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		merged    []int
+		survivors []*CustomerKey
+	)
+	for i, k := range keys {
+		if customers[i] != nil || k.ID == 0 {
+			continue
+		}
+		survivor, err := uc.merges.Survivor(ctx, k.ID)
+		if err != nil {
+			return nil, err
+		}
+		if survivor != 0 {
+			merged = append(merged, i)
+			survivors = append(survivors, &CustomerKey{ID: survivor})
+		}
+	}
+	if len(survivors) == 0 {
+		return customers, nil
+	}
+	found, err := uc.repo.GetCustomersByKeys(ctx, survivors)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range merged {
+		customers[i] = found[j]
+	}
+	return customers, nil
+}
+
+// BatchUpdateCustomers applies updates like UpdateCustomer, leaving the
+// empty fields of an update as they are. Unless bestEffort is set they
+// apply all or none, and the first update that fails is returned as the
+// error. In best effort mode each update runs in a savepoint of its own,
+// so a failing one is rolled back alone and reported in its result; the
+// call only fails when the batch cannot be loaded or committed.
+func (uc *CustomerUsecase) BatchUpdateCustomers(ctx context.Context, updates []*Customer, bestEffort bool) ([]*CustomerUpdateResult, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.BatchUpdateCustomers")
+	defer span.End()
+	if len(updates) > maxBatchSize {
+		return nil, fmt.Errorf("at most %d customers can be updated at once", maxBatchSize)
+	}
+	keys := make([]*CustomerKey, len(updates))
+	for i, u := range updates {
+		keys[i] = &CustomerKey{ID: u.ID}
+	}
+
+	results := make([]*CustomerUpdateResult, len(updates))
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		current, err := uc.loadCustomers(ctx, keys)
+		if err != nil {
+			return err
+		}
+		// a merged id and its survivor are the same customer
+		seen := make(map[int64]int, len(current))
+		for i, c := range current {
+			if c == nil {
+				continue
+			}
+			if j, ok := seen[c.ID]; ok {
+				return fmt.Errorf("update %d: customer %d is updated twice, by update %d too", i, c.ID, j)
+			}
+			seen[c.ID] = i
+		}
+		for i, u := range updates {
+			results[i] = &CustomerUpdateResult{ID: u.ID}
+			apply := func(ctx context.Context) error {
+				return uc.applyUpdate(ctx, current[i], u)
+			}
+			if bestEffort {
+				err = uc.repo.Tx(ctx, apply)
+			} else {
+				err = apply(ctx)
+			}
+			if err != nil && !bestEffort {
+				return updateError(i, u.ID, err)
+			}
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i].Customer = current[i]
+		}
+		return nil
+	})
+	if err != nil {
+		// in best effort mode too, the batch did not commit
+		return nil, err
+	}
+	return results, nil
+}
+
+// applyUpdate updates c, as loaded, with the fields set in u. Closed
+// customers keep their data as it was.
+func (uc *CustomerUsecase) applyUpdate(ctx context.Context, c *Customer, u *Customer) error {
+	if c == nil {
+		return ErrCustomerNotFound
+	}
+	if err := uc.lockOpen(ctx, c.ID); err != nil {
+		return err
+	}
+	if u.Name != "" {
+		c.Name = u.Name
+	}
+	if u.DateOfBirth != "" {
+		c.DateOfBirth = u.DateOfBirth
+	}
+	if err := uc.rules.Customer.Validate(c); err != nil {
+		return err
+	}
+	if err := uc.repo.UpdateCustomer(ctx, c); err != nil {
+		return err
+	}
+	return uc.emit(ctx, c.ID, &v1.CustomerUpdated{
+		CustomerId:  c.ID,
+		Name:        c.Name,
+		DateOfBirth: c.DateOfBirth,
+	})
+}
+
+// updateError names the update of a batch err is about. Errors with a
+// status, such as ErrCustomerClosed, keep it.
+func updateError(i int, id int64, err error) error {
+	prefix := fmt.Sprintf("update %d (customer %d): ", i, id)
+	if se := new(errors.Error); errors.As(err, &se) {
+		return errors.New(int(se.Code), se.Reason, prefix+se.Message).WithCause(err)
+	}
+	return fmt.Errorf("%s%w", prefix, err)
+}
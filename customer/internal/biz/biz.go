@@ -3,4 +3,4 @@ package biz
 import "github.com/google/wire"
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewCustomerUsecase)
+var ProviderSet = wire.NewSet(NewCustomerUsecase, NewErasureUsecase, NewRetentionUsecase, NewRuleEngine, NewOutboxRelay, NewChangeFeed, NewHealthUsecase, NewIdempotencyUsecase, NewRateLimitUsecase)
@@ -0,0 +1,298 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "customer/api/customer/v1"
+)
+
+const (
+	defaultReachablePageSize = 100
+	maxReachablePageSize     = 1000
+)
+
+// ConsentChannel mirrors api.customer.v1.ConsentChannel.
+type ConsentChannel int32
+
+const (
+	ConsentChannelUnspecified ConsentChannel = iota
+	ConsentChannelEmail
+	ConsentChannelSMS
+)
+
+// ConsentStatus mirrors api.customer.v1.ConsentStatus.
+type ConsentStatus int32
+
+const (
+	ConsentUnspecified ConsentStatus = iota
+	ConsentGranted
+	ConsentWithdrawn
+)
+
+// Consent is one grant or withdrawal of a customer. Contact is the email
+// or phone number it applies to, empty for every contact point of the
+// channel. Consents are only ever added; the latest one for a channel,
+// purpose and contact is in force.
+type Consent struct {
+	ID            int64
+	CustomerID    int64
+	Channel       ConsentChannel
+	Purpose       string
+	Status        ConsentStatus
+	Contact       string
+	Source        string
+	PolicyVersion string
+	RecordedBy    string
+	RecordedAt    time.Time
+}
+
+// consentKey is what a later consent overrides an earlier one on.
+type consentKey struct {
+	customerID int64
+	channel    ConsentChannel
+	purpose    string
+	contact    string
+}
+
+func (c *Consent) key() consentKey {
+	return consentKey{c.CustomerID, c.Channel, c.Purpose, c.Contact}
+}
+
+// ReachableContact is a contact point that may be contacted for a
+// purpose.
+type ReachableContact struct {
+	CustomerID int64
+	Channel    ConsentChannel
+	Contact    string
+}
+
+type ReachablePage struct {
+	Contacts []*ReachableContact
+	// NextPageToken is empty on the last page.
+	NextPageToken string
+}
+
+type ConsentRepo interface {
+	// Record adds c to the consent history.
+	Record(ctx context.Context, c *Consent) error
+	// List returns the consent history of the customers, oldest first.
+	List(ctx context.Context, customerIDs []int64) ([]*Consent, error)
+	// Granted returns up to limit customers after afterCustomerID, in
+	// order, with a consent in force granted for purpose on channel, or
+	// on any channel when it is unspecified.
+	Granted(ctx context.Context, purpose string, channel ConsentChannel, afterCustomerID int64, limit int) ([]int64, error)
+}
+
+// GrantConsent records a grant, see recordConsent.
+func (uc *CustomerUsecase) GrantConsent(ctx context.Context, c *Consent) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.GrantConsent")
+	defer span.End()
+	if strings.TrimSpace(c.PolicyVersion) == "" {
+		return errors.New("policy version is required")
+	}
+	c.Status = ConsentGranted
+	return uc.recordConsent(ctx, c)
+}
+
+// WithdrawConsent records a withdrawal, see recordConsent.
+func (uc *CustomerUsecase) WithdrawConsent(ctx context.Context, c *Consent) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.WithdrawConsent")
+	defer span.End()
+	c.Status = ConsentWithdrawn
+	return uc.recordConsent(ctx, c)
+}
+
+// recordConsent adds c to the history of its customer. A contact must be
+// one of the customer's emails for the email channel and one of its phone
+// numbers for SMS.
+func (uc *CustomerUsecase) recordConsent(ctx context.Context, c *Consent) error {
+	c.Purpose = strings.ToLower(strings.TrimSpace(c.Purpose))
+	if c.Purpose == "" {
+		return errors.New("purpose is required")
+	}
+	if c.Channel != ConsentChannelEmail && c.Channel != ConsentChannelSMS {
+		return errors.New("channel is required")
+	}
+	if _, err := uc.repo.GetCustomer(ctx, c.CustomerID); err != nil {
+		return err
+	}
+
+	if c.Contact != "" {
+		var contacts []string
+		var err error
+		switch c.Channel {
+		case ConsentChannelEmail:
+			contacts, err = uc.repo.ListEmails(ctx, c.CustomerID)
+		case ConsentChannelSMS:
+			contacts, err = uc.repo.ListPhoneNumbers(ctx, c.CustomerID)
+		}
+		if err != nil {
+			return err
+		}
+		if !containsContact(contacts, c.Contact) {
+			return errors.New("contact is not a contact point of the customer on this channel")
+		}
+	}
+
+	c.RecordedAt = time.Now()
+	c.RecordedBy, _ = SubjectFromContext(ctx)
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.consents.Record(ctx, c); err != nil {
+			return err
+		}
+		if c.Status == ConsentGranted {
+			return uc.emit(ctx, c.CustomerID, &v1.ConsentGranted{
+				CustomerId: c.CustomerID,
+				ConsentId:  c.ID,
+				Channel:    v1.ConsentChannel(c.Channel),
+				Purpose:    c.Purpose,
+				Contact:    c.Contact,
+			})
+		}
+		return uc.emit(ctx, c.CustomerID, &v1.ConsentWithdrawn{
+			CustomerId: c.CustomerID,
+			ConsentId:  c.ID,
+			Channel:    v1.ConsentChannel(c.Channel),
+			Purpose:    c.Purpose,
+			Contact:    c.Contact,
+		})
+	})
+}
+
+// ListConsents returns the consents of a customer in force, or its whole
+// history when history is set.
+func (uc *CustomerUsecase) ListConsents(ctx context.Context, customerID int64, history bool) ([]*Consent, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ListConsents")
+	defer span.End()
+	if _, err := uc.repo.GetCustomer(ctx, customerID); err != nil {
+		return nil, err
+	}
+	consents, err := uc.consents.List(ctx, []int64{customerID})
+	if err != nil {
+		return nil, err
+	}
+	if history {
+		return consents, nil
+	}
+	return currentConsents(consents), nil
+}
+
+// ListReachableContacts returns the emails and phone numbers that may be
+// contacted for purpose, a page of customers at a time. A contact point is
+// reachable when the consent in force on it is granted, or, without one,
+// the consent in force on its whole channel. Suspended and closed customers
+// are never reachable.
+func (uc *CustomerUsecase) ListReachableContacts(ctx context.Context, purpose string, channel ConsentChannel, pageSize int, pageToken string) (*ReachablePage, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ListReachableContacts")
+	defer span.End()
+	purpose = strings.ToLower(strings.TrimSpace(purpose))
+	if purpose == "" {
+		return nil, errors.New("purpose is required")
+	}
+	if pageSize <= 0 {
+		pageSize = defaultReachablePageSize
+	}
+	if pageSize > maxReachablePageSize {
+		pageSize = maxReachablePageSize
+	}
+	var after int64
+	if pageToken != "" {
+		n, err := strconv.ParseInt(pageToken, 10, 64)
+		if err != nil || n < 0 {
+			return nil, errors.New("invalid page token")
+		}
+		after = n
+	}
+
+	// one extra customer tells whether there is a next page
+	ids, err := uc.consents.Granted(ctx, purpose, channel, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &ReachablePage{}
+	if len(ids) > pageSize {
+		ids = ids[:pageSize]
+		page.NextPageToken = strconv.FormatInt(ids[len(ids)-1], 10)
+	}
+	if len(ids) == 0 {
+		return page, nil
+	}
+
+	consents, err := uc.consents.List(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	inForce := make(map[consentKey]ConsentStatus)
+	for _, c := range currentConsents(consents) {
+		inForce[c.key()] = c.Status
+	}
+	reachable := func(customerID int64, ch ConsentChannel, contact string) bool {
+		if s, ok := inForce[consentKey{customerID, ch, purpose, contact}]; ok {
+			return s == ConsentGranted
+		}
+		return inForce[consentKey{customerID, ch, purpose, ""}] == ConsentGranted
+	}
+
+	// deleted customers are left out
+	customers, err := uc.customersWithContacts(ctx, &CustomerFilter{
+		IDs:      ids,
+		Statuses: []CustomerStatus{CustomerProspect, CustomerActive},
+	})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*Customer, len(customers))
+	for _, c := range customers {
+		byID[c.ID] = c
+	}
+	for _, id := range ids {
+		c, ok := byID[id]
+		if !ok {
+			continue
+		}
+		if channel == ConsentChannelUnspecified || channel == ConsentChannelEmail {
+			for _, e := range c.Emails {
+				if reachable(c.ID, ConsentChannelEmail, e.Email) {
+					page.Contacts = append(page.Contacts, &ReachableContact{CustomerID: c.ID, Channel: ConsentChannelEmail, Contact: e.Email})
+				}
+			}
+		}
+		if channel == ConsentChannelUnspecified || channel == ConsentChannelSMS {
+			for _, p := range c.PhoneNumbers {
+				if reachable(c.ID, ConsentChannelSMS, p.PhoneNumber) {
+					page.Contacts = append(page.Contacts, &ReachableContact{CustomerID: c.ID, Channel: ConsentChannelSMS, Contact: p.PhoneNumber})
+				}
+			}
+		}
+	}
+	return page, nil
+}
+
+// currentConsents picks the consents in force out of a history, oldest
+// first.
+func currentConsents(history []*Consent) []*Consent {
+	latest := make(map[consentKey]*Consent)
+	for _, c := range history {
+		latest[c.key()] = c
+	}
+	out := make([]*Consent, 0, len(latest))
+	for _, c := range history {
+		if latest[c.key()] == c {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func containsContact(contacts []string, contact string) bool {
+	for _, c := range contacts {
+		if c == contact {
+			return true
+		}
+	}
+	return false
+}
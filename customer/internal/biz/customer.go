@@ -2,7 +2,11 @@ package biz
 
 import (
 	"context"
-	"errors"
+	"time"
+
+	v1 "customer/api/customer/v1"
+
+	"google.golang.org/protobuf/proto"
 )
 
 //  entities
@@ -13,6 +17,28 @@ type Customer struct {
 	Name        string
 	DateOfBirth string
 
+	// Status is where the customer is in its lifecycle, only the lifecycle
+	// transitions change it
+	Status          CustomerStatus
+	StatusReason    string
+	StatusChangedAt time.Time
+
+	// contact points, only filled by the queries that load them
+	Emails       []*Email
+	PhoneNumbers []*PhoneNumber
+	Addresses    []*Address
+}
+
+// CustomerFilter narrows exports and lookups. Empty fields match
+// everything. Name matches a case-insensitive substring, dates are
+// YYYY-MM-DD and inclusive.
+type CustomerFilter struct {
+	IDs        []int64
+	Name       string
+	BornAfter  string
+	BornBefore string
+	// Statuses matches customers in any of them
+	Statuses []CustomerStatus
 }
 
 type Email struct {
@@ -41,18 +67,34 @@ type CustomerRepo interface {
     UpdateCustomer(ctx context.Context, c *Customer) error
     DeleteCustomer(ctx context.Context, id int64) error
     GetCustomer(ctx context.Context, id int64) (*Customer, error)
+    // LockCustomer reads the customer on the primary and keeps its status
+    // from changing until the transaction of ctx ends.
+    LockCustomer(ctx context.Context, id int64) (*Customer, error)
     ListCustomer(ctx context.Context) ([]*Customer, error)
+    IterateCustomers(ctx context.Context, filter *CustomerFilter, batchSize int, fn func([]*Customer) error) error
     GetCustomerByEmail(ctx context.Context, email string) (*Customer, error)
     GetCustomerByPhoneNumber(ctx context.Context, phone string) (*Customer, error)
+    // GetCustomersByKeys returns the customers of keys with their contact
+    // points, in the order of keys, nil where none matches.
+    GetCustomersByKeys(ctx context.Context, keys []*CustomerKey) ([]*Customer, error)
+    // SetCustomerStatus moves the customer from status from to c.Status,
+    // recording c.StatusReason and c.StatusChangedAt. It returns false
+    // when the customer is not in status from (anymore).
+    SetCustomerStatus(ctx context.Context, c *Customer, from CustomerStatus) (bool, error)
+    // TouchCustomer records at as the last activity of the customer.
+    TouchCustomer(ctx context.Context, id int64, at time.Time) error
 
     // email
     AddEmail(ctx context.Context, e *Email) error
     DeleteEmail(ctx context.Context, customerID int64, email string) error
+    // VerifyEmail records at as the time the email was verified.
+    VerifyEmail(ctx context.Context, customerID int64, email string, at time.Time) error
     ListEmails(ctx context.Context, customerID int64) ([]string, error)
 
     // phone
     AddPhoneNumber(ctx context.Context, p *PhoneNumber) error
     DeletePhoneNumber(ctx context.Context, customerID int64, phone string) error
+    VerifyPhoneNumber(ctx context.Context, customerID int64, phone string, at time.Time) error
     ListPhoneNumbers(ctx context.Context, customerID int64) ([]string, error)
 
     // address
@@ -68,58 +110,150 @@ type CustomerRepo interface {
 // usecase 
 
 type CustomerUsecase struct {
-	repo CustomerRepo
+	repo     CustomerRepo
+	outbox   OutboxRepo
+	merges   MergeRepo
+	search   SearchRepo
+	changes  ChangeFeedRepo
+	exports  DataExportRepo
+	consents ConsentRepo
+	erasures ErasureRepo
+	rules    *RuleEngine
 }
 
-func NewCustomerUsecase(repo CustomerRepo) *CustomerUsecase {
-	return &CustomerUsecase{repo: repo}
+func NewCustomerUsecase(repo CustomerRepo, outbox OutboxRepo, merges MergeRepo, search SearchRepo, changes ChangeFeedRepo, exports DataExportRepo, consents ConsentRepo, erasures ErasureRepo, rules *RuleEngine) *CustomerUsecase {
+	return &CustomerUsecase{repo: repo, outbox: outbox, merges: merges, search: search, changes: changes, exports: exports, consents: consents, erasures: erasures, rules: rules}
+}
+
+// emit records a domain event in the outbox and marks the customer active,
+// for retention. Call it inside repo.Tx so the event commits together with
+// the change.
+func (uc *CustomerUsecase) emit(ctx context.Context, customerID int64, msg proto.Message) error {
+	e, err := NewEvent(customerID, msg)
+	if err != nil {
+		return err
+	}
+	e.TenantID = TenantFromContext(ctx)
+	e.Actor, _ = SubjectFromContext(ctx)
+	e.TraceContext = traceContext(ctx)
+	if err := uc.outbox.Save(ctx, e); err != nil {
+		return err
+	}
+	return uc.repo.TouchCustomer(ctx, customerID, e.OccurredAt)
 }
 
 // business Logic 
 
 func (uc *CustomerUsecase) CreateCustomer(ctx context.Context, c *Customer) error {
-	if c.Name == "" {
-		return errors.New("name is required")
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.CreateCustomer")
+	defer span.End()
+	c.Status = CustomerProspect
+	if err := uc.rules.Customer.Validate(c); err != nil {
+		return err
 	}
-	return uc.repo.CreateCustomer(ctx, c)
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateCustomer(ctx, c); err != nil {
+			return err
+		}
+		return uc.emit(ctx, c.ID, &v1.CustomerCreated{
+			CustomerId:  c.ID,
+			Name:        c.Name,
+			DateOfBirth: c.DateOfBirth,
+		})
+	})
 }
 
 func (uc *CustomerUsecase) DeleteCustomer(ctx context.Context, id int64) error { // TODO check if customer exissts before deleting
+    ctx, span := tracer.Start(ctx, "CustomerUsecase.DeleteCustomer")
+    defer span.End()
     _, err := uc.repo.GetCustomer(ctx, id)
     if err != nil {
         return err
     }
 
-    return uc.repo.DeleteCustomer(ctx, id)
+    return uc.repo.Tx(ctx, func(ctx context.Context) error {
+        if err := uc.ensureNotErasing(ctx, id); err != nil {
+            return err
+        }
+        if err := uc.repo.DeleteCustomer(ctx, id); err != nil {
+            return err
+        }
+        return uc.emit(ctx, id, &v1.CustomerDeleted{CustomerId: id})
+    })
 }
 
 func (uc *CustomerUsecase) UpdateCustomer(ctx context.Context, c *Customer) error {
-    return uc.repo.UpdateCustomer(ctx, c)
+    ctx, span := tracer.Start(ctx, "CustomerUsecase.UpdateCustomer")
+    defer span.End()
+    if err := uc.rules.Customer.Validate(c); err != nil {
+        return err
+    }
+    return uc.repo.Tx(ctx, func(ctx context.Context) error {
+        // closed customers keep their data as it was
+        if err := uc.lockOpen(ctx, c.ID); err != nil {
+            return err
+        }
+        if err := uc.repo.UpdateCustomer(ctx, c); err != nil {
+            return err
+        }
+        return uc.emit(ctx, c.ID, &v1.CustomerUpdated{
+            CustomerId:  c.ID,
+            Name:        c.Name,
+            DateOfBirth: c.DateOfBirth,
+        })
+    })
 }
 
+// GetCustomer returns the customer, or the survivor when id was merged
+// into another customer.
 func (uc *CustomerUsecase) GetCustomer(ctx context.Context, id int64) (*Customer, error) {
-	return uc.repo.GetCustomer(ctx, id)
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.GetCustomer")
+	defer span.End()
+	c, err := uc.repo.GetCustomer(ctx, id)
+	if err == nil {
+		return c, nil
+	}
+	survivor, serr := uc.merges.Survivor(ctx, id)
+	if serr != nil || survivor == 0 {
+		return nil, err
+	}
+	return uc.repo.GetCustomer(ctx, survivor)
 }
 
 func (uc *CustomerUsecase) GetCustomerByEmail(ctx context.Context, email string) (*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.GetCustomerByEmail")
+	defer span.End()
 	return uc.repo.GetCustomerByEmail(ctx, email)
 }
 
 func (uc *CustomerUsecase) GetCustomerByPhoneNumber(ctx context.Context, phone string) (*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.GetCustomerByPhoneNumber")
+	defer span.End()
 	return uc.repo.GetCustomerByPhoneNumber(ctx, phone)
 }
 
 func (uc *CustomerUsecase) ListCustomer(ctx context.Context) ([]*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ListCustomer")
+	defer span.End()
 	return uc.repo.ListCustomer(ctx)
 }
 
-func (uc *CustomerUsecase) AddEmail(ctx context.Context, id int64, e string) (*Email, error) {
-	if e == "" {
-		return nil, errors.New("email cannot be empty")
-	}
+// customersWithContacts returns the customers matching filter with their contact
+// points. Every match is held in memory, so filter is meant to name a few
+// ids.
+func (uc *CustomerUsecase) customersWithContacts(ctx context.Context, filter *CustomerFilter) ([]*Customer, error) {
+	var out []*Customer
+	err := uc.repo.IterateCustomers(ctx, filter, 500, func(batch []*Customer) error {
+		out = append(out, batch...)
+		return nil
+	})
+	return out, err
+}
 
-	//ensure customer exists
-	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
+func (uc *CustomerUsecase) AddEmail(ctx context.Context, id int64, e string) (*Email, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.AddEmail")
+	defer span.End()
+	if err := uc.rules.Email.Validate(e); err != nil {
 		return nil, err
 	}
 
@@ -128,7 +262,17 @@ func (uc *CustomerUsecase) AddEmail(ctx context.Context, id int64, e string) (*E
 		Email:      e,
 	}
 
-	if err := uc.repo.AddEmail(ctx, email); err != nil {
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		//ensure customer exists and takes new contact points
+		if err := uc.lockOpen(ctx, id); err != nil {
+			return err
+		}
+		if err := uc.repo.AddEmail(ctx, email); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.EmailAdded{CustomerId: id, EmailId: email.ID, Email: email.Email})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -137,20 +281,38 @@ func (uc *CustomerUsecase) AddEmail(ctx context.Context, id int64, e string) (*E
 
 
 func (uc *CustomerUsecase) DeleteEmail(ctx context.Context, id int64, e string) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.DeleteEmail")
+	defer span.End()
 	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
 		return err
 	}
 
-	return uc.repo.DeleteEmail(ctx, id, e)
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.DeleteEmail(ctx, id, e); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.EmailRemoved{CustomerId: id, Email: e})
+	})
 }
 
 
-func (uc *CustomerUsecase) AddPhoneNumber(ctx context.Context, id int64, p string) (*PhoneNumber, error) {
-	if p == "" {
-		return nil, errors.New("phone number cannot be empty")
-	}
+// VerifyEmail records that the customer proved to own email, which keeps
+// it from the unverified contacts retention policy.
+func (uc *CustomerUsecase) VerifyEmail(ctx context.Context, id int64, e string) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.VerifyEmail")
+	defer span.End()
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.VerifyEmail(ctx, id, e, time.Now()); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.EmailVerified{CustomerId: id, Email: e})
+	})
+}
 
-	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
+func (uc *CustomerUsecase) AddPhoneNumber(ctx context.Context, id int64, p string) (*PhoneNumber, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.AddPhoneNumber")
+	defer span.End()
+	if err := uc.rules.PhoneNumber.Validate(p); err != nil {
 		return nil, err
 	}
 
@@ -159,7 +321,16 @@ func (uc *CustomerUsecase) AddPhoneNumber(ctx context.Context, id int64, p strin
 		PhoneNumber: p,
 	}
 
-	if err := uc.repo.AddPhoneNumber(ctx, phone); err != nil {
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.lockOpen(ctx, id); err != nil {
+			return err
+		}
+		if err := uc.repo.AddPhoneNumber(ctx, phone); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.PhoneNumberAdded{CustomerId: id, PhoneNumberId: phone.ID, PhoneNumber: phone.PhoneNumber})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -168,19 +339,36 @@ func (uc *CustomerUsecase) AddPhoneNumber(ctx context.Context, id int64, p strin
 
 
 func (uc *CustomerUsecase) DeletePhoneNumber(ctx context.Context, id int64, p string) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.DeletePhoneNumber")
+	defer span.End()
 	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
 		return err
 	}
-	return uc.repo.DeletePhoneNumber(ctx, id, p)
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.DeletePhoneNumber(ctx, id, p); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.PhoneNumberRemoved{CustomerId: id, PhoneNumber: p})
+	})
 }
 
 
-func (uc *CustomerUsecase) AddAddress(ctx context.Context, id int64, addr string) (*Address, error) {
-	if addr == "" {
-		return nil, errors.New("address cannot be empty")
-	}
+// VerifyPhoneNumber records that the customer proved to own phone.
+func (uc *CustomerUsecase) VerifyPhoneNumber(ctx context.Context, id int64, p string) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.VerifyPhoneNumber")
+	defer span.End()
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.VerifyPhoneNumber(ctx, id, p, time.Now()); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.PhoneNumberVerified{CustomerId: id, PhoneNumber: p})
+	})
+}
 
-	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
+func (uc *CustomerUsecase) AddAddress(ctx context.Context, id int64, addr string) (*Address, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.AddAddress")
+	defer span.End()
+	if err := uc.rules.Address.Validate(addr); err != nil {
 		return nil, err
 	}
 
@@ -189,7 +377,16 @@ func (uc *CustomerUsecase) AddAddress(ctx context.Context, id int64, addr string
 		Address:    addr,
 	}
 
-	if err := uc.repo.AddAddress(ctx, address); err != nil {
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.lockOpen(ctx, id); err != nil {
+			return err
+		}
+		if err := uc.repo.AddAddress(ctx, address); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.AddressAdded{CustomerId: id, AddressId: address.ID, Address: address.Address})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -199,22 +396,35 @@ func (uc *CustomerUsecase) AddAddress(ctx context.Context, id int64, addr string
 
 
 func (uc *CustomerUsecase) DeleteAddress(ctx context.Context, id int64, address string) error {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.DeleteAddress")
+	defer span.End()
 	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
 		return err
 	}
-	return uc.repo.DeleteAddress(ctx, id, address)
+	return uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.DeleteAddress(ctx, id, address); err != nil {
+			return err
+		}
+		return uc.emit(ctx, id, &v1.AddressRemoved{CustomerId: id, Address: address})
+	})
 }
 
 func (uc *CustomerUsecase) ListEmail(ctx context.Context, id int64) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ListEmail")
+	defer span.End()
 	return uc.repo.ListEmails(ctx, id)
 }
 
 func (uc *CustomerUsecase) ListPhoneNumber(ctx context.Context, id int64) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ListPhoneNumber")
+	defer span.End()
 	return uc.repo.ListPhoneNumbers(ctx, id)
 }
 
 
 func (uc *CustomerUsecase) ListAddress(ctx context.Context, id int64) ([]string, error) {
+    ctx, span := tracer.Start(ctx, "CustomerUsecase.ListAddress")
+    defer span.End()
     return uc.repo.ListAddresses(ctx, id) 
 }
 
@@ -225,41 +435,104 @@ func (uc *CustomerUsecase) CreateCustomerWithDetails(
     p *PhoneNumber,
     a *Address,
 ) error {
+    ctx, span := tracer.Start(ctx, "CustomerUsecase.CreateCustomerWithDetails")
+    defer span.End()
+
+    var (
+        emails    []*Email
+        phones    []*PhoneNumber
+        addresses []*Address
+    )
+    if e != nil {
+        emails = append(emails, e)
+    }
+    if p != nil {
+        phones = append(phones, p)
+    }
+    if a != nil {
+        addresses = append(addresses, a)
+    }
+    if err := uc.validateDetails(c, emails, phones, addresses); err != nil {
+        return err
+    }
 
     return uc.repo.Tx(ctx, func(ctx context.Context) error {
+        return uc.createWithDetails(ctx, c, emails, phones, addresses)
+    })
+}
 
-        //Create customer
-        if err := uc.repo.CreateCustomer(ctx, c); err != nil {
+// validateDetails runs the rules a customer and its contact points must
+// pass before they are created.
+func (uc *CustomerUsecase) validateDetails(c *Customer, emails []*Email, phones []*PhoneNumber, addresses []*Address) error {
+    if err := uc.rules.Customer.Validate(c); err != nil {
+        return err
+    }
+    for _, e := range emails {
+        if err := uc.rules.Email.Validate(e.Email); err != nil {
+            return err
+        }
+    }
+    for _, p := range phones {
+        if err := uc.rules.PhoneNumber.Validate(p.PhoneNumber); err != nil {
+            return err
+        }
+    }
+    for _, a := range addresses {
+        if err := uc.rules.Address.Validate(a.Address); err != nil {
             return err
         }
+    }
+    return nil
+}
 
-        // Add email (if provided)
-        if e != nil {
-            e.CustomerID = c.ID
-            if err := uc.repo.AddEmail(ctx, e); err != nil {
-                return err
-            }
+// createWithDetails creates c with its contact points. It must run inside
+// repo.Tx.
+func (uc *CustomerUsecase) createWithDetails(ctx context.Context, c *Customer, emails []*Email, phones []*PhoneNumber, addresses []*Address) error {
+    //Create customer, every customer starts as a prospect
+    c.Status = CustomerProspect
+    if err := uc.repo.CreateCustomer(ctx, c); err != nil {
+        return err
+    }
+    if err := uc.emit(ctx, c.ID, &v1.CustomerCreated{
+        CustomerId:  c.ID,
+        Name:        c.Name,
+        DateOfBirth: c.DateOfBirth,
+    }); err != nil {
+        return err
+    }
+
+    // Add emails
+    for _, e := range emails {
+        e.CustomerID = c.ID
+        if err := uc.repo.AddEmail(ctx, e); err != nil {
+            return err
         }
+        if err := uc.emit(ctx, c.ID, &v1.EmailAdded{CustomerId: c.ID, EmailId: e.ID, Email: e.Email}); err != nil {
+            return err
+        }
+    }
 
-        // Add phone number (if provided)
-        if p != nil {
-            p.CustomerID = c.ID
-            if err := uc.repo.AddPhoneNumber(ctx, p); err != nil {
-                return err
-            }
+    // Add phone numbers
+    for _, p := range phones {
+        p.CustomerID = c.ID
+        if err := uc.repo.AddPhoneNumber(ctx, p); err != nil {
+            return err
+        }
+        if err := uc.emit(ctx, c.ID, &v1.PhoneNumberAdded{CustomerId: c.ID, PhoneNumberId: p.ID, PhoneNumber: p.PhoneNumber}); err != nil {
+            return err
         }
+    }
 
-        //Add address (if provided)
-        if a != nil {
-            a.CustomerID = c.ID
-            if err := uc.repo.AddAddress(ctx, a); err != nil {
-                return err
-            }
+    //Add addresses
+    for _, a := range addresses {
+        a.CustomerID = c.ID
+        if err := uc.repo.AddAddress(ctx, a); err != nil {
+            return err
+        }
+        if err := uc.emit(ctx, c.ID, &v1.AddressAdded{CustomerId: c.ID, AddressId: a.ID, Address: a.Address}); err != nil {
+            return err
         }
+    }
 
-        return nil
-    })
+    return nil
 }
-
-
-
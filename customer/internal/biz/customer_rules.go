@@ -8,4 +8,86 @@ package biz
 
 // gRPC
 
-// HTTP
\ No newline at end of file
+// HTTP
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+const dateOfBirthLayout = "2006-01-02"
+
+// NewRuleEngine returns the customer rules.
+func NewRuleEngine() *RuleEngine {
+	return &RuleEngine{
+		Customer: RuleSet[*Customer]{
+			{Name: "name_required", Check: func(c *Customer) error {
+				if strings.TrimSpace(c.Name) == "" {
+					return errors.New("name is required")
+				}
+				return nil
+			}},
+			{Name: "date_of_birth_format", Check: func(c *Customer) error {
+				if c.DateOfBirth == "" {
+					return nil
+				}
+				dob, err := time.Parse(dateOfBirthLayout, c.DateOfBirth)
+				if err != nil {
+					return errors.New("date of birth must be formatted as YYYY-MM-DD")
+				}
+				if dob.After(time.Now()) {
+					return errors.New("date of birth cannot be in the future")
+				}
+				return nil
+			}},
+		},
+		Email: RuleSet[string]{
+			{Name: "email_required", Check: func(e string) error {
+				if e == "" {
+					return errors.New("email cannot be empty")
+				}
+				return nil
+			}},
+			{Name: "email_format", Check: func(e string) error {
+				if a, err := mail.ParseAddress(e); err != nil || a.Address != e {
+					return errors.New("email is not a valid address")
+				}
+				return nil
+			}},
+		},
+		PhoneNumber: RuleSet[string]{
+			{Name: "phone_number_required", Check: func(p string) error {
+				if p == "" {
+					return errors.New("phone number cannot be empty")
+				}
+				return nil
+			}},
+			{Name: "phone_number_format", Check: func(p string) error {
+				digits := 0
+				for i, r := range p {
+					switch {
+					case r >= '0' && r <= '9':
+						digits++
+					case r == '+' && i == 0, r == ' ', r == '-', r == '(', r == ')':
+					default:
+						return errors.New("phone number may only contain digits, spaces and + - ( )")
+					}
+				}
+				if digits < 6 || digits > 15 {
+					return errors.New("phone number must have between 6 and 15 digits")
+				}
+				return nil
+			}},
+		},
+		Address: RuleSet[string]{
+			{Name: "address_required", Check: func(a string) error {
+				if strings.TrimSpace(a) == "" {
+					return errors.New("address cannot be empty")
+				}
+				return nil
+			}},
+		},
+	}
+}
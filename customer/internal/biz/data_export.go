@@ -0,0 +1,147 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	v1 "customer/api/customer/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// dataBundleVersion is the version of the CustomerDataBundle format.
+const dataBundleVersion = 1
+
+// DataExport is a subject access bundle delivered for a customer. Only its
+// checksum is kept on record, the bundle itself is not stored.
+type DataExport struct {
+	ID         int64
+	CustomerID int64
+	Bundle     []byte
+	Checksum   string
+	Actor      string
+	ExportedAt time.Time
+}
+
+type DataExportRepo interface {
+	// Record keeps the delivery of e without its bundle.
+	Record(ctx context.Context, e *DataExport) error
+}
+
+// ExportCustomerData collects everything stored about a customer into a
+// JSON bundle: the profile with its contact points, the change history,
+// the customers merged into it and the consent history. The checksum of
+// the bundle is recorded before it is returned.
+func (uc *CustomerUsecase) ExportCustomerData(ctx context.Context, id int64) (*DataExport, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ExportCustomerData")
+	defer span.End()
+	if _, err := uc.repo.GetCustomer(ctx, id); err != nil {
+		return nil, err
+	}
+	customers, err := uc.customersWithContacts(ctx, &CustomerFilter{IDs: []int64{id}})
+	if err != nil {
+		return nil, err
+	}
+	c := customers[0]
+
+	now := time.Now().UTC()
+	bundle := &v1.CustomerDataBundle{
+		Version:     dataBundleVersion,
+		TenantId:    TenantFromContext(ctx),
+		GeneratedAt: timestamppb.New(now),
+		Customer: &v1.GetCustomerReply{
+			Id:          c.ID,
+			Name:        c.Name,
+			DateOfBirth: c.DateOfBirth,
+		},
+	}
+	for _, e := range c.Emails {
+		bundle.Customer.Emails = append(bundle.Customer.Emails, e.Email)
+	}
+	for _, p := range c.PhoneNumbers {
+		bundle.Customer.PhoneNumbers = append(bundle.Customer.PhoneNumbers, p.PhoneNumber)
+	}
+	for _, a := range c.Addresses {
+		bundle.Customer.Addresses = append(bundle.Customer.Addresses, a.Address)
+	}
+
+	var seq int64
+	for {
+		changes, err := uc.changes.After(ctx, seq, []int64{id}, watchBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range changes {
+			bundle.History = append(bundle.History, &v1.CustomerDataChange{
+				Sequence: ch.Sequence,
+				Type:     v1.ChangeType(ch.Type),
+				Event: &anypb.Any{
+					TypeUrl: "type.googleapis.com/" + ch.Event.Type,
+					Value:   ch.Event.Payload,
+				},
+				OccurredAt: timestamppb.New(ch.Event.OccurredAt),
+				Actor:      ch.Event.Actor,
+			})
+			seq = ch.Sequence
+		}
+		if len(changes) < watchBatchSize {
+			break
+		}
+	}
+
+	merges, err := uc.merges.Merged(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range merges {
+		bundle.Merges = append(bundle.Merges, &v1.CustomerDataMerge{
+			MergedId:     m.MergedID,
+			Name:         m.Name,
+			DateOfBirth:  m.DateOfBirth,
+			Emails:       m.Emails,
+			PhoneNumbers: m.PhoneNumbers,
+			Addresses:    m.Addresses,
+			MergedAt:     timestamppb.New(m.MergedAt),
+		})
+	}
+
+	consents, err := uc.consents.List(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range consents {
+		bundle.Consents = append(bundle.Consents, &v1.Consent{
+			Id:            c.ID,
+			CustomerId:    c.CustomerID,
+			Channel:       v1.ConsentChannel(c.Channel),
+			Purpose:       c.Purpose,
+			Status:        v1.ConsentStatus(c.Status),
+			Contact:       c.Contact,
+			Source:        c.Source,
+			PolicyVersion: c.PolicyVersion,
+			RecordedBy:    c.RecordedBy,
+			RecordedAt:    timestamppb.New(c.RecordedAt),
+		})
+	}
+
+	b, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	e := &DataExport{
+		CustomerID: id,
+		Bundle:     b,
+		Checksum:   "sha256:" + hex.EncodeToString(sum[:]),
+		ExportedAt: now,
+	}
+	e.Actor, _ = SubjectFromContext(ctx)
+	if err := uc.exports.Record(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
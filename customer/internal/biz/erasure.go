@@ -0,0 +1,390 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "customer/api/customer/v1"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ErasureStatus mirrors api.customer.v1.ErasureStatus.
+type ErasureStatus int32
+
+const (
+	ErasureUnspecified ErasureStatus = iota
+	ErasureScheduled
+	ErasureCompleted
+	ErasureCancelled
+)
+
+// Erasure is a request to erase the personal data of a customer. Once
+// completed it is chained to the erasure completed before it: Hash covers
+// the record and PrevHash, so changing or removing a record breaks the
+// chain after it.
+type Erasure struct {
+	ID          int64
+	TenantID    string
+	CustomerID  int64
+	Status      ErasureStatus
+	Reason      string
+	RequestedBy string
+	RequestedAt time.Time
+	EraseAfter  time.Time
+	CompletedAt time.Time
+	CancelledAt time.Time
+	PrevHash    string
+	Hash        string
+}
+
+// ErrErasureNotScheduled is returned when an erasure was completed or
+// cancelled in the meantime.
+var ErrErasureNotScheduled = errors.New("erasure is not scheduled")
+
+// ErrErasureScheduled is returned for changes that would move a customer's
+// data out of reach of its scheduled erasure.
+var ErrErasureScheduled = errors.New("customer has a scheduled erasure")
+
+type ErasureRepo interface {
+	// Create records the scheduled erasure e.
+	Create(ctx context.Context, e *Erasure) error
+	Get(ctx context.Context, id int64) (*Erasure, error)
+	// Active returns the scheduled or completed erasure of a customer, nil
+	// when there is none.
+	Active(ctx context.Context, customerID int64) (*Erasure, error)
+	// Cancel marks e cancelled, ErrErasureNotScheduled when it no longer
+	// is scheduled.
+	Cancel(ctx context.Context, e *Erasure) error
+	// Due returns the scheduled erasures of every tenant to run at now,
+	// oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]*Erasure, error)
+	// LastHash returns the hash of the latest completed erasure, empty
+	// when there is none.
+	LastHash(ctx context.Context) (string, error)
+	// Complete stores e as completed, ErrErasureNotScheduled when it no
+	// longer is scheduled. It fails when another erasure was chained to
+	// e.PrevHash first.
+	Complete(ctx context.Context, e *Erasure) error
+	// Anonymize replaces the personal data of the customers, deleted ones
+	// included, their contact points, the contacts of their consents and
+	// the snapshots of customers merged into them with random tokens.
+	// Emails and phone numbers become free for other customers.
+	Anonymize(ctx context.Context, customerIDs []int64) error
+	// ScrubEvents rewrites the payloads of the outbox events and change
+	// feed entries of the customers with scrub.
+	ScrubEvents(ctx context.Context, customerIDs []int64, scrub func(eventType string, payload []byte) []byte) error
+}
+
+// ErasureUsecase erases customers for right-to-erasure requests.
+type ErasureUsecase struct {
+	repo     CustomerRepo
+	outbox   OutboxRepo
+	merges   MergeRepo
+	erasures ErasureRepo
+	consents ConsentRepo
+	// idempotency keeps replies that may name the customer
+	idempotency IdempotencyRepo
+	grace       time.Duration
+	log         *log.Helper
+}
+
+func NewErasureUsecase(c *conf.Data, repo CustomerRepo, outbox OutboxRepo, merges MergeRepo, erasures ErasureRepo, consents ConsentRepo, idempotency IdempotencyRepo, logger log.Logger) *ErasureUsecase {
+	uc := &ErasureUsecase{
+		repo:        repo,
+		outbox:      outbox,
+		merges:      merges,
+		erasures:    erasures,
+		consents:    consents,
+		idempotency: idempotency,
+		log:         log.NewHelper(logger),
+	}
+	if g := c.GetErasure().GetGracePeriod(); g != nil {
+		uc.grace = g.AsDuration()
+	}
+	return uc
+}
+
+// EraseCustomer asks for the erasure of a customer. Without a grace period
+// it runs right away, otherwise it is scheduled and can be cancelled
+// until it runs.
+func (uc *ErasureUsecase) EraseCustomer(ctx context.Context, customerID int64, reason string) (*Erasure, error) {
+	if _, err := uc.repo.GetCustomer(ctx, customerID); err != nil {
+		return nil, err
+	}
+	active, err := uc.erasures.Active(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		if active.Status == ErasureCompleted {
+			return nil, errors.New("customer is already erased")
+		}
+		return nil, errors.New("customer already has a scheduled erasure")
+	}
+
+	now := erasureTime(time.Now())
+	e := &Erasure{
+		TenantID:    TenantFromContext(ctx),
+		CustomerID:  customerID,
+		Status:      ErasureScheduled,
+		Reason:      reason,
+		RequestedAt: now,
+		EraseAfter:  now.Add(uc.grace),
+	}
+	e.RequestedBy, _ = SubjectFromContext(ctx)
+
+	err = uc.repo.Tx(ctx, func(ctx context.Context) error {
+		if err := uc.erasures.Create(ctx, e); err != nil {
+			return err
+		}
+		if uc.grace > 0 {
+			return nil
+		}
+		return uc.erase(ctx, e)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// CancelCustomerErasure cancels a scheduled erasure.
+func (uc *ErasureUsecase) CancelCustomerErasure(ctx context.Context, id int64) (*Erasure, error) {
+	e, err := uc.erasures.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != ErasureScheduled {
+		return nil, fmt.Errorf("only scheduled erasures can be cancelled: %w", ErrErasureNotScheduled)
+	}
+	e.Status = ErasureCancelled
+	e.CancelledAt = erasureTime(time.Now())
+	if err := uc.erasures.Cancel(ctx, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// RunDue runs up to limit erasures whose grace period is over, each in the
+// tenant it was asked for, and returns how many it completed. A failed
+// erasure is logged and retried on the next run.
+func (uc *ErasureUsecase) RunDue(ctx context.Context, limit int) (int, error) {
+	due, err := uc.erasures.Due(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, e := range due {
+		ectx := NewTenantContext(ctx, e.TenantID)
+		if e.RequestedBy != "" {
+			ectx = NewSubjectContext(ectx, e.RequestedBy)
+		}
+		err := uc.repo.Tx(ectx, func(ctx context.Context) error {
+			return uc.erase(ctx, e)
+		})
+		if err != nil {
+			uc.log.Errorf("erasure %d of customer %d: %v", e.ID, e.CustomerID, err)
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+// erase replaces the personal data of the customer of e and completes e.
+// It must run inside CustomerRepo.Tx.
+func (uc *ErasureUsecase) erase(ctx context.Context, e *Erasure) error {
+	ids := []int64{e.CustomerID}
+	merges, err := uc.merges.Merged(ctx, e.CustomerID)
+	if err != nil {
+		return err
+	}
+	for _, m := range merges {
+		ids = append(ids, m.MergedID)
+	}
+
+	// closed first, in the same transaction: contact points added from now
+	// on are refused, and ones added meanwhile are anonymized below
+	if err := uc.closeErased(ctx, e.CustomerID); err != nil {
+		return err
+	}
+	// the tokens replacing the contact points must not be reachable
+	for _, id := range ids {
+		if err := uc.withdrawConsents(ctx, id); err != nil {
+			return err
+		}
+	}
+	// the customers merged into it are the same person
+	if err := uc.erasures.Anonymize(ctx, ids); err != nil {
+		return err
+	}
+	if err := uc.erasures.ScrubEvents(ctx, ids, scrubEvent); err != nil {
+		return err
+	}
+	if err := uc.idempotency.Forget(ctx, ids); err != nil {
+		return err
+	}
+
+	prev, err := uc.erasures.LastHash(ctx)
+	if err != nil {
+		return err
+	}
+	e.Status = ErasureCompleted
+	e.CompletedAt = erasureTime(time.Now())
+	e.PrevHash = prev
+	e.Hash = erasureHash(e)
+	if err := uc.erasures.Complete(ctx, e); err != nil {
+		return err
+	}
+
+	return uc.emit(ctx, e.CustomerID, &v1.CustomerErased{CustomerId: e.CustomerID, ErasureId: e.ID})
+}
+
+// erasedStatusReason is the reason recorded when an erasure closes its
+// customer.
+const erasedStatusReason = "erased"
+
+// closeErased closes the customer of an erasure unless it is closed or
+// deleted already. It must run inside CustomerRepo.Tx.
+func (uc *ErasureUsecase) closeErased(ctx context.Context, id int64) error {
+	found, err := uc.repo.GetCustomersByKeys(ctx, []*CustomerKey{{ID: id}})
+	if err != nil {
+		return err
+	}
+	c := found[0]
+	if c == nil || c.Status == CustomerClosed {
+		return nil
+	}
+	from := c.Status
+	c.Status, c.StatusReason, c.StatusChangedAt = CustomerClosed, erasedStatusReason, time.Now()
+	ok, err := uc.repo.SetCustomerStatus(ctx, c, from)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the next run retries it
+		return fmt.Errorf("status of customer %d changed meanwhile", id)
+	}
+	return uc.emit(ctx, id, &v1.CustomerStatusChanged{
+		CustomerId: id,
+		From:       v1.CustomerStatus(from),
+		To:         v1.CustomerStatus(CustomerClosed),
+		Reason:     erasedStatusReason,
+	})
+}
+
+// emit records msg in the outbox as an event of the erasure.
+func (uc *ErasureUsecase) emit(ctx context.Context, customerID int64, msg proto.Message) error {
+	e, err := NewEvent(customerID, msg)
+	if err != nil {
+		return err
+	}
+	e.TenantID = TenantFromContext(ctx)
+	e.Actor, _ = SubjectFromContext(ctx)
+	e.TraceContext = traceContext(ctx)
+	return uc.outbox.Save(ctx, e)
+}
+
+// ensureNotErasing fails with ErrErasureScheduled while the customer has a
+// scheduled erasure: once merged away or deleted, the erasure would no
+// longer find its data.
+func (uc *CustomerUsecase) ensureNotErasing(ctx context.Context, customerID int64) error {
+	e, err := uc.erasures.Active(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if e != nil && e.Status == ErasureScheduled {
+		return ErrErasureScheduled
+	}
+	return nil
+}
+
+// withdrawConsents withdraws the consents in force a customer granted.
+func (uc *ErasureUsecase) withdrawConsents(ctx context.Context, customerID int64) error {
+	history, err := uc.consents.List(ctx, []int64{customerID})
+	if err != nil {
+		return err
+	}
+	for _, c := range currentConsents(history) {
+		if c.Status != ConsentGranted {
+			continue
+		}
+		w := &Consent{
+			CustomerID:    customerID,
+			Channel:       c.Channel,
+			Purpose:       c.Purpose,
+			Status:        ConsentWithdrawn,
+			Contact:       c.Contact,
+			Source:        "erasure",
+			PolicyVersion: c.PolicyVersion,
+			RecordedAt:    time.Now(),
+		}
+		w.RecordedBy, _ = SubjectFromContext(ctx)
+		if err := uc.consents.Record(ctx, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// erasureTime is t as stored, so hashes computed before and after a round
+// trip through the database agree.
+func erasureTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// erasureHash chains e to the erasure completed before it.
+func erasureHash(e *Erasure) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%s\n%d\n%q\n%s\n%s\n%s\n%s",
+		e.ID, e.TenantID, e.CustomerID, e.Reason, e.RequestedBy,
+		e.RequestedAt.Format(time.RFC3339Nano), e.CompletedAt.Format(time.RFC3339Nano), e.PrevHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// personalFields are the event fields holding personal data.
+var personalFields = map[protoreflect.Name]bool{
+	"name":          true,
+	"date_of_birth": true,
+	"email":         true,
+	"emails":        true,
+	"phone_number":  true,
+	"phone_numbers": true,
+	"address":       true,
+	"addresses":     true,
+	"contact":       true,
+}
+
+// scrubEvent clears the personal fields of an event payload. Payloads of
+// unknown types are dropped.
+func scrubEvent(eventType string, payload []byte) []byte {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(eventType))
+	if err != nil {
+		return nil
+	}
+	m := mt.New()
+	if err := proto.Unmarshal(payload, m.Interface()); err != nil {
+		return nil
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); personalFields[fd.Name()] {
+			m.Clear(fd)
+		}
+	}
+	b, err := proto.Marshal(m.Interface())
+	if err != nil {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Event is a domain event waiting in (or delivered from) the outbox.
+// Type is the full protobuf name of the payload, e.g.
+// "api.customer.v1.CustomerCreated".
+type Event struct {
+	ID         int64
+	CustomerID int64
+	Type       string
+	Payload    []byte
+	OccurredAt time.Time
+	TenantID   string
+	// Actor is the subject whose request produced the event, empty for
+	// unauthenticated requests.
+	Actor string
+	// TraceContext is the W3C trace context of the request that produced
+	// the event, passed on to consumers.
+	TraceContext  map[string]string
+	Attempts      int32
+	NextAttemptAt time.Time
+}
+
+// NewEvent wraps a protobuf domain event for the outbox.
+func NewEvent(customerID int64, msg proto.Message) (*Event, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		CustomerID: customerID,
+		Type:       string(msg.ProtoReflect().Descriptor().FullName()),
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}, nil
+}
+
+// Message decodes the payload back into its typed protobuf message.
+func (e *Event) Message() (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(e.Type))
+	if err != nil {
+		return nil, err
+	}
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(e.Payload, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// OutboxRepo stores events next to the change that produced them. Save
+// must join the transaction carried by ctx so both commit together.
+type OutboxRepo interface {
+	Save(ctx context.Context, e *Event) error
+	// Pending returns undelivered events already in the change feed and
+	// due for an attempt, in the order they were written. It leaves out the events of customers whose
+	// earlier event is still backing off, so a backlog of failing events
+	// cannot take the whole batch.
+	Pending(ctx context.Context, limit int) ([]*Event, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, attempts int32, next time.Time, reason string) error
+}
+
+// EventPublisher delivers events to downstream consumers. Publish may be
+// called more than once for the same event, consumers dedupe on Event.ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, e *Event) error
+}
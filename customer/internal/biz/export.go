@@ -0,0 +1,280 @@
+package biz
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	defaultExportBatchSize = 500
+	// exportValueSeparator joins multi-valued columns in CSV and Parquet.
+	exportValueSeparator = ";"
+)
+
+type ExportFormat int32
+
+// ExportFormat mirrors api.customer.v1.ExportFormat.
+const (
+	ExportFormatUnspecified ExportFormat = iota
+	ExportCSV
+	ExportNDJSON
+	ExportParquet
+)
+
+// Export columns. Every column except id holds PII.
+const (
+	ColumnID           = "id"
+	ColumnName         = "name"
+	ColumnDateOfBirth  = "date_of_birth"
+	ColumnEmails       = "emails"
+	ColumnPhoneNumbers = "phone_numbers"
+	ColumnAddresses    = "addresses"
+)
+
+var exportColumns = []string{ColumnID, ColumnName, ColumnDateOfBirth, ColumnEmails, ColumnPhoneNumbers, ColumnAddresses}
+
+type ExportOptions struct {
+	Format ExportFormat
+	// Exclude drops columns from the output.
+	Exclude []string
+	// Mask keeps columns but hides most of every value.
+	Mask      []string
+	BatchSize int
+}
+
+// ExportCustomers streams the customers matching filter to w in the
+// requested format and returns how many were written.
+func (uc *CustomerUsecase) ExportCustomers(ctx context.Context, filter *CustomerFilter, opts ExportOptions, w io.Writer) (int64, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ExportCustomers")
+	defer span.End()
+	columns, err := exportColumnsFor(opts)
+	if err != nil {
+		return 0, err
+	}
+	masked := make(map[string]bool, len(opts.Mask))
+	for _, c := range opts.Mask {
+		if c == ColumnID {
+			return 0, fmt.Errorf("column %q cannot be masked", c)
+		}
+		if !containsString(exportColumns, c) {
+			return 0, fmt.Errorf("unknown column %q", c)
+		}
+		masked[c] = true
+	}
+
+	var ew exportWriter
+	switch opts.Format {
+	case ExportCSV, ExportFormatUnspecified:
+		ew, err = newCSVExportWriter(w, columns)
+	case ExportNDJSON:
+		ew = &ndjsonExportWriter{enc: json.NewEncoder(w), columns: columns}
+	case ExportParquet:
+		ew, err = newParquetExportWriter(w, columns)
+	default:
+		return 0, fmt.Errorf("unsupported export format %d", opts.Format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultExportBatchSize
+	}
+
+	var n int64
+	err = uc.repo.IterateCustomers(ctx, filter, batchSize, func(customers []*Customer) error {
+		for _, c := range customers {
+			if err := ew.Write(exportRow(c, masked)); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, ew.Close()
+}
+
+func exportColumnsFor(opts ExportOptions) ([]string, error) {
+	for _, c := range opts.Exclude {
+		if !containsString(exportColumns, c) {
+			return nil, fmt.Errorf("unknown column %q", c)
+		}
+	}
+	columns := make([]string, 0, len(exportColumns))
+	for _, c := range exportColumns {
+		if !containsString(opts.Exclude, c) {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("every column is excluded")
+	}
+	return columns, nil
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// exportRow flattens c into column values, masking the columns in masked.
+func exportRow(c *Customer, masked map[string]bool) map[string][]string {
+	row := map[string][]string{
+		ColumnID:          {strconv.FormatInt(c.ID, 10)},
+		ColumnName:        {c.Name},
+		ColumnDateOfBirth: {c.DateOfBirth},
+	}
+	for _, e := range c.Emails {
+		row[ColumnEmails] = append(row[ColumnEmails], e.Email)
+	}
+	for _, p := range c.PhoneNumbers {
+		row[ColumnPhoneNumbers] = append(row[ColumnPhoneNumbers], p.PhoneNumber)
+	}
+	for _, a := range c.Addresses {
+		row[ColumnAddresses] = append(row[ColumnAddresses], a.Address)
+	}
+	for col := range masked {
+		for i, v := range row[col] {
+			row[col][i] = MaskValue(col, v)
+		}
+	}
+	return row
+}
+
+// MaskValue hides most of v while keeping enough to recognise it:
+// the first letter of a name, the domain of an email, the last digits of
+// a phone number and the year of a date of birth.
+func MaskValue(column, v string) string {
+	if v == "" {
+		return v
+	}
+	switch column {
+	case ColumnEmails:
+		if at := strings.LastIndexByte(v, '@'); at > 0 {
+			return v[:1] + "***" + v[at:]
+		}
+	case ColumnPhoneNumbers:
+		if len(v) > 3 {
+			return strings.Repeat("*", len(v)-3) + v[len(v)-3:]
+		}
+	case ColumnDateOfBirth:
+		if len(v) >= 4 {
+			return v[:4] + "-**-**"
+		}
+	case ColumnName:
+		r := []rune(v)
+		return string(r[0]) + "***"
+	}
+	return "***"
+}
+
+type exportWriter interface {
+	Write(row map[string][]string) error
+	Close() error
+}
+
+type csvExportWriter struct {
+	w       *csv.Writer
+	columns []string
+	record  []string
+}
+
+func newCSVExportWriter(w io.Writer, columns []string) (*csvExportWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return nil, err
+	}
+	return &csvExportWriter{w: cw, columns: columns, record: make([]string, len(columns))}, nil
+}
+
+func (w *csvExportWriter) Write(row map[string][]string) error {
+	for i, c := range w.columns {
+		w.record[i] = strings.Join(row[c], exportValueSeparator)
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *csvExportWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type ndjsonExportWriter struct {
+	enc     *json.Encoder
+	columns []string
+}
+
+func (w *ndjsonExportWriter) Write(row map[string][]string) error {
+	obj := make(map[string]any, len(w.columns))
+	for _, c := range w.columns {
+		switch c {
+		case ColumnID:
+			id, _ := strconv.ParseInt(row[c][0], 10, 64)
+			obj[c] = id
+		case ColumnName, ColumnDateOfBirth:
+			obj[c] = row[c][0]
+		default:
+			values := row[c]
+			if values == nil {
+				values = []string{}
+			}
+			obj[c] = values
+		}
+	}
+	return w.enc.Encode(obj)
+}
+
+func (w *ndjsonExportWriter) Close() error { return nil }
+
+type parquetExportWriter struct {
+	pw      *writer.CSVWriter
+	columns []string
+	record  []*string
+}
+
+func newParquetExportWriter(w io.Writer, columns []string) (*parquetExportWriter, error) {
+	md := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if c == ColumnID {
+			md = append(md, "name="+c+", type=INT64")
+			continue
+		}
+		md = append(md, "name="+c+", type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL")
+	}
+	pw, err := writer.NewCSVWriterFromWriter(md, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	// keep row groups small so the export streams instead of buffering
+	pw.RowGroupSize = 8 * 1024 * 1024
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return &parquetExportWriter{pw: pw, columns: columns, record: make([]*string, len(columns))}, nil
+}
+
+func (w *parquetExportWriter) Write(row map[string][]string) error {
+	for i, c := range w.columns {
+		v := strings.Join(row[c], exportValueSeparator)
+		w.record[i] = &v
+	}
+	return w.pw.WriteString(w.record)
+}
+
+func (w *parquetExportWriter) Close() error {
+	return w.pw.WriteStop()
+}
@@ -0,0 +1,182 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// exportRepo serves the customers of an export.
+type exportRepo struct {
+	CustomerRepo
+	customers []*Customer
+}
+
+func (r *exportRepo) IterateCustomers(_ context.Context, _ *CustomerFilter, batchSize int, fn func([]*Customer) error) error {
+	for i := 0; i < len(r.customers); i += batchSize {
+		end := i + batchSize
+		if end > len(r.customers) {
+			end = len(r.customers)
+		}
+		if err := fn(r.customers[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportFixture() []*Customer {
+	return []*Customer{
+		{
+			ID:           1,
+			Name:         "Jane Doe",
+			DateOfBirth:  "1990-04-12",
+			Emails:       []*Email{{Email: "jane@example.com"}, {Email: "jd@example.org"}},
+			PhoneNumbers: []*PhoneNumber{{PhoneNumber: "+4915112345678"}},
+			Addresses:    []*Address{{Address: "Main Street 1, Berlin"}},
+		},
+		{ID: 2, Name: "Émile", DateOfBirth: "1985-01-01"},
+	}
+}
+
+func TestMaskValue(t *testing.T) {
+	for _, c := range []struct {
+		column, value, want string
+	}{
+		{ColumnName, "Jane Doe", "J***"},
+		{ColumnName, "Émile", "É***"},
+		{ColumnEmails, "jane@example.com", "j***@example.com"},
+		{ColumnEmails, "not-an-email", "***"},
+		{ColumnPhoneNumbers, "+4915112345678", "***********678"},
+		{ColumnPhoneNumbers, "123", "***"},
+		{ColumnDateOfBirth, "1990-04-12", "1990-**-**"},
+		{ColumnDateOfBirth, "199", "***"},
+		{ColumnAddresses, "Main Street 1, Berlin", "***"},
+		{ColumnName, "", ""},
+	} {
+		if got := MaskValue(c.column, c.value); got != c.want {
+			t.Errorf("MaskValue(%s, %q) = %q, want %q", c.column, c.value, got, c.want)
+		}
+	}
+}
+
+func TestExportRow(t *testing.T) {
+	c := exportFixture()[0]
+	for _, tc := range []struct {
+		name   string
+		masked []string
+		want   map[string][]string
+	}{
+		{
+			name: "plain",
+			want: map[string][]string{
+				ColumnID:           {"1"},
+				ColumnName:         {"Jane Doe"},
+				ColumnDateOfBirth:  {"1990-04-12"},
+				ColumnEmails:       {"jane@example.com", "jd@example.org"},
+				ColumnPhoneNumbers: {"+4915112345678"},
+				ColumnAddresses:    {"Main Street 1, Berlin"},
+			},
+		},
+		{
+			name:   "masked",
+			masked: []string{ColumnName, ColumnEmails, ColumnAddresses},
+			want: map[string][]string{
+				ColumnID:           {"1"},
+				ColumnName:         {"J***"},
+				ColumnDateOfBirth:  {"1990-04-12"},
+				ColumnEmails:       {"j***@example.com", "j***@example.org"},
+				ColumnPhoneNumbers: {"+4915112345678"},
+				ColumnAddresses:    {"***"},
+			},
+		},
+	} {
+		masked := map[string]bool{}
+		for _, m := range tc.masked {
+			masked[m] = true
+		}
+		if got := exportRow(c, masked); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: exportRow = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	// masking works on a copy, the customer keeps its values
+	if c.Emails[0].Email != "jane@example.com" {
+		t.Errorf("exportRow changed the customer: %q", c.Emails[0].Email)
+	}
+}
+
+func TestExportCustomers(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    ExportOptions
+		want    string
+		wantErr string
+	}{
+		{
+			name: "csv",
+			opts: ExportOptions{Format: ExportCSV},
+			want: "id,name,date_of_birth,emails,phone_numbers,addresses\n" +
+				"1,Jane Doe,1990-04-12,jane@example.com;jd@example.org,+4915112345678,\"Main Street 1, Berlin\"\n" +
+				"2,Émile,1985-01-01,,,\n",
+		},
+		{
+			name: "csv by default, excluded and masked",
+			opts: ExportOptions{Exclude: []string{ColumnAddresses, ColumnPhoneNumbers}, Mask: []string{ColumnEmails, ColumnDateOfBirth}},
+			want: "id,name,date_of_birth,emails\n" +
+				"1,Jane Doe,1990-**-**,j***@example.com;j***@example.org\n" +
+				"2,Émile,1985-**-**,\n",
+		},
+		{
+			name: "ndjson",
+			opts: ExportOptions{Format: ExportNDJSON, BatchSize: 1},
+			want: `{"addresses":["Main Street 1, Berlin"],"date_of_birth":"1990-04-12","emails":["jane@example.com","jd@example.org"],"id":1,"name":"Jane Doe","phone_numbers":["+4915112345678"]}` + "\n" +
+				`{"addresses":[],"date_of_birth":"1985-01-01","emails":[],"id":2,"name":"Émile","phone_numbers":[]}` + "\n",
+		},
+		{
+			name: "ndjson excluded and masked",
+			opts: ExportOptions{Format: ExportNDJSON, Exclude: []string{ColumnID, ColumnEmails, ColumnAddresses}, Mask: []string{ColumnName, ColumnPhoneNumbers}},
+			want: `{"date_of_birth":"1990-04-12","name":"J***","phone_numbers":["***********678"]}` + "\n" +
+				`{"date_of_birth":"1985-01-01","name":"É***","phone_numbers":[]}` + "\n",
+		},
+		{
+			name:    "unknown excluded column",
+			opts:    ExportOptions{Exclude: []string{"ssn"}},
+			wantErr: `unknown column "ssn"`,
+		},
+		{
+			name:    "unknown masked column",
+			opts:    ExportOptions{Mask: []string{"ssn"}},
+			wantErr: `unknown column "ssn"`,
+		},
+		{
+			name:    "masked id",
+			opts:    ExportOptions{Mask: []string{ColumnID}},
+			wantErr: `column "id" cannot be masked`,
+		},
+	} {
+		uc := &CustomerUsecase{repo: &exportRepo{customers: exportFixture()}}
+		var buf bytes.Buffer
+		n, err := uc.ExportCustomers(context.Background(), nil, tc.opts, &buf)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: error %v, want %q", tc.name, err, tc.wantErr)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("%s: wrote %q before failing", tc.name, buf.String())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if n != 2 {
+			t.Errorf("%s: exported %d customers, want 2", tc.name, n)
+		}
+		if buf.String() != tc.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tc.name, buf.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// HealthRepo reaches the stores the service depends on.
+type HealthRepo interface {
+	PingDatabase(ctx context.Context) error
+	// PingRedis returns nil when no Redis is configured.
+	PingRedis(ctx context.Context) error
+}
+
+// HealthUsecase tells whether the service can serve requests.
+type HealthUsecase struct {
+	repo  HealthRepo
+	rules *RuleEngine
+}
+
+func NewHealthUsecase(repo HealthRepo, rules *RuleEngine) *HealthUsecase {
+	return &HealthUsecase{repo: repo, rules: rules}
+}
+
+// Check returns nil when every dependency is up, otherwise the first one
+// that is not.
+func (uc *HealthUsecase) Check(ctx context.Context) error {
+	if err := uc.repo.PingDatabase(ctx); err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	if err := uc.repo.PingRedis(ctx); err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+	if uc.rules == nil || uc.rules.Rules() == 0 {
+		return errors.New("rule engine: no rules loaded")
+	}
+	return nil
+}
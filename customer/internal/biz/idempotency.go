@@ -0,0 +1,167 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultIdempotencyTTL = 24 * time.Hour
+	// how long a key stays claimed by a request that never finishes, e.g.
+	// because its replica died. Running requests renew their claim every
+	// idempotencyClaimRenewal, so slow ones keep it.
+	idempotencyClaimTTL     = time.Minute
+	idempotencyClaimRenewal = idempotencyClaimTTL / 3
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned for a key sent again with a
+	// different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrIdempotencyKeyInProgress is returned for a retry arriving while
+	// the request it retries still runs.
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+// IdempotencyRecord is what is kept under an idempotency key: the
+// fingerprint of the request and, once it succeeded, its response.
+type IdempotencyRecord struct {
+	Fingerprint string
+	// Response is nil while the request runs
+	Response []byte
+	// CustomerIDs are the customers the response is about; erasing one of
+	// them removes the record
+	CustomerIDs []int64
+}
+
+// IdempotencyRepo keeps idempotency records until they expire.
+type IdempotencyRepo interface {
+	// Claim stores an empty record for key unless an unexpired one exists,
+	// which it returns instead.
+	Claim(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, error)
+	// Complete stores the response of the key claimed for rec.Fingerprint.
+	// It fails when the claim expired meanwhile.
+	Complete(ctx context.Context, key string, rec *IdempotencyRecord, ttl time.Duration) error
+	// Renew extends the claim on key for fingerprint to ttl from now while
+	// its request runs.
+	Renew(ctx context.Context, key, fingerprint string, ttl time.Duration) error
+	// Release drops the claim on key of a request that failed.
+	Release(ctx context.Context, key string) error
+	// Forget removes the records whose responses are about the customers.
+	Forget(ctx context.Context, customerIDs []int64) error
+	// DeleteExpired removes up to limit expired records and returns how
+	// many it removed. Stores expiring records themselves remove none.
+	DeleteExpired(ctx context.Context, limit int) (int, error)
+}
+
+// IdempotencyUsecase makes retried requests safe: a request sent again
+// under the same key gets the response of the first one instead of
+// running twice.
+type IdempotencyUsecase struct {
+	repo IdempotencyRepo
+	ttl  time.Duration
+	log  *log.Helper
+}
+
+func NewIdempotencyUsecase(c *conf.Data, repo IdempotencyRepo, logger log.Logger) *IdempotencyUsecase {
+	uc := &IdempotencyUsecase{repo: repo, ttl: defaultIdempotencyTTL, log: log.NewHelper(logger)}
+	if c.GetIdempotency().GetTtl() != nil {
+		uc.ttl = c.GetIdempotency().GetTtl().AsDuration()
+	}
+	return uc
+}
+
+// Do runs the request with the given key and fingerprint once. A retry
+// with the same fingerprint returns the stored response and reports it
+// replayed; failed requests are not kept, so their retries run again.
+// Besides the response, run returns the customers it is about. Keys are
+// scoped to the tenant and subject of ctx.
+func (uc *IdempotencyUsecase) Do(ctx context.Context, key, fingerprint string, run func(ctx context.Context) ([]byte, []int64, error)) (response []byte, replayed bool, err error) {
+	key = idempotencyScope(ctx, key)
+	rec, err := uc.repo.Claim(ctx, key, fingerprint, idempotencyClaimTTL)
+	if err != nil {
+		return nil, false, err
+	}
+	if rec != nil {
+		switch {
+		case rec.Fingerprint != fingerprint:
+			return nil, false, ErrIdempotencyKeyReused
+		case rec.Response == nil:
+			return nil, false, ErrIdempotencyKeyInProgress
+		}
+		return rec.Response, true, nil
+	}
+
+	stop := uc.keepClaimed(ctx, key, fingerprint)
+	response, customerIDs, err := run(ctx)
+	stop()
+	// the outcome is recorded even when the caller gave up meanwhile
+	store := context.WithoutCancel(ctx)
+	if err != nil {
+		if rerr := uc.repo.Release(store, key); rerr != nil {
+			uc.log.Warnf("release idempotency key: %v", rerr)
+		}
+		return nil, false, err
+	}
+	if cerr := uc.repo.Complete(store, key, &IdempotencyRecord{Fingerprint: fingerprint, Response: response, CustomerIDs: customerIDs}, uc.ttl); cerr != nil {
+		// the request went through, a retry finds the key claimed until
+		// the claim expires
+		uc.log.Errorf("store idempotent response: %v", cerr)
+	}
+	return response, false, nil
+}
+
+// keepClaimed renews the claim on key until the returned stop is called,
+// so a retry of a request running longer than idempotencyClaimTTL does
+// not run it a second time.
+func (uc *IdempotencyUsecase) keepClaimed(ctx context.Context, key, fingerprint string) (stop func()) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(idempotencyClaimRenewal)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := uc.repo.Renew(ctx, key, fingerprint, idempotencyClaimTTL); err != nil {
+					uc.log.Warnf("renew idempotency claim: %v", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// Sweep removes expired records in batches of batchSize and returns how
+// many it removed.
+func (uc *IdempotencyUsecase) Sweep(ctx context.Context, batchSize int) (int, error) {
+	total := 0
+	for {
+		n, err := uc.repo.DeleteExpired(ctx, batchSize)
+		total += n
+		if err != nil || n < batchSize {
+			return total, err
+		}
+	}
+}
+
+// idempotencyScope keeps callers from seeing each other's responses by
+// picking the same key.
+func idempotencyScope(ctx context.Context, key string) string {
+	subject, _ := SubjectFromContext(ctx)
+	sum := sha256.Sum256([]byte(TenantFromContext(ctx) + "\x00" + subject + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
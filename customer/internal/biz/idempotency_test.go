@@ -0,0 +1,159 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// memIdempotencyRepo keeps the records in memory, without expiry.
+type memIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]*IdempotencyRecord
+}
+
+func newMemIdempotencyRepo() *memIdempotencyRepo {
+	return &memIdempotencyRepo{records: map[string]*IdempotencyRecord{}}
+}
+
+func (r *memIdempotencyRepo) Claim(_ context.Context, key, fingerprint string, _ time.Duration) (*IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.records[key]; ok {
+		c := *rec
+		return &c, nil
+	}
+	r.records[key] = &IdempotencyRecord{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (r *memIdempotencyRepo) Complete(_ context.Context, key string, rec *IdempotencyRecord, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.records[key]; !ok || cur.Fingerprint != rec.Fingerprint || cur.Response != nil {
+		return errors.New("claim lost")
+	}
+	r.records[key] = rec
+	return nil
+}
+
+func (r *memIdempotencyRepo) Renew(context.Context, string, string, time.Duration) error { return nil }
+
+func (r *memIdempotencyRepo) Release(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.records[key]; ok && rec.Response == nil {
+		delete(r.records, key)
+	}
+	return nil
+}
+
+func (r *memIdempotencyRepo) Forget(context.Context, []int64) error { return nil }
+
+func (r *memIdempotencyRepo) DeleteExpired(context.Context, int) (int, error) { return 0, nil }
+
+func newTestIdempotency() *IdempotencyUsecase {
+	return NewIdempotencyUsecase(&conf.Data{}, newMemIdempotencyRepo(), log.DefaultLogger)
+}
+
+// reply returns a run replying with response, counting its calls.
+func reply(calls *int, response string) func(context.Context) ([]byte, []int64, error) {
+	return func(context.Context) ([]byte, []int64, error) {
+		*calls++
+		return []byte(response), []int64{1}, nil
+	}
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	uc := newTestIdempotency()
+	ctx := NewTenantContext(context.Background(), "acme")
+	calls := 0
+
+	first, replayed, err := uc.Do(ctx, "key", "fp", reply(&calls, "created 1"))
+	if err != nil || replayed || string(first) != "created 1" {
+		t.Fatalf("first = %q, %v, %v", first, replayed, err)
+	}
+	again, replayed, err := uc.Do(ctx, "key", "fp", reply(&calls, "created 2"))
+	if err != nil || !replayed || string(again) != "created 1" {
+		t.Errorf("retry = %q, %v, %v, want the stored reply replayed", again, replayed, err)
+	}
+	if calls != 1 {
+		t.Errorf("ran %d times, want once", calls)
+	}
+
+	// keys are scoped to the caller
+	other := NewTenantContext(context.Background(), "globex")
+	if r, replayed, err := uc.Do(other, "key", "fp", reply(&calls, "created 3")); err != nil || replayed || string(r) != "created 3" {
+		t.Errorf("other tenant = %q, %v, %v, want its own run", r, replayed, err)
+	}
+}
+
+func TestIdempotencyConflictingRequest(t *testing.T) {
+	uc := newTestIdempotency()
+	ctx := context.Background()
+	calls := 0
+	if _, _, err := uc.Do(ctx, "key", "first", reply(&calls, "created")); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := uc.Do(ctx, "key", "second", reply(&calls, "other"))
+	if !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("another request under the key: %v, want ErrIdempotencyKeyReused", err)
+	}
+	if calls != 1 {
+		t.Errorf("ran %d times, want once", calls)
+	}
+}
+
+func TestIdempotencyInFlight(t *testing.T) {
+	uc := newTestIdempotency()
+	ctx := context.Background()
+	started, finish := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, _, err := uc.Do(ctx, "key", "fp", func(context.Context) ([]byte, []int64, error) {
+			close(started)
+			<-finish
+			return []byte("created"), nil, nil
+		})
+		done <- err
+	}()
+	<-started
+
+	calls := 0
+	if _, _, err := uc.Do(ctx, "key", "fp", reply(&calls, "twice")); !errors.Is(err, ErrIdempotencyKeyInProgress) {
+		t.Errorf("retry in flight: %v, want ErrIdempotencyKeyInProgress", err)
+	}
+	if _, _, err := uc.Do(ctx, "key", "other", reply(&calls, "twice")); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("another request in flight: %v, want ErrIdempotencyKeyReused", err)
+	}
+	close(finish)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if r, replayed, err := uc.Do(ctx, "key", "fp", reply(&calls, "twice")); err != nil || !replayed || string(r) != "created" {
+		t.Errorf("retry once done = %q, %v, %v, want the reply replayed", r, replayed, err)
+	}
+	if calls != 0 {
+		t.Errorf("retries ran %d times, want none", calls)
+	}
+}
+
+func TestIdempotencyFailureIsNotKept(t *testing.T) {
+	uc := newTestIdempotency()
+	ctx := context.Background()
+	failed := errors.New("database down")
+	_, _, err := uc.Do(ctx, "key", "fp", func(context.Context) ([]byte, []int64, error) { return nil, nil, failed })
+	if !errors.Is(err, failed) {
+		t.Fatalf("err = %v", err)
+	}
+	calls := 0
+	if r, replayed, err := uc.Do(ctx, "key", "fp", reply(&calls, "created")); err != nil || replayed || string(r) != "created" || calls != 1 {
+		t.Errorf("retry of a failure = %q, %v, %v, ran %d times, want it run again", r, replayed, err, calls)
+	}
+}
@@ -0,0 +1,176 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+const defaultImportBatchSize = 500
+
+// errDryRun rolls back the transaction of a dry-run batch.
+var errDryRun = errors.New("dry run")
+
+// ImportRecord is one customer of a bulk import. Err is set when the
+// source row could not be decoded; the row is then reported as failed.
+type ImportRecord struct {
+	Line         int64
+	Customer     Customer
+	Emails       []string
+	PhoneNumbers []string
+	Addresses    []string
+	Err          error
+}
+
+type ImportOptions struct {
+	// DryRun validates and inserts every batch, then rolls it back.
+	DryRun    bool
+	BatchSize int
+}
+
+// ImportStatus mirrors api.customer.v1.ImportRowStatus.
+type ImportStatus int32
+
+const (
+	ImportUnspecified ImportStatus = iota
+	ImportCreated
+	ImportFailed
+	ImportValid
+)
+
+type ImportResult struct {
+	Line       int64
+	Status     ImportStatus
+	CustomerID int64
+	Err        error
+}
+
+type ImportSummary struct {
+	Total   int64
+	Created int64
+	Valid   int64
+	Failed  int64
+}
+
+// ImportCustomers creates the records returned by next until it returns
+// io.EOF, reporting the outcome of every row. Records go through the same
+// rules as CreateCustomerWithDetails and are committed in batches; a row
+// the database rejects only fails itself, not its batch.
+func (uc *CustomerUsecase) ImportCustomers(
+	ctx context.Context,
+	opts ImportOptions,
+	next func() (*ImportRecord, error),
+	report func(*ImportResult) error,
+) (*ImportSummary, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ImportCustomers")
+	defer span.End()
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	summary := &ImportSummary{}
+	batch := make([]*ImportRecord, 0, batchSize)
+	flush := func() error {
+		for _, res := range uc.importBatch(ctx, batch, opts.DryRun) {
+			summary.Total++
+			switch res.Status {
+			case ImportCreated:
+				summary.Created++
+			case ImportValid:
+				summary.Valid++
+			default:
+				summary.Failed++
+			}
+			if err := report(res); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	var line int64
+	for {
+		rec, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return summary, err
+		}
+		line++
+		if rec.Line == 0 {
+			rec.Line = line
+		}
+		batch = append(batch, rec)
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+func (uc *CustomerUsecase) importBatch(ctx context.Context, batch []*ImportRecord, dryRun bool) []*ImportResult {
+	results := make([]*ImportResult, len(batch))
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		for i, rec := range batch {
+			results[i] = uc.importRecord(ctx, rec, dryRun)
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		// the batch did not commit, nothing in it was created
+		for _, res := range results {
+			if res.Status == ImportCreated {
+				res.Status, res.CustomerID, res.Err = ImportFailed, 0, err
+			}
+		}
+	}
+	return results
+}
+
+// importRecord creates one record inside the batch transaction. The nested
+// Tx is a savepoint, so a row the database rejects is rolled back alone
+// and later rows still see the rows before it.
+func (uc *CustomerUsecase) importRecord(ctx context.Context, rec *ImportRecord, dryRun bool) *ImportResult {
+	if rec.Err != nil {
+		return &ImportResult{Line: rec.Line, Status: ImportFailed, Err: rec.Err}
+	}
+
+	c := rec.Customer
+	emails := make([]*Email, 0, len(rec.Emails))
+	for _, e := range rec.Emails {
+		emails = append(emails, &Email{Email: e})
+	}
+	phones := make([]*PhoneNumber, 0, len(rec.PhoneNumbers))
+	for _, p := range rec.PhoneNumbers {
+		phones = append(phones, &PhoneNumber{PhoneNumber: p})
+	}
+	addresses := make([]*Address, 0, len(rec.Addresses))
+	for _, a := range rec.Addresses {
+		addresses = append(addresses, &Address{Address: a})
+	}
+
+	if err := uc.validateDetails(&c, emails, phones, addresses); err != nil {
+		return &ImportResult{Line: rec.Line, Status: ImportFailed, Err: err}
+	}
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		return uc.createWithDetails(ctx, &c, emails, phones, addresses)
+	})
+	if err != nil {
+		return &ImportResult{Line: rec.Line, Status: ImportFailed, Err: err}
+	}
+	if dryRun {
+		return &ImportResult{Line: rec.Line, Status: ImportValid}
+	}
+	return &ImportResult{Line: rec.Line, Status: ImportCreated, CustomerID: c.ID}
+}
@@ -0,0 +1,156 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "customer/api/customer/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+const maxStatusReasonLength = 500
+
+// CustomerStatus mirrors api.customer.v1.CustomerStatus.
+type CustomerStatus int32
+
+const (
+	CustomerStatusUnspecified CustomerStatus = iota
+	CustomerProspect
+	CustomerActive
+	CustomerSuspended
+	CustomerClosed
+)
+
+func (s CustomerStatus) String() string {
+	switch s {
+	case CustomerProspect:
+		return "prospect"
+	case CustomerActive:
+		return "active"
+	case CustomerSuspended:
+		return "suspended"
+	case CustomerClosed:
+		return "closed"
+	}
+	return "unspecified"
+}
+
+// customerTransitions are the statuses a customer may move to from the
+// one it is in. Closed is final.
+var customerTransitions = map[CustomerStatus][]CustomerStatus{
+	CustomerProspect:  {CustomerActive, CustomerClosed},
+	CustomerActive:    {CustomerSuspended, CustomerClosed},
+	CustomerSuspended: {CustomerActive, CustomerClosed},
+}
+
+// failedPrecondition is the error of a change the status of a customer
+// refuses. Kratos errors carry HTTP codes and have no helper for it, the
+// server maps 412 to the gRPC FailedPrecondition.
+func failedPrecondition(reason, message string) *errors.Error {
+	return errors.New(http.StatusPreconditionFailed, reason, message)
+}
+
+// ErrCustomerClosed is returned for changes closed customers refuse.
+var ErrCustomerClosed = failedPrecondition("CUSTOMER_CLOSED", "customer is closed")
+
+// canBecome tells whether a customer in status s may move to status to.
+func (s CustomerStatus) canBecome(to CustomerStatus) bool {
+	for _, next := range customerTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ActivateCustomer makes a prospect an active customer, or lifts the
+// suspension of a suspended one.
+func (uc *CustomerUsecase) ActivateCustomer(ctx context.Context, id int64, reason string) (*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.ActivateCustomer")
+	defer span.End()
+	return uc.changeStatus(ctx, id, CustomerActive, reason)
+}
+
+// SuspendCustomer suspends an active customer.
+func (uc *CustomerUsecase) SuspendCustomer(ctx context.Context, id int64, reason string) (*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.SuspendCustomer")
+	defer span.End()
+	return uc.changeStatus(ctx, id, CustomerSuspended, reason)
+}
+
+// CloseCustomer closes a customer in any status but closed.
+func (uc *CustomerUsecase) CloseCustomer(ctx context.Context, id int64, reason string) (*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.CloseCustomer")
+	defer span.End()
+	return uc.changeStatus(ctx, id, CustomerClosed, reason)
+}
+
+// changeStatus moves the customer to status to if its current status
+// allows, recording the reason, which every change needs, and the time
+// with the customer and in its history. It returns the customer with its
+// contact points.
+func (uc *CustomerUsecase) changeStatus(ctx context.Context, id int64, to CustomerStatus, reason string) (*Customer, error) {
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "":
+		return nil, errors.BadRequest("STATUS_REASON_REQUIRED", fmt.Sprintf("a reason is required to make a customer %s", to))
+	case len(reason) > maxStatusReasonLength:
+		return nil, errors.BadRequest("STATUS_REASON_TOO_LONG", fmt.Sprintf("reason is longer than %d bytes", maxStatusReasonLength))
+	}
+
+	var c *Customer
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		found, err := uc.repo.GetCustomersByKeys(ctx, []*CustomerKey{{ID: id}})
+		if err != nil {
+			return err
+		}
+		if c = found[0]; c == nil {
+			return ErrCustomerNotFound
+		}
+		from := c.Status
+		if !from.canBecome(to) {
+			return failedPrecondition("CUSTOMER_STATUS_TRANSITION", fmt.Sprintf("a %s customer cannot be made %s", from, to))
+		}
+
+		c.Status, c.StatusReason, c.StatusChangedAt = to, reason, time.Now()
+		ok, err := uc.repo.SetCustomerStatus(ctx, c, from)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.Conflict("CUSTOMER_STATUS_CHANGED", fmt.Sprintf("the status of customer %d changed meanwhile, try again", id))
+		}
+		return uc.emit(ctx, id, &v1.CustomerStatusChanged{
+			CustomerId: id,
+			From:       v1.CustomerStatus(from),
+			To:         v1.CustomerStatus(to),
+			Reason:     reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ensureOpen returns ErrCustomerClosed for a closed customer.
+func ensureOpen(c *Customer) error {
+	if c.Status == CustomerClosed {
+		return ErrCustomerClosed
+	}
+	return nil
+}
+
+// lockOpen returns ErrCustomerClosed when customer id is closed, and
+// keeps it from being closed until the transaction of ctx ends.
+func (uc *CustomerUsecase) lockOpen(ctx context.Context, id int64) error {
+	c, err := uc.repo.LockCustomer(ctx, id)
+	if err != nil {
+		return err
+	}
+	return ensureOpen(c)
+}
@@ -0,0 +1,357 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	v1 "customer/api/customer/v1"
+)
+
+const (
+	defaultDuplicateMinScore = 0.5
+	defaultDuplicateLimit    = 100
+	// names at least this similar still count towards the score
+	similarNameThreshold = 0.8
+)
+
+// weights of the signals a duplicate score is built from, capped at 1
+const (
+	nameWeight        = 0.4
+	dateOfBirthWeight = 0.3
+	emailWeight       = 0.3
+	phoneNumberWeight = 0.3
+	addressWeight     = 0.2
+)
+
+// CustomerMerge is the history entry of a duplicate merged into a
+// survivor. Name and DateOfBirth are those of the duplicate, the contact
+// points are the ones moved to the survivor.
+type CustomerMerge struct {
+	ID           int64
+	SurvivorID   int64
+	MergedID     int64
+	Name         string
+	DateOfBirth  string
+	Emails       []string
+	PhoneNumbers []string
+	Addresses    []string
+	MergedAt     time.Time
+}
+
+type MergeRepo interface {
+	// Merge moves the contact points and consent history of m.MergedID
+	// onto m.SurvivorID, deletes the merged customer and records m,
+	// filling in the moved contact points. Redirects to the merged customer are repointed to
+	// the survivor. It must run inside CustomerRepo.Tx.
+	Merge(ctx context.Context, m *CustomerMerge) error
+	// Survivor returns the customer id was merged into, 0 if it never was.
+	Survivor(ctx context.Context, id int64) (int64, error)
+	// Merged returns the merges into survivorID, oldest first, including
+	// those into customers later merged into it.
+	Merged(ctx context.Context, survivorID int64) ([]*CustomerMerge, error)
+}
+
+type DuplicateCandidate struct {
+	CustomerID  int64
+	DuplicateID int64
+	Score       float64
+	Reasons     []string
+}
+
+// FindDuplicateCustomers scores every pair of customers matching filter
+// that share a normalized name, date of birth, email, phone number or
+// address, and returns the pairs scoring at least minScore, best first.
+func (uc *CustomerUsecase) FindDuplicateCustomers(ctx context.Context, filter *CustomerFilter, minScore float64, limit int) ([]*DuplicateCandidate, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.FindDuplicateCustomers")
+	defer span.End()
+	if minScore <= 0 {
+		minScore = defaultDuplicateMinScore
+	}
+	if limit <= 0 {
+		limit = defaultDuplicateLimit
+	}
+
+	var customers []*duplicateKey
+	blocks := make(map[string][]int)
+	err := uc.repo.IterateCustomers(ctx, filter, 500, func(batch []*Customer) error {
+		for _, c := range batch {
+			k := newDuplicateKey(c)
+			for _, b := range k.blocks() {
+				blocks[b] = append(blocks[b], len(customers))
+			}
+			customers = append(customers, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[[2]int]bool)
+	var out []*DuplicateCandidate
+	for _, members := range blocks {
+		for i := 0; i < len(members); i++ {
+			for j := i + 1; j < len(members); j++ {
+				pair := [2]int{members[i], members[j]}
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+
+				a, b := customers[pair[0]], customers[pair[1]]
+				score, reasons := duplicateScore(a, b)
+				if score < minScore {
+					continue
+				}
+				out = append(out, &DuplicateCandidate{
+					CustomerID:  a.id,
+					DuplicateID: b.id,
+					Score:       score,
+					Reasons:     reasons,
+				})
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		if out[i].CustomerID != out[j].CustomerID {
+			return out[i].CustomerID < out[j].CustomerID
+		}
+		return out[i].DuplicateID < out[j].DuplicateID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// MergeCustomers merges every duplicate into the survivor in one
+// transaction and returns the survivor with its contact points.
+func (uc *CustomerUsecase) MergeCustomers(ctx context.Context, survivorID int64, duplicateIDs []int64) (*Customer, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.MergeCustomers")
+	defer span.End()
+	if len(duplicateIDs) == 0 {
+		return nil, errors.New("at least one duplicate id is required")
+	}
+	for _, id := range duplicateIDs {
+		if id == survivorID {
+			return nil, errors.New("a customer cannot be merged into itself")
+		}
+	}
+
+	err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+		survivor, err := uc.repo.GetCustomer(ctx, survivorID)
+		if err != nil {
+			return err
+		}
+		// the contact points of the duplicates would be added to it
+		if err := ensureOpen(survivor); err != nil {
+			return err
+		}
+		for _, id := range duplicateIDs {
+			dup, err := uc.repo.GetCustomer(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := uc.ensureNotErasing(ctx, dup.ID); err != nil {
+				return err
+			}
+			m := &CustomerMerge{
+				SurvivorID:  survivorID,
+				MergedID:    dup.ID,
+				Name:        dup.Name,
+				DateOfBirth: dup.DateOfBirth,
+				MergedAt:    time.Now(),
+			}
+			if err := uc.merges.Merge(ctx, m); err != nil {
+				return err
+			}
+			if err := uc.emit(ctx, survivorID, &v1.CustomersMerged{
+				CustomerId:   survivorID,
+				MergedId:     dup.ID,
+				Emails:       m.Emails,
+				PhoneNumbers: m.PhoneNumbers,
+				Addresses:    m.Addresses,
+			}); err != nil {
+				return err
+			}
+			if err := uc.emit(ctx, dup.ID, &v1.CustomerDeleted{CustomerId: dup.ID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	survivors, err := uc.customersWithContacts(ctx, &CustomerFilter{IDs: []int64{survivorID}})
+	if err != nil {
+		return nil, err
+	}
+	if len(survivors) == 0 {
+		return nil, errors.New("survivor not found after merge")
+	}
+	return survivors[0], nil
+}
+
+// duplicateKey holds the normalized values of a customer that duplicates
+// are matched on.
+type duplicateKey struct {
+	id           int64
+	name         string
+	dateOfBirth  string
+	emails       []string
+	phoneNumbers []string
+	addresses    []string
+}
+
+func newDuplicateKey(c *Customer) *duplicateKey {
+	k := &duplicateKey{
+		id:          c.ID,
+		name:        normalizeName(c.Name),
+		dateOfBirth: strings.TrimSpace(c.DateOfBirth),
+	}
+	for _, e := range c.Emails {
+		k.emails = append(k.emails, strings.ToLower(strings.TrimSpace(e.Email)))
+	}
+	for _, p := range c.PhoneNumbers {
+		k.phoneNumbers = append(k.phoneNumbers, normalizeDigits(p.PhoneNumber))
+	}
+	for _, a := range c.Addresses {
+		k.addresses = append(k.addresses, normalizeText(a.Address))
+	}
+	return k
+}
+
+// blocks lists the values a customer shares with its candidate
+// duplicates; only customers sharing a block are compared.
+func (k *duplicateKey) blocks() []string {
+	var out []string
+	add := func(prefix string, values ...string) {
+		for _, v := range values {
+			if v != "" {
+				out = append(out, prefix+v)
+			}
+		}
+	}
+	add("name:", k.name)
+	add("dob:", k.dateOfBirth)
+	add("email:", k.emails...)
+	add("phone:", k.phoneNumbers...)
+	add("address:", k.addresses...)
+	return out
+}
+
+func duplicateScore(a, b *duplicateKey) (float64, []string) {
+	var (
+		score   float64
+		reasons []string
+	)
+	if a.name != "" && a.name == b.name {
+		score += nameWeight
+		reasons = append(reasons, "name")
+	} else if sim := similarity(a.name, b.name); sim >= similarNameThreshold {
+		score += nameWeight * sim
+		reasons = append(reasons, "similar_name")
+	}
+	if a.dateOfBirth != "" && a.dateOfBirth == b.dateOfBirth {
+		score += dateOfBirthWeight
+		reasons = append(reasons, "date_of_birth")
+	}
+	if sharesAny(a.emails, b.emails) {
+		score += emailWeight
+		reasons = append(reasons, "email")
+	}
+	if sharesAny(a.phoneNumbers, b.phoneNumbers) {
+		score += phoneNumberWeight
+		reasons = append(reasons, "phone_number")
+	}
+	if sharesAny(a.addresses, b.addresses) {
+		score += addressWeight
+		reasons = append(reasons, "address")
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score, reasons
+}
+
+func sharesAny(a, b []string) bool {
+	for _, x := range a {
+		if x != "" && containsString(b, x) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeName lowercases the name, drops punctuation and sorts its
+// words, so "Smith, John" and "john smith" are the same name.
+func normalizeName(s string) string {
+	words := strings.Fields(normalizeText(s))
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// normalizeText lowercases s and keeps only letters and digits, with
+// single spaces between words.
+func normalizeText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func normalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// similarity is 1 minus the edit distance of a and b relative to the
+// longer of the two.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
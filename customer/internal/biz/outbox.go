@@ -0,0 +1,120 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	relayBaseBackoff = time.Second
+	relayMaxBackoff  = 5 * time.Minute
+
+	outboxLease = "outbox-relay"
+)
+
+// OutboxRelay moves events from the outbox to the publisher.
+//
+// Delivery is at-least-once: an event is marked published only after the
+// publisher accepted it. Events of one customer are delivered in the order
+// they were written; when one fails, the customer's later events wait
+// until it has gone through. Events are appended to the change feed behind
+// WatchCustomers before they are published, so the feed keeps up while
+// the publisher is down. Replicas share a lease, so one of them relays at
+// a time.
+type OutboxRelay struct {
+	repo   OutboxRepo
+	pub    EventPublisher
+	feed   *ChangeFeed
+	leases LeaseRepo
+	log    *log.Helper
+}
+
+func NewOutboxRelay(repo OutboxRepo, pub EventPublisher, feed *ChangeFeed, leases LeaseRepo, logger log.Logger) *OutboxRelay {
+	return &OutboxRelay{repo: repo, pub: pub, feed: feed, leases: leases, log: log.NewHelper(logger)}
+}
+
+// Relay delivers one batch of pending events as holder of the relay lease
+// and returns how many were published. It publishes nothing while another
+// replica holds the lease. The lease is taken or renewed for leaseTTL
+// with every batch, and the batch stops halfway through it so the lease
+// cannot run out under a slow publisher.
+func (r *OutboxRelay) Relay(ctx context.Context, holder string, batchSize int, leaseTTL time.Duration) (int, error) {
+	ok, err := r.leases.Acquire(ctx, outboxLease, holder, leaseTTL)
+	if err != nil || !ok {
+		return 0, err
+	}
+	deadline := time.Now().Add(leaseTTL / 2)
+
+	if _, err := r.feed.Sync(ctx, batchSize); err != nil {
+		return 0, err
+	}
+	events, err := r.repo.Pending(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := make(map[int64]bool)
+	published := 0
+	for _, e := range events {
+		now := time.Now()
+		if now.After(deadline) {
+			break
+		}
+		if blocked[e.CustomerID] {
+			continue
+		}
+
+		err := r.publish(ctx, e)
+		if err != nil {
+			blocked[e.CustomerID] = true
+			attempts := e.Attempts + 1
+			r.log.WithContext(ctx).Warnf("publish event %d (%s) failed, attempt %d: %v", e.ID, e.Type, attempts, err)
+			if err := r.repo.MarkFailed(ctx, e.ID, attempts, now.Add(relayBackoff(attempts)), err.Error()); err != nil {
+				return published, err
+			}
+			continue
+		}
+		if err := r.repo.MarkPublished(ctx, e.ID); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// Release gives the relay lease up if holder has it.
+func (r *OutboxRelay) Release(ctx context.Context, holder string) error {
+	return r.leases.Release(ctx, outboxLease, holder)
+}
+
+// publish delivers e in a span of the trace e was recorded in.
+func (r *OutboxRelay) publish(ctx context.Context, e *Event) error {
+	ctx, span := tracer.Start(withTraceContext(ctx, e), "OutboxRelay.Publish "+e.Type,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.Int64("event.id", e.ID), attribute.Int("event.attempts", int(e.Attempts)+1)))
+	defer span.End()
+
+	err := r.pub.Publish(ctx, e)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// relayBackoff doubles the wait with every attempt, capped at relayMaxBackoff.
+func relayBackoff(attempts int32) time.Duration {
+	d := relayBaseBackoff
+	for i := int32(1); i < attempts && d < relayMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > relayMaxBackoff {
+		d = relayMaxBackoff
+	}
+	return d
+}
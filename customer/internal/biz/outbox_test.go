@@ -0,0 +1,26 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelayBackoff(t *testing.T) {
+	for _, c := range []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{5, 16 * time.Second},
+		{9, 256 * time.Second},
+		{10, 5 * time.Minute},
+		{11, 5 * time.Minute},
+		{1000, 5 * time.Minute},
+	} {
+		if got := relayBackoff(c.attempts); got != c.want {
+			t.Errorf("relayBackoff(%d) = %s, want %s", c.attempts, got, c.want)
+		}
+	}
+}
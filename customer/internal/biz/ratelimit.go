@@ -0,0 +1,72 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// RateLimit lets Requests through per Period, up to Burst of them at once.
+//
+// The buckets follow the generic cell rate algorithm: a bucket is the
+// theoretical arrival time of its next request, which every request
+// moves on by one emission interval. A request is let through unless
+// that leaves the time more than the burst ahead of now.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Emission returns the time a request takes up in a bucket of l, and the
+// time the whole burst does.
+func (l RateLimit) Emission() (interval, burst time.Duration) {
+	interval = l.Period / time.Duration(l.Requests)
+	return interval, interval * time.Duration(l.Burst)
+}
+
+// Take takes a request at now out of a bucket of l whose theoretical
+// arrival time is tat, zero for a new bucket. It returns the arrival time
+// the bucket moves on to or, when it is empty, false and how long until
+// it is not.
+func (l RateLimit) Take(tat, now time.Time) (next time.Time, ok bool, retryAfter time.Duration) {
+	interval, burst := l.Emission()
+	if tat.Before(now) {
+		tat = now
+	}
+	next = tat.Add(interval)
+	if allowAt := next.Add(-burst); now.Before(allowAt) {
+		return tat, false, allowAt.Sub(now)
+	}
+	return next, true, 0
+}
+
+// RateLimitRepo keeps a bucket of requests per key.
+type RateLimitRepo interface {
+	// Take takes a request out of the bucket key filled after limit. When
+	// the bucket is empty it returns false and how long until it is not.
+	Take(ctx context.Context, key string, limit RateLimit) (ok bool, retryAfter time.Duration, err error)
+}
+
+// RateLimitUsecase tells whether a client may make another request.
+type RateLimitUsecase struct {
+	repo RateLimitRepo
+	log  *log.Helper
+}
+
+func NewRateLimitUsecase(repo RateLimitRepo, logger log.Logger) *RateLimitUsecase {
+	return &RateLimitUsecase{repo: repo, log: log.NewHelper(logger)}
+}
+
+// Allow takes a request of client out of its bucket of the limit named
+// name. A store that cannot be reached lets requests through, an outage
+// of the limiter must not become one of the service.
+func (uc *RateLimitUsecase) Allow(ctx context.Context, client, name string, limit RateLimit) (bool, time.Duration) {
+	ok, retryAfter, err := uc.repo.Take(ctx, name+"\x00"+client, limit)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("rate limit %s: %v", name, err)
+		return true, 0
+	}
+	return ok, retryAfter
+}
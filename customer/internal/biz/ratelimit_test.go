@@ -0,0 +1,126 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// take is a request to a bucket at an offset from the start of a test,
+// and what it should get.
+type take struct {
+	at         time.Duration
+	ok         bool
+	retryAfter time.Duration
+}
+
+func TestRateLimitTake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		name  string
+		limit RateLimit
+		takes []take
+	}{
+		{
+			name:  "burst",
+			limit: RateLimit{Requests: 10, Period: time.Second, Burst: 3},
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 0, ok: true},
+				{at: 0, ok: true},
+				{at: 0, retryAfter: 100 * time.Millisecond},
+				{at: 50 * time.Millisecond, retryAfter: 50 * time.Millisecond},
+				{at: 100 * time.Millisecond, ok: true},
+				{at: 100 * time.Millisecond, retryAfter: 100 * time.Millisecond},
+			},
+		},
+		{
+			name:  "steady rate",
+			limit: RateLimit{Requests: 10, Period: time.Second, Burst: 1},
+			takes: []take{
+				{at: 0, ok: true},
+				{at: 100 * time.Millisecond, ok: true},
+				{at: 200 * time.Millisecond, ok: true},
+				{at: 250 * time.Millisecond, retryAfter: 50 * time.Millisecond},
+				{at: 300 * time.Millisecond, ok: true},
+				{at: 400 * time.Millisecond, ok: true},
+			},
+		},
+		{
+			name:  "refused requests take nothing",
+			limit: RateLimit{Requests: 1, Period: time.Minute, Burst: 1},
+			takes: []take{
+				{at: 0, ok: true},
+				{at: time.Second, retryAfter: 59 * time.Second},
+				{at: 30 * time.Second, retryAfter: 30 * time.Second},
+				{at: time.Minute, ok: true},
+			},
+		},
+		{
+			name:  "an idle bucket fills up to the burst only",
+			limit: RateLimit{Requests: 10, Period: time.Second, Burst: 2},
+			takes: []take{
+				{at: 0, ok: true},
+				{at: time.Hour, ok: true},
+				{at: time.Hour, ok: true},
+				{at: time.Hour, retryAfter: 100 * time.Millisecond},
+			},
+		},
+	} {
+		var tat time.Time
+		for i, tk := range c.takes {
+			next, ok, retryAfter := c.limit.Take(tat, start.Add(tk.at))
+			if ok != tk.ok || retryAfter != tk.retryAfter {
+				t.Errorf("%s: take %d at %s = %v, %s, want %v, %s", c.name, i, tk.at, ok, retryAfter, tk.ok, tk.retryAfter)
+			}
+			if ok {
+				tat = next
+			}
+		}
+	}
+}
+
+func TestRateLimitEmission(t *testing.T) {
+	interval, burst := RateLimit{Requests: 4, Period: time.Second, Burst: 8}.Emission()
+	if interval != 250*time.Millisecond || burst != 2*time.Second {
+		t.Errorf("emission = %s, %s, want 250ms, 2s", interval, burst)
+	}
+}
+
+// failingRateLimitRepo cannot reach its store.
+type failingRateLimitRepo struct{}
+
+func (failingRateLimitRepo) Take(context.Context, string, RateLimit) (bool, time.Duration, error) {
+	return false, 0, errors.New("connection refused")
+}
+
+// keyRateLimitRepo records the keys taken from.
+type keyRateLimitRepo struct {
+	keys []string
+}
+
+func (r *keyRateLimitRepo) Take(_ context.Context, key string, _ RateLimit) (bool, time.Duration, error) {
+	r.keys = append(r.keys, key)
+	return false, time.Second, nil
+}
+
+func TestRateLimitAllow(t *testing.T) {
+	limit := RateLimit{Requests: 1, Period: time.Second, Burst: 1}
+	uc := NewRateLimitUsecase(failingRateLimitRepo{}, log.DefaultLogger)
+	if ok, retryAfter := uc.Allow(context.Background(), "client", "writes", limit); !ok || retryAfter != 0 {
+		t.Errorf("unreachable store = %v, %s, want requests let through", ok, retryAfter)
+	}
+
+	repo := &keyRateLimitRepo{}
+	uc = NewRateLimitUsecase(repo, log.DefaultLogger)
+	if ok, retryAfter := uc.Allow(context.Background(), "client", "writes", limit); ok || retryAfter != time.Second {
+		t.Errorf("empty bucket = %v, %s, want refused for 1s", ok, retryAfter)
+	}
+	uc.Allow(context.Background(), "client", "reads", limit)
+	if len(repo.keys) != 2 || repo.keys[0] == repo.keys[1] {
+		t.Errorf("keys = %q, want a bucket per limit", repo.keys)
+	}
+}
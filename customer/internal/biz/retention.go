@@ -0,0 +1,252 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	v1 "customer/api/customer/v1"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// purgeLease is the lease replicas hold while purging.
+const purgeLease = "retention-purge"
+
+// RetentionPolicy says how long data is kept. Zero periods keep data
+// forever.
+type RetentionPolicy struct {
+	DeletedCustomers   time.Duration
+	InactiveCustomers  time.Duration
+	UnverifiedContacts time.Duration
+}
+
+// PurgeTarget is a customer due for purging.
+type PurgeTarget struct {
+	CustomerID int64
+	TenantID   string
+}
+
+// PurgedContact is an email or phone number removed by the purge.
+type PurgedContact struct {
+	TenantID   string
+	CustomerID int64
+	Value      string
+}
+
+// PurgeSummary reports what one purge run removed. Skipped is set when
+// another replica held the lease.
+type PurgeSummary struct {
+	Skipped                bool
+	DeletedCustomers       int
+	InactiveCustomers      int
+	UnverifiedEmails       int
+	UnverifiedPhoneNumbers int
+	Took                   time.Duration
+}
+
+// RetentionRepo finds and removes expired data of every tenant.
+type RetentionRepo interface {
+	// DeletedCustomers returns up to limit customers deleted before t.
+	DeletedCustomers(ctx context.Context, t time.Time, limit int) ([]*PurgeTarget, error)
+	// InactiveCustomers returns up to limit customers without activity
+	// since t, leaving out suspended customers and scheduled erasures.
+	InactiveCustomers(ctx context.Context, t time.Time, limit int) ([]*PurgeTarget, error)
+	// DeleteCustomers soft-deletes the customers, like DeleteCustomer.
+	DeleteCustomers(ctx context.Context, ids []int64) error
+	// PurgeCustomers removes the customers for good, with their contact
+	// points, consents and the snapshots of customers merged into them.
+	PurgeCustomers(ctx context.Context, ids []int64) error
+	// PurgeUnverifiedEmails removes up to limit emails added before t and
+	// never verified, and returns them.
+	PurgeUnverifiedEmails(ctx context.Context, t time.Time, limit int) ([]*PurgedContact, error)
+	// PurgeUnverifiedPhoneNumbers does the same for phone numbers.
+	PurgeUnverifiedPhoneNumbers(ctx context.Context, t time.Time, limit int) ([]*PurgedContact, error)
+}
+
+// LeaseRepo hands out named leases so only one replica runs a job at a
+// time.
+type LeaseRepo interface {
+	// Acquire takes or renews the lease for holder until ttl from now. It
+	// reports false when another holder has it.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release gives the lease up if holder has it.
+	Release(ctx context.Context, name, holder string) error
+}
+
+// RetentionUsecase purges data its retention policy no longer allows to
+// keep.
+type RetentionUsecase struct {
+	repo      CustomerRepo
+	outbox    OutboxRepo
+	retention RetentionRepo
+	leases    LeaseRepo
+	policy    RetentionPolicy
+	log       *log.Helper
+}
+
+func NewRetentionUsecase(c *conf.Data, repo CustomerRepo, outbox OutboxRepo, retention RetentionRepo, leases LeaseRepo, logger log.Logger) *RetentionUsecase {
+	uc := &RetentionUsecase{
+		repo:      repo,
+		outbox:    outbox,
+		retention: retention,
+		leases:    leases,
+		log:       log.NewHelper(logger),
+	}
+	if r := c.GetRetention(); r != nil {
+		uc.policy = RetentionPolicy{
+			DeletedCustomers:   r.GetDeletedCustomers().AsDuration(),
+			InactiveCustomers:  r.GetInactiveCustomers().AsDuration(),
+			UnverifiedContacts: r.GetUnverifiedContacts().AsDuration(),
+		}
+	}
+	return uc
+}
+
+// Purge applies the retention policy in transactions of batchSize rows,
+// as holder of the purge lease. The lease is renewed after every batch so
+// it outlives long runs; a replica that loses it stops.
+func (uc *RetentionUsecase) Purge(ctx context.Context, holder string, batchSize int, leaseTTL time.Duration) (*PurgeSummary, error) {
+	start := time.Now()
+	sum := &PurgeSummary{}
+
+	ok, err := uc.leases.Acquire(ctx, purgeLease, holder, leaseTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		sum.Skipped = true
+		return sum, nil
+	}
+	defer func() {
+		if err := uc.leases.Release(context.WithoutCancel(ctx), purgeLease, holder); err != nil {
+			uc.log.Warnf("release purge lease: %v", err)
+		}
+	}()
+	renew := func() error {
+		ok, err := uc.leases.Acquire(ctx, purgeLease, holder, leaseTTL)
+		if err == nil && !ok {
+			return errLeaseLost
+		}
+		return err
+	}
+
+	if p := uc.policy.DeletedCustomers; p > 0 {
+		before := start.Add(-p)
+		for {
+			targets, err := uc.retention.DeletedCustomers(ctx, before, batchSize)
+			if err != nil {
+				return sum, err
+			}
+			if len(targets) == 0 {
+				break
+			}
+			// their deletion was announced already
+			err = uc.repo.Tx(ctx, func(ctx context.Context) error {
+				return uc.retention.PurgeCustomers(ctx, purgeIDs(targets))
+			})
+			if err != nil {
+				return sum, err
+			}
+			sum.DeletedCustomers += len(targets)
+			if err := renew(); err != nil {
+				return sum, err
+			}
+		}
+	}
+
+	if p := uc.policy.InactiveCustomers; p > 0 {
+		before := start.Add(-p)
+		for {
+			targets, err := uc.retention.InactiveCustomers(ctx, before, batchSize)
+			if err != nil {
+				return sum, err
+			}
+			if len(targets) == 0 {
+				break
+			}
+			err = uc.repo.Tx(ctx, func(ctx context.Context) error {
+				for _, t := range targets {
+					if err := uc.emit(ctx, t.TenantID, t.CustomerID, &v1.CustomerDeleted{CustomerId: t.CustomerID}); err != nil {
+						return err
+					}
+				}
+				// deleted like any other customer, the deleted customers
+				// policy purges them later
+				return uc.retention.DeleteCustomers(ctx, purgeIDs(targets))
+			})
+			if err != nil {
+				return sum, err
+			}
+			sum.InactiveCustomers += len(targets)
+			if err := renew(); err != nil {
+				return sum, err
+			}
+		}
+	}
+
+	if p := uc.policy.UnverifiedContacts; p > 0 {
+		before := start.Add(-p)
+		for {
+			var emails, phones []*PurgedContact
+			err := uc.repo.Tx(ctx, func(ctx context.Context) error {
+				var err error
+				if emails, err = uc.retention.PurgeUnverifiedEmails(ctx, before, batchSize); err != nil {
+					return err
+				}
+				for _, c := range emails {
+					if err := uc.emit(ctx, c.TenantID, c.CustomerID, &v1.EmailRemoved{CustomerId: c.CustomerID, Email: c.Value}); err != nil {
+						return err
+					}
+				}
+				if phones, err = uc.retention.PurgeUnverifiedPhoneNumbers(ctx, before, batchSize); err != nil {
+					return err
+				}
+				for _, c := range phones {
+					if err := uc.emit(ctx, c.TenantID, c.CustomerID, &v1.PhoneNumberRemoved{CustomerId: c.CustomerID, PhoneNumber: c.Value}); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return sum, err
+			}
+			sum.UnverifiedEmails += len(emails)
+			sum.UnverifiedPhoneNumbers += len(phones)
+			if len(emails) < batchSize && len(phones) < batchSize {
+				break
+			}
+			if err := renew(); err != nil {
+				return sum, err
+			}
+		}
+	}
+
+	sum.Took = time.Since(start)
+	return sum, nil
+}
+
+var errLeaseLost = errors.New("purge lease lost to another replica")
+
+// emit records an event of a purged customer, whose tenant is not the one
+// of ctx: the purge runs for all tenants.
+func (uc *RetentionUsecase) emit(ctx context.Context, tenantID string, customerID int64, msg proto.Message) error {
+	e, err := NewEvent(customerID, msg)
+	if err != nil {
+		return err
+	}
+	e.TenantID = tenantID
+	e.TraceContext = traceContext(ctx)
+	return uc.outbox.Save(ctx, e)
+}
+
+func purgeIDs(targets []*PurgeTarget) []int64 {
+	ids := make([]int64, 0, len(targets))
+	for _, t := range targets {
+		ids = append(ids, t.CustomerID)
+	}
+	return ids
+}
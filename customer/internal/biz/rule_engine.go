@@ -6,4 +6,47 @@ package biz
 
 // No business logic yet
 
-// Everything else depends on this
\ No newline at end of file
+// Everything else depends on this
+
+// Rule checks one aspect of a value. Check returns nil when the value
+// passes, or the reason it does not.
+type Rule[T any] struct {
+	Name  string
+	Check func(T) error
+}
+
+// RuleSet is an ordered list of rules evaluated together.
+type RuleSet[T any] []Rule[T]
+
+// RuleViolation is returned when a value fails a rule.
+type RuleViolation struct {
+	Rule string
+	Err  error
+}
+
+func (v *RuleViolation) Error() string { return v.Err.Error() }
+
+func (v *RuleViolation) Unwrap() error { return v.Err }
+
+// Validate returns the first violation, or nil if every rule passes.
+func (rs RuleSet[T]) Validate(v T) error {
+	for _, r := range rs {
+		if err := r.Check(v); err != nil {
+			return &RuleViolation{Rule: r.Name, Err: err}
+		}
+	}
+	return nil
+}
+
+// RuleEngine holds the rule sets the usecases validate against.
+type RuleEngine struct {
+	Customer    RuleSet[*Customer]
+	Email       RuleSet[string]
+	PhoneNumber RuleSet[string]
+	Address     RuleSet[string]
+}
+
+// Rules returns the number of rules loaded across all sets.
+func (e *RuleEngine) Rules() int {
+	return len(e.Customer) + len(e.Email) + len(e.PhoneNumber) + len(e.Address)
+}
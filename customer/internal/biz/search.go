@@ -0,0 +1,239 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+	// words at least this similar to a query word count as a match
+	searchMatchThreshold = 0.6
+
+	highlightPre  = "<em>"
+	highlightPost = "</em>"
+)
+
+// SearchMatch is a customer found by SearchRepo with its relevance.
+type SearchMatch struct {
+	CustomerID int64
+	Score      float64
+}
+
+type SearchRepo interface {
+	// Search returns the customers matching query, best first, skipping
+	// offset matches and returning at most limit.
+	Search(ctx context.Context, query string, offset, limit int) ([]*SearchMatch, error)
+}
+
+type Highlight struct {
+	Field       string
+	Value       string
+	Highlighted string
+}
+
+type SearchHit struct {
+	Customer   *Customer
+	Score      float64
+	Highlights []*Highlight
+}
+
+type SearchPage struct {
+	Hits []*SearchHit
+	// NextPageToken is empty on the last page.
+	NextPageToken string
+}
+
+// SearchCustomers runs a ranked, typo tolerant search over names, emails,
+// phone numbers and addresses. Page tokens are opaque to callers.
+func (uc *CustomerUsecase) SearchCustomers(ctx context.Context, query string, pageSize int, pageToken string) (*SearchPage, error) {
+	ctx, span := tracer.Start(ctx, "CustomerUsecase.SearchCustomers")
+	defer span.End()
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("query is required")
+	}
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+	var offset int
+	if pageToken != "" {
+		n, err := strconv.Atoi(pageToken)
+		if err != nil || n < 0 {
+			return nil, errors.New("invalid page token")
+		}
+		offset = n
+	}
+
+	// one extra match tells whether there is a next page
+	matches, err := uc.search.Search(ctx, query, offset, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &SearchPage{}
+	if len(matches) > pageSize {
+		matches = matches[:pageSize]
+		page.NextPageToken = strconv.Itoa(offset + pageSize)
+	}
+	if len(matches) == 0 {
+		return page, nil
+	}
+
+	ids := make([]int64, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m.CustomerID)
+	}
+	customers, err := uc.customersWithContacts(ctx, &CustomerFilter{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*Customer, len(customers))
+	for _, c := range customers {
+		byID[c.ID] = c
+	}
+
+	for _, m := range matches {
+		c, ok := byID[m.CustomerID]
+		if !ok {
+			// deleted since the search ran
+			continue
+		}
+		_, highlights := MatchCustomer(query, c)
+		page.Hits = append(page.Hits, &SearchHit{Customer: c, Score: m.Score, Highlights: highlights})
+	}
+	return page, nil
+}
+
+// MatchCustomer scores how well c matches query, from 0 to 1, and
+// highlights the matched words of every field. Each query word is matched
+// against the closest word of any field, so word order and small typos
+// do not matter.
+func MatchCustomer(query string, c *Customer) (float64, []*Highlight) {
+	tokens := strings.Fields(normalizeText(query))
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+
+	best := make([]float64, len(tokens))
+	var highlights []*Highlight
+	match := func(field, value string) {
+		if h := matchField(field, value, tokens, best); h != nil {
+			highlights = append(highlights, h)
+		}
+	}
+	match("name", c.Name)
+	for _, e := range c.Emails {
+		match("email", e.Email)
+	}
+	for _, p := range c.PhoneNumbers {
+		match("phone_number", p.PhoneNumber)
+	}
+	for _, a := range c.Addresses {
+		match("address", a.Address)
+	}
+
+	var sum float64
+	for _, s := range best {
+		sum += s
+	}
+	return sum / float64(len(tokens)), highlights
+}
+
+// matchField matches the words of value against tokens, raising best
+// where a word matches better, and returns the highlighted value when
+// any word matched.
+func matchField(field, value string, tokens []string, best []float64) *Highlight {
+	type span struct{ start, end int }
+	var spans []span
+
+	if field == "phone_number" {
+		// phone numbers are matched on their digits as a whole
+		digits := normalizeDigits(value)
+		for i, t := range tokens {
+			if len(t) >= 3 && normalizeDigits(t) == t && strings.Contains(digits, t) {
+				best[i] = 1
+				spans = []span{{0, len(value)}}
+			}
+		}
+	} else {
+		for _, w := range wordSpans(value) {
+			word := strings.ToLower(value[w[0]:w[1]])
+			matched := false
+			for i, t := range tokens {
+				s := wordMatch(t, word)
+				if s == 0 {
+					continue
+				}
+				matched = true
+				if s > best[i] {
+					best[i] = s
+				}
+			}
+			if matched {
+				spans = append(spans, span{w[0], w[1]})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, s := range spans {
+		b.WriteString(value[last:s.start])
+		b.WriteString(highlightPre)
+		b.WriteString(value[s.start:s.end])
+		b.WriteString(highlightPost)
+		last = s.end
+	}
+	b.WriteString(value[last:])
+	return &Highlight{Field: field, Value: value, Highlighted: b.String()}
+}
+
+// wordMatch scores a query token against a lowercase word: exact, prefix
+// and infix matches first, then edit distance for typos.
+func wordMatch(token, word string) float64 {
+	switch {
+	case token == word:
+		return 1
+	case len(token) >= 2 && strings.HasPrefix(word, token):
+		return 0.9
+	case len(token) >= 3 && strings.Contains(word, token):
+		return 0.8
+	}
+	if s := similarity(token, word); s >= searchMatchThreshold {
+		return s
+	}
+	return 0
+}
+
+// wordSpans returns the byte offsets of the runs of letters and digits
+// in s.
+func wordSpans(s string) [][2]int {
+	var (
+		out   [][2]int
+		start = -1
+	)
+	for i, r := range s {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if inWord && start < 0 {
+			start = i
+		}
+		if !inWord && start >= 0 {
+			out = append(out, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		out = append(out, [2]int{start, len(s)})
+	}
+	return out
+}
@@ -0,0 +1,18 @@
+package biz
+
+import "context"
+
+type subjectKey struct{}
+
+// NewSubjectContext returns a copy of ctx carrying the authenticated
+// subject of the request, i.e. the sub claim of its token.
+func NewSubjectContext(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// SubjectFromContext returns the authenticated subject, false for
+// unauthenticated requests and background work.
+func SubjectFromContext(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(subjectKey{}).(string)
+	return s, ok && s != ""
+}
@@ -0,0 +1,18 @@
+package biz
+
+import "context"
+
+type tenantKey struct{}
+
+// NewTenantContext returns a copy of ctx scoped to tenant. Every customer
+// query made with the returned context only sees that tenant's data.
+func NewTenantContext(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant of the request. The empty tenant
+// is the default one of single-tenant deployments.
+func TenantFromContext(ctx context.Context) string {
+	t, _ := ctx.Value(tenantKey{}).(string)
+	return t
+}
@@ -0,0 +1,31 @@
+package biz
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// tracer traces the usecases. It is the global tracer, which does nothing
+// until tracing is configured.
+var tracer = otel.Tracer("customer/internal/biz")
+
+// traceContext is the trace context of ctx as propagated to consumers,
+// nil when ctx is not traced.
+func traceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// withTraceContext continues the trace an event was recorded in.
+func withTraceContext(ctx context.Context, e *Event) context.Context {
+	if len(e.TraceContext) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(e.TraceContext))
+}
@@ -0,0 +1,169 @@
+package biz
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "customer/api/customer/v1"
+)
+
+const (
+	watchBatchSize    = 100
+	watchPollInterval = time.Second
+)
+
+// ChangeType mirrors api.customer.v1.ChangeType.
+type ChangeType int32
+
+const (
+	ChangeUnspecified ChangeType = iota
+	ChangeCreated
+	ChangeUpdated
+	ChangeDeleted
+)
+
+// changeTypeOf classifies a domain event for the change feed. Contact
+// point events count as updates of their customer.
+func changeTypeOf(eventType string) ChangeType {
+	switch eventType {
+	case string((&v1.CustomerCreated{}).ProtoReflect().Descriptor().FullName()):
+		return ChangeCreated
+	case string((&v1.CustomerDeleted{}).ProtoReflect().Descriptor().FullName()):
+		return ChangeDeleted
+	default:
+		return ChangeUpdated
+	}
+}
+
+// Change is one entry of the persisted change feed. Sequence is assigned
+// when the event is appended and only ever grows.
+type Change struct {
+	Sequence   int64
+	CustomerID int64
+	Type       ChangeType
+	Event      *Event
+}
+
+type WatchFilter struct {
+	CustomerIDs   []int64
+	Types         []ChangeType
+	AfterSequence int64
+}
+
+func (f *WatchFilter) match(c *Change) bool {
+	if len(f.CustomerIDs) > 0 && !containsInt64(f.CustomerIDs, c.CustomerID) {
+		return false
+	}
+	if len(f.Types) > 0 {
+		for _, t := range f.Types {
+			if t == c.Type {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsInt64(s []int64, v int64) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+type ChangeFeedRepo interface {
+	// AppendPending appends up to limit outbox events not in the feed yet,
+	// oldest first, classified by classify, and returns how many it
+	// appended. Appends never interleave, so a change is visible to After
+	// only once every change with a smaller sequence is.
+	AppendPending(ctx context.Context, limit int, classify func(eventType string) ChangeType) (int, error)
+	// After returns changes of the tenant of ctx with a sequence greater
+	// than seq, oldest first.
+	After(ctx context.Context, seq int64, customerIDs []int64, limit int) ([]*Change, error)
+	// Head returns the latest sequence, 0 for an empty feed.
+	Head(ctx context.Context) (int64, error)
+}
+
+// ChangeFeed keeps the persisted feed behind WatchCustomers. The outbox
+// relay syncs it with the outbox; watchers read from the table so they can
+// resume after a restart, and are woken up directly when the append
+// happened in this process.
+type ChangeFeed struct {
+	repo ChangeFeedRepo
+
+	mu     sync.Mutex
+	notify chan struct{}
+}
+
+func NewChangeFeed(repo ChangeFeedRepo) *ChangeFeed {
+	return &ChangeFeed{repo: repo, notify: make(chan struct{})}
+}
+
+// Sync appends up to limit recorded events to the feed, whether they were
+// published or not, and returns how many it appended.
+func (f *ChangeFeed) Sync(ctx context.Context, limit int) (int, error) {
+	n, err := f.repo.AppendPending(ctx, limit, changeTypeOf)
+	if err != nil || n == 0 {
+		return n, err
+	}
+
+	f.mu.Lock()
+	close(f.notify)
+	f.notify = make(chan struct{})
+	f.mu.Unlock()
+	return n, nil
+}
+
+func (f *ChangeFeed) wait() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.notify
+}
+
+// Watch calls send for every change matching filter until ctx is done or
+// send fails. With no AfterSequence it starts at the current head.
+func (f *ChangeFeed) Watch(ctx context.Context, filter *WatchFilter, send func(*Change) error) error {
+	seq := filter.AfterSequence
+	if seq <= 0 {
+		head, err := f.repo.Head(ctx)
+		if err != nil {
+			return err
+		}
+		seq = head
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		// grab the channel before reading so an append in between is not missed
+		wake := f.wait()
+
+		changes, err := f.repo.After(ctx, seq, filter.CustomerIDs, watchBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			seq = c.Sequence
+			if !filter.match(c) {
+				continue
+			}
+			if err := send(c); err != nil {
+				return err
+			}
+		}
+		if len(changes) == watchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
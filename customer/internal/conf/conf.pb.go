@@ -26,6 +26,7 @@ type Bootstrap struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log           *Log                   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,16 +75,89 @@ func (x *Bootstrap) GetData() *Data {
 	return nil
 }
 
+func (x *Bootstrap) GetLog() *Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+// Log configures the service log.
+type Log struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// debug, info, warn or error, info when unset
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// logfmt or json, logfmt when unset
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
+	mi := &file_internal_conf_conf_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log) ProtoMessage() {}
+
+func (x *Log) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Log) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Log) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,1,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Outbox        *Server_Outbox         `protobuf:"bytes,2,opt,name=outbox,proto3" json:"outbox,omitempty"`
+	Auth          *Server_Auth           `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth,omitempty"`
+	Authz         *Server_Authz          `protobuf:"bytes,4,opt,name=authz,proto3" json:"authz,omitempty"`
+	Tenancy       *Server_Tenancy        `protobuf:"bytes,5,opt,name=tenancy,proto3" json:"tenancy,omitempty"`
+	Erasure       *Server_Erasure        `protobuf:"bytes,6,opt,name=erasure,proto3" json:"erasure,omitempty"`
+	Purge         *Server_Purge          `protobuf:"bytes,7,opt,name=purge,proto3" json:"purge,omitempty"`
+	Metrics       *Server_Metrics        `protobuf:"bytes,8,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Tracing       *Server_Tracing        `protobuf:"bytes,9,opt,name=tracing,proto3" json:"tracing,omitempty"`
+	AccessLog     *Server_AccessLog      `protobuf:"bytes,10,opt,name=access_log,json=accessLog,proto3" json:"access_log,omitempty"`
+	Health        *Server_Health         `protobuf:"bytes,11,opt,name=health,proto3" json:"health,omitempty"`
+	RateLimit     *Server_RateLimit      `protobuf:"bytes,12,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Server) Reset() {
 	*x = Server{}
-	mi := &file_internal_conf_conf_proto_msgTypes[1]
+	mi := &file_internal_conf_conf_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -95,7 +169,7 @@ func (x *Server) String() string {
 func (*Server) ProtoMessage() {}
 
 func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[1]
+	mi := &file_internal_conf_conf_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,7 +182,7 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server.ProtoReflect.Descriptor instead.
 func (*Server) Descriptor() ([]byte, []int) {
-	return file_internal_conf_conf_proto_rawDescGZIP(), []int{1}
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Server) GetGrpc() *Server_GRPC {
@@ -118,17 +192,100 @@ func (x *Server) GetGrpc() *Server_GRPC {
 	return nil
 }
 
+func (x *Server) GetOutbox() *Server_Outbox {
+	if x != nil {
+		return x.Outbox
+	}
+	return nil
+}
+
+func (x *Server) GetAuth() *Server_Auth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *Server) GetAuthz() *Server_Authz {
+	if x != nil {
+		return x.Authz
+	}
+	return nil
+}
+
+func (x *Server) GetTenancy() *Server_Tenancy {
+	if x != nil {
+		return x.Tenancy
+	}
+	return nil
+}
+
+func (x *Server) GetErasure() *Server_Erasure {
+	if x != nil {
+		return x.Erasure
+	}
+	return nil
+}
+
+func (x *Server) GetPurge() *Server_Purge {
+	if x != nil {
+		return x.Purge
+	}
+	return nil
+}
+
+func (x *Server) GetMetrics() *Server_Metrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Server) GetTracing() *Server_Tracing {
+	if x != nil {
+		return x.Tracing
+	}
+	return nil
+}
+
+func (x *Server) GetAccessLog() *Server_AccessLog {
+	if x != nil {
+		return x.AccessLog
+	}
+	return nil
+}
+
+func (x *Server) GetHealth() *Server_Health {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *Server) GetRateLimit() *Server_RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
 	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	Publisher     *Data_Publisher        `protobuf:"bytes,3,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	Encryption    *Data_Encryption       `protobuf:"bytes,4,opt,name=encryption,proto3" json:"encryption,omitempty"`
+	Erasure       *Data_Erasure          `protobuf:"bytes,5,opt,name=erasure,proto3" json:"erasure,omitempty"`
+	Retention     *Data_Retention        `protobuf:"bytes,6,opt,name=retention,proto3" json:"retention,omitempty"`
+	Idempotency   *Data_Idempotency      `protobuf:"bytes,7,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
+	RateLimit     *Data_RateLimit        `protobuf:"bytes,8,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_internal_conf_conf_proto_msgTypes[2]
+	mi := &file_internal_conf_conf_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +297,7 @@ func (x *Data) String() string {
 func (*Data) ProtoMessage() {}
 
 func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[2]
+	mi := &file_internal_conf_conf_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +310,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2}
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Data) GetDatabase() *Data_Database {
@@ -170,18 +327,62 @@ func (x *Data) GetRedis() *Data_Redis {
 	return nil
 }
 
+func (x *Data) GetPublisher() *Data_Publisher {
+	if x != nil {
+		return x.Publisher
+	}
+	return nil
+}
+
+func (x *Data) GetEncryption() *Data_Encryption {
+	if x != nil {
+		return x.Encryption
+	}
+	return nil
+}
+
+func (x *Data) GetErasure() *Data_Erasure {
+	if x != nil {
+		return x.Erasure
+	}
+	return nil
+}
+
+func (x *Data) GetRetention() *Data_Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Data) GetIdempotency() *Data_Idempotency {
+	if x != nil {
+		return x.Idempotency
+	}
+	return nil
+}
+
+func (x *Data) GetRateLimit() *Data_RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
 type Server_GRPC struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Network string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr    string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// serve grpc.reflection for grpcurl and the like
+	Reflection    bool `protobuf:"varint,4,opt,name=reflection,proto3" json:"reflection,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_internal_conf_conf_proto_msgTypes[3]
+	mi := &file_internal_conf_conf_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -193,7 +394,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[3]
+	mi := &file_internal_conf_conf_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -206,7 +407,7 @@ func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server_GRPC.ProtoReflect.Descriptor instead.
 func (*Server_GRPC) Descriptor() ([]byte, []int) {
-	return file_internal_conf_conf_proto_rawDescGZIP(), []int{1, 0}
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 0}
 }
 
 func (x *Server_GRPC) GetNetwork() string {
@@ -230,29 +431,39 @@ func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
-type Data_Database struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
-	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+func (x *Server_GRPC) GetReflection() bool {
+	if x != nil {
+		return x.Reflection
+	}
+	return false
+}
+
+type Server_Outbox struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Interval  *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// how long a replica holds the relay lease without renewing it, 30s
+	// when unset
+	LeaseTtl      *durationpb.Duration `protobuf:"bytes,3,opt,name=lease_ttl,json=leaseTtl,proto3" json:"lease_ttl,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Database) Reset() {
-	*x = Data_Database{}
-	mi := &file_internal_conf_conf_proto_msgTypes[4]
+func (x *Server_Outbox) Reset() {
+	*x = Server_Outbox{}
+	mi := &file_internal_conf_conf_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Database) String() string {
+func (x *Server_Outbox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Database) ProtoMessage() {}
+func (*Server_Outbox) ProtoMessage() {}
 
-func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[4]
+func (x *Server_Outbox) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,50 +474,56 @@ func (x *Data_Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
-func (*Data_Database) Descriptor() ([]byte, []int) {
-	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use Server_Outbox.ProtoReflect.Descriptor instead.
+func (*Server_Outbox) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 1}
 }
 
-func (x *Data_Database) GetDriver() string {
+func (x *Server_Outbox) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Driver
+		return x.Interval
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Database) GetSource() string {
+func (x *Server_Outbox) GetBatchSize() int32 {
 	if x != nil {
-		return x.Source
+		return x.BatchSize
 	}
-	return ""
+	return 0
 }
 
-type Data_Redis struct {
+func (x *Server_Outbox) GetLeaseTtl() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseTtl
+	}
+	return nil
+}
+
+// Erasure runs the customer erasures whose grace period is over.
+type Server_Erasure struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
-	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Redis) Reset() {
-	*x = Data_Redis{}
-	mi := &file_internal_conf_conf_proto_msgTypes[5]
+func (x *Server_Erasure) Reset() {
+	*x = Server_Erasure{}
+	mi := &file_internal_conf_conf_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Redis) String() string {
+func (x *Server_Erasure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Redis) ProtoMessage() {}
+func (*Server_Erasure) ProtoMessage() {}
 
-func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_conf_conf_proto_msgTypes[5]
+func (x *Server_Erasure) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,65 +534,1443 @@ func (x *Data_Redis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
-func (*Data_Redis) Descriptor() ([]byte, []int) {
-	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 1}
+// Deprecated: Use Server_Erasure.ProtoReflect.Descriptor instead.
+func (*Server_Erasure) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 2}
 }
 
-func (x *Data_Redis) GetNetwork() string {
+func (x *Server_Erasure) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Network
+		return x.Interval
 	}
-	return ""
+	return nil
 }
 
-func (x *Data_Redis) GetAddr() string {
+func (x *Server_Erasure) GetBatchSize() int32 {
 	if x != nil {
-		return x.Addr
+		return x.BatchSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Data_Redis) GetReadTimeout() *durationpb.Duration {
+// Purge applies the retention policies of conf.Data. Replicas take turns
+// through a lease in the database.
+type Server_Purge struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Interval  *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// how long a replica holds the lease without renewing it, 5m when unset
+	LeaseTtl      *durationpb.Duration `protobuf:"bytes,3,opt,name=lease_ttl,json=leaseTtl,proto3" json:"lease_ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Purge) Reset() {
+	*x = Server_Purge{}
+	mi := &file_internal_conf_conf_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Purge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Purge) ProtoMessage() {}
+
+func (x *Server_Purge) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[7]
 	if x != nil {
-		return x.ReadTimeout
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Purge.ProtoReflect.Descriptor instead.
+func (*Server_Purge) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Server_Purge) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
 	}
 	return nil
 }
 
-func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
+func (x *Server_Purge) GetBatchSize() int32 {
 	if x != nil {
-		return x.WriteTimeout
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_Purge) GetLeaseTtl() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseTtl
 	}
 	return nil
 }
 
-var File_internal_conf_conf_proto protoreflect.FileDescriptor
+// Auth validates JWT bearer tokens. Requests are not authenticated
+// when it is unset.
+type Server_Auth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JWKS document with the verification keys
+	JwksFile string `protobuf:"bytes,1,opt,name=jwks_file,json=jwksFile,proto3" json:"jwks_file,omitempty"`
+	// PEM encoded public keys, or the shared secret for HS* methods
+	KeyFiles []string `protobuf:"bytes,2,rep,name=key_files,json=keyFiles,proto3" json:"key_files,omitempty"`
+	// signing method tokens must use, RS256 when unset
+	SigningMethod string `protobuf:"bytes,3,opt,name=signing_method,json=signingMethod,proto3" json:"signing_method,omitempty"`
+	// expected iss and aud claims, not checked when empty
+	Issuer   string `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Audience string `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`
+	// operations served without a token, the health service when unset
+	AllowUnauthenticated []string `protobuf:"bytes,6,rep,name=allow_unauthenticated,json=allowUnauthenticated,proto3" json:"allow_unauthenticated,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
 
-const file_internal_conf_conf_proto_rawDesc = "" +
-	"\n" +
-	"\x18internal/conf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"]\n" +
-	"\tBootstrap\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
-	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\"\xa0\x01\n" +
-	"\x06Server\x12+\n" +
-	"\x04grpc\x18\x01 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
-	"\x04GRPC\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xdd\x02\n" +
-	"\x04Data\x125\n" +
-	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
-	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
-	"\bDatabase\x12\x16\n" +
-	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
-	"\x06source\x18\x02 \x01(\tR\x06source\x1a\xb3\x01\n" +
-	"\x05Redis\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
-	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
-	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeoutB\x1dZ\x1bcustomer/internal/conf;confb\x06proto3"
+func (x *Server_Auth) Reset() {
+	*x = Server_Auth{}
+	mi := &file_internal_conf_conf_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Auth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Auth) ProtoMessage() {}
+
+func (x *Server_Auth) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Auth.ProtoReflect.Descriptor instead.
+func (*Server_Auth) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *Server_Auth) GetJwksFile() string {
+	if x != nil {
+		return x.JwksFile
+	}
+	return ""
+}
+
+func (x *Server_Auth) GetKeyFiles() []string {
+	if x != nil {
+		return x.KeyFiles
+	}
+	return nil
+}
+
+func (x *Server_Auth) GetSigningMethod() string {
+	if x != nil {
+		return x.SigningMethod
+	}
+	return ""
+}
+
+func (x *Server_Auth) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Server_Auth) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *Server_Auth) GetAllowUnauthenticated() []string {
+	if x != nil {
+		return x.AllowUnauthenticated
+	}
+	return nil
+}
+
+// Authz limits what the roles of an authenticated caller may call and
+// see. The roles of a caller are its roles and scope claims. Every
+// authenticated caller may call everything when it is unset.
+type Server_Authz struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []*Server_Authz_Policy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Authz) Reset() {
+	*x = Server_Authz{}
+	mi := &file_internal_conf_conf_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Authz) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Authz) ProtoMessage() {}
+
+func (x *Server_Authz) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Authz.ProtoReflect.Descriptor instead.
+func (*Server_Authz) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *Server_Authz) GetPolicies() []*Server_Authz_Policy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+// Tenancy selects the tenant a request acts on. Authenticated requests
+// use the tenant claim of their token and are rejected without one,
+// others use the metadata header.
+type Server_Tenancy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// metadata key of the tenant id, "x-tenant-id" when unset
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// reject requests without a tenant instead of using the default one
+	Required      bool `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Tenancy) Reset() {
+	*x = Server_Tenancy{}
+	mi := &file_internal_conf_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Tenancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Tenancy) ProtoMessage() {}
+
+func (x *Server_Tenancy) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Tenancy.ProtoReflect.Descriptor instead.
+func (*Server_Tenancy) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 6}
+}
+
+func (x *Server_Tenancy) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *Server_Tenancy) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// Metrics serves Prometheus metrics over HTTP. They are not collected
+// when it is unset.
+type Server_Metrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// listen address, e.g. 0.0.0.0:9090
+	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	// "/metrics" when unset
+	Path          string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Metrics) Reset() {
+	*x = Server_Metrics{}
+	mi := &file_internal_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Metrics) ProtoMessage() {}
+
+func (x *Server_Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Metrics.ProtoReflect.Descriptor instead.
+func (*Server_Metrics) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 7}
+}
+
+func (x *Server_Metrics) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Server_Metrics) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// Tracing exports OpenTelemetry traces. Nothing is traced when it is
+// unset.
+type Server_Tracing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "otlp" sends spans to a collector over gRPC, "stdout" prints them
+	// and "file" appends them to file, for environments without a
+	// collector
+	Exporter string `protobuf:"bytes,1,opt,name=exporter,proto3" json:"exporter,omitempty"`
+	// collector address for otlp, e.g. localhost:4317
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// talk to the collector without TLS
+	Insecure bool   `protobuf:"varint,3,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	File     string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	// share of new traces recorded, all of them when unset
+	SampleRatio float64 `protobuf:"fixed64,5,opt,name=sample_ratio,json=sampleRatio,proto3" json:"sample_ratio,omitempty"`
+	// "customer" when unset
+	ServiceName   string `protobuf:"bytes,6,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Tracing) Reset() {
+	*x = Server_Tracing{}
+	mi := &file_internal_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Tracing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Tracing) ProtoMessage() {}
+
+func (x *Server_Tracing) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Tracing.ProtoReflect.Descriptor instead.
+func (*Server_Tracing) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 8}
+}
+
+func (x *Server_Tracing) GetExporter() string {
+	if x != nil {
+		return x.Exporter
+	}
+	return ""
+}
+
+func (x *Server_Tracing) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Server_Tracing) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *Server_Tracing) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Server_Tracing) GetSampleRatio() float64 {
+	if x != nil {
+		return x.SampleRatio
+	}
+	return 0
+}
+
+func (x *Server_Tracing) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// AccessLog configures the log line written for every request.
+type Server_AccessLog struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// log requests and replies too, with personal data masked
+	Payloads      bool `protobuf:"varint,1,opt,name=payloads,proto3" json:"payloads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_AccessLog) Reset() {
+	*x = Server_AccessLog{}
+	mi := &file_internal_conf_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_AccessLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_AccessLog) ProtoMessage() {}
+
+func (x *Server_AccessLog) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_AccessLog.ProtoReflect.Descriptor instead.
+func (*Server_AccessLog) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 9}
+}
+
+func (x *Server_AccessLog) GetPayloads() bool {
+	if x != nil {
+		return x.Payloads
+	}
+	return false
+}
+
+// Health drives the grpc.health.v1 status from the dependencies.
+type Server_Health struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// how often the dependencies are checked, 5s when unset
+	Interval *durationpb.Duration `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// how long one check may take, 2s when unset
+	Timeout *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// how long the server keeps serving after it reports NOT_SERVING on
+	// shutdown, so probes notice before the listener closes
+	ShutdownDelay *durationpb.Duration `protobuf:"bytes,3,opt,name=shutdown_delay,json=shutdownDelay,proto3" json:"shutdown_delay,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Health) Reset() {
+	*x = Server_Health{}
+	mi := &file_internal_conf_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Health) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Health) ProtoMessage() {}
+
+func (x *Server_Health) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Health.ProtoReflect.Descriptor instead.
+func (*Server_Health) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 10}
+}
+
+func (x *Server_Health) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_Health) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Server_Health) GetShutdownDelay() *durationpb.Duration {
+	if x != nil {
+		return x.ShutdownDelay
+	}
+	return nil
+}
+
+// RateLimit limits how often every client may call the server. Clients
+// are told apart by their subject within the tenant, anonymous ones by
+// the host they connect from.
+type Server_RateLimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the limit of operations no other limit names, none when unset
+	Default *Server_RateLimit_Limit `protobuf:"bytes,1,opt,name=default,proto3" json:"default,omitempty"`
+	// limits of single operations, the first one naming an operation
+	// applies. Every limit has a bucket of its own per client.
+	Limits        []*Server_RateLimit_Limit `protobuf:"bytes,2,rep,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_RateLimit) Reset() {
+	*x = Server_RateLimit{}
+	mi := &file_internal_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_RateLimit) ProtoMessage() {}
+
+func (x *Server_RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_RateLimit.ProtoReflect.Descriptor instead.
+func (*Server_RateLimit) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 11}
+}
+
+func (x *Server_RateLimit) GetDefault() *Server_RateLimit_Limit {
+	if x != nil {
+		return x.Default
+	}
+	return nil
+}
+
+func (x *Server_RateLimit) GetLimits() []*Server_RateLimit_Limit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type Server_Authz_Policy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Role  string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// operations the role may call: full names, method names or globs
+	// such as "Get*"; "*" allows every operation
+	Allow []string `protobuf:"bytes,2,rep,name=allow,proto3" json:"allow,omitempty"`
+	// reply fields hidden from the role, matched by name at any depth,
+	// e.g. date_of_birth or addresses. emails, phone_numbers and
+	// addresses also hide the single email, phone_number and address
+	// fields and the consent contacts of their channel.
+	Redact        []string `protobuf:"bytes,3,rep,name=redact,proto3" json:"redact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Authz_Policy) Reset() {
+	*x = Server_Authz_Policy{}
+	mi := &file_internal_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Authz_Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Authz_Policy) ProtoMessage() {}
+
+func (x *Server_Authz_Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Authz_Policy.ProtoReflect.Descriptor instead.
+func (*Server_Authz_Policy) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 5, 0}
+}
+
+func (x *Server_Authz_Policy) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Server_Authz_Policy) GetAllow() []string {
+	if x != nil {
+		return x.Allow
+	}
+	return nil
+}
+
+func (x *Server_Authz_Policy) GetRedact() []string {
+	if x != nil {
+		return x.Redact
+	}
+	return nil
+}
+
+type Server_RateLimit_Limit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// names the bucket, e.g. "list"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// operations the limit applies to: full names, method names or
+	// globs of either, e.g. "ListCustomer" or "Export*"
+	Operations []string `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	// requests let through per period
+	Requests int32 `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	// 1s when unset
+	Period *durationpb.Duration `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	// requests let through at once, requests when unset
+	Burst         int32 `protobuf:"varint,5,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_RateLimit_Limit) Reset() {
+	*x = Server_RateLimit_Limit{}
+	mi := &file_internal_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_RateLimit_Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_RateLimit_Limit) ProtoMessage() {}
+
+func (x *Server_RateLimit_Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_RateLimit_Limit.ProtoReflect.Descriptor instead.
+func (*Server_RateLimit_Limit) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{2, 11, 0}
+}
+
+func (x *Server_RateLimit_Limit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Server_RateLimit_Limit) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *Server_RateLimit_Limit) GetRequests() int32 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *Server_RateLimit_Limit) GetPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *Server_RateLimit_Limit) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+type Data_Database struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Driver string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Source string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// connection pool limits, unlimited when unset
+	MaxOpenConns int32 `protobuf:"varint,3,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	// idle connections kept open, 2 when unset
+	MaxIdleConns int32 `protobuf:"varint,4,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	// connections are closed and reopened after this long, never when unset
+	ConnMaxLifetime *durationpb.Duration `protobuf:"bytes,5,opt,name=conn_max_lifetime,json=connMaxLifetime,proto3" json:"conn_max_lifetime,omitempty"`
+	// idle connections are closed after this long, never when unset
+	ConnMaxIdleTime *durationpb.Duration `protobuf:"bytes,6,opt,name=conn_max_idle_time,json=connMaxIdleTime,proto3" json:"conn_max_idle_time,omitempty"`
+	// how long one statement may run, within the deadline of the request;
+	// statements only have the request deadline when unset
+	QueryTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=query_timeout,json=queryTimeout,proto3" json:"query_timeout,omitempty"`
+	// statements running longer are logged, none when unset
+	SlowQueryThreshold *durationpb.Duration `protobuf:"bytes,8,opt,name=slow_query_threshold,json=slowQueryThreshold,proto3" json:"slow_query_threshold,omitempty"`
+	// read replicas of source, customer queries outside of transactions
+	// are spread over the healthy ones
+	Replicas []string `protobuf:"bytes,9,rep,name=replicas,proto3" json:"replicas,omitempty"`
+	// after a write, the caller reads from source for this long so it sees
+	// its own write; replicas may return older data right after a write
+	// when unset
+	ReadYourWrites *durationpb.Duration `protobuf:"bytes,10,opt,name=read_your_writes,json=readYourWrites,proto3" json:"read_your_writes,omitempty"`
+	// how often replicas are checked, 5s when unset
+	ReplicaCheckInterval *durationpb.Duration `protobuf:"bytes,11,opt,name=replica_check_interval,json=replicaCheckInterval,proto3" json:"replica_check_interval,omitempty"`
+	// replicas further behind source are taken out of rotation, the lag
+	// is not checked when unset
+	ReplicaMaxLag *durationpb.Duration `protobuf:"bytes,12,opt,name=replica_max_lag,json=replicaMaxLag,proto3" json:"replica_max_lag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Database) Reset() {
+	*x = Data_Database{}
+	mi := &file_internal_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Database) ProtoMessage() {}
+
+func (x *Data_Database) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Database.ProtoReflect.Descriptor instead.
+func (*Data_Database) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *Data_Database) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *Data_Database) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Data_Database) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *Data_Database) GetConnMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxLifetime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetConnMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.ConnMaxIdleTime
+	}
+	return nil
+}
+
+func (x *Data_Database) GetQueryTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.QueryTimeout
+	}
+	return nil
+}
+
+func (x *Data_Database) GetSlowQueryThreshold() *durationpb.Duration {
+	if x != nil {
+		return x.SlowQueryThreshold
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicas() []string {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReadYourWrites() *durationpb.Duration {
+	if x != nil {
+		return x.ReadYourWrites
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicaCheckInterval() *durationpb.Duration {
+	if x != nil {
+		return x.ReplicaCheckInterval
+	}
+	return nil
+}
+
+func (x *Data_Database) GetReplicaMaxLag() *durationpb.Duration {
+	if x != nil {
+		return x.ReplicaMaxLag
+	}
+	return nil
+}
+
+type Data_Redis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	ReadTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Redis) Reset() {
+	*x = Data_Redis{}
+	mi := &file_internal_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Redis) ProtoMessage() {}
+
+func (x *Data_Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Redis.ProtoReflect.Descriptor instead.
+func (*Data_Redis) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *Data_Redis) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Data_Redis) GetReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadTimeout
+	}
+	return nil
+}
+
+func (x *Data_Redis) GetWriteTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WriteTimeout
+	}
+	return nil
+}
+
+type Data_Publisher struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind selects the publisher implementation: "log" (default) or "webhook".
+	Kind          string               `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	WebhookUrl    string               `protobuf:"bytes,2,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	Timeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Publisher) Reset() {
+	*x = Data_Publisher{}
+	mi := &file_internal_conf_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Publisher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Publisher) ProtoMessage() {}
+
+func (x *Data_Publisher) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Publisher.ProtoReflect.Descriptor instead.
+func (*Data_Publisher) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *Data_Publisher) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Data_Publisher) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *Data_Publisher) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// Encryption encrypts names, dates of birth and contact points at rest.
+// Without it they are stored in plain text.
+type Data_Encryption struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind selects the key provider: "file" (default).
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// key_file is the key ring of the file provider, see configs/keys.example.json.
+	KeyFile       string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Encryption) Reset() {
+	*x = Data_Encryption{}
+	mi := &file_internal_conf_conf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Encryption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Encryption) ProtoMessage() {}
+
+func (x *Data_Encryption) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Encryption.ProtoReflect.Descriptor instead.
+func (*Data_Encryption) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 3}
+}
+
+func (x *Data_Encryption) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Data_Encryption) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+// Erasure configures EraseCustomer.
+type Data_Erasure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// grace_period delays erasures so they can be cancelled, they run
+	// right away when unset
+	GracePeriod   *durationpb.Duration `protobuf:"bytes,1,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Erasure) Reset() {
+	*x = Data_Erasure{}
+	mi := &file_internal_conf_conf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Erasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Erasure) ProtoMessage() {}
+
+func (x *Data_Erasure) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Erasure.ProtoReflect.Descriptor instead.
+func (*Data_Erasure) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 4}
+}
+
+func (x *Data_Erasure) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
+	}
+	return nil
+}
+
+// Retention limits how long data is kept. Unset periods keep it forever.
+type Data_Retention struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// soft-deleted customers are purged this long after their deletion
+	DeletedCustomers *durationpb.Duration `protobuf:"bytes,1,opt,name=deleted_customers,json=deletedCustomers,proto3" json:"deleted_customers,omitempty"`
+	// customers without activity for this long are deleted, then purged
+	// by deleted_customers; suspended customers and scheduled erasures
+	// are left alone
+	InactiveCustomers *durationpb.Duration `protobuf:"bytes,2,opt,name=inactive_customers,json=inactiveCustomers,proto3" json:"inactive_customers,omitempty"`
+	// emails and phone numbers not verified this long after they were
+	// added are removed
+	UnverifiedContacts *durationpb.Duration `protobuf:"bytes,3,opt,name=unverified_contacts,json=unverifiedContacts,proto3" json:"unverified_contacts,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Data_Retention) Reset() {
+	*x = Data_Retention{}
+	mi := &file_internal_conf_conf_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Retention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Retention) ProtoMessage() {}
+
+func (x *Data_Retention) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Retention.ProtoReflect.Descriptor instead.
+func (*Data_Retention) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 5}
+}
+
+func (x *Data_Retention) GetDeletedCustomers() *durationpb.Duration {
+	if x != nil {
+		return x.DeletedCustomers
+	}
+	return nil
+}
+
+func (x *Data_Retention) GetInactiveCustomers() *durationpb.Duration {
+	if x != nil {
+		return x.InactiveCustomers
+	}
+	return nil
+}
+
+func (x *Data_Retention) GetUnverifiedContacts() *durationpb.Duration {
+	if x != nil {
+		return x.UnverifiedContacts
+	}
+	return nil
+}
+
+// Idempotency keeps the responses of requests sent with an idempotency
+// key, so retries get them instead of running again.
+type Data_Idempotency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// where responses are kept: "postgres" (default) or "redis", which
+	// needs redis to be configured
+	Backend string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	// how long a response is kept, 24h when unset
+	Ttl           *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Idempotency) Reset() {
+	*x = Data_Idempotency{}
+	mi := &file_internal_conf_conf_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Idempotency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Idempotency) ProtoMessage() {}
+
+func (x *Data_Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Idempotency.ProtoReflect.Descriptor instead.
+func (*Data_Idempotency) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 6}
+}
+
+func (x *Data_Idempotency) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *Data_Idempotency) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// RateLimit keeps the buckets of the rate limits.
+type Data_RateLimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "memory" (default) keeps them per replica, "redis" shares them
+	// between replicas and needs redis to be configured
+	Backend       string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_RateLimit) Reset() {
+	*x = Data_RateLimit{}
+	mi := &file_internal_conf_conf_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_RateLimit) ProtoMessage() {}
+
+func (x *Data_RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_conf_conf_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_RateLimit.ProtoReflect.Descriptor instead.
+func (*Data_RateLimit) Descriptor() ([]byte, []int) {
+	return file_internal_conf_conf_proto_rawDescGZIP(), []int{3, 7}
+}
+
+func (x *Data_RateLimit) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+var File_internal_conf_conf_proto protoreflect.FileDescriptor
+
+const file_internal_conf_conf_proto_rawDesc = "" +
+	"\n" +
+	"\x18internal/conf/conf.proto\x12\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\x80\x01\n" +
+	"\tBootstrap\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12!\n" +
+	"\x03log\x18\x03 \x01(\v2\x0f.kratos.api.LogR\x03log\"3\n" +
+	"\x03Log\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\xb7\x12\n" +
+	"\x06Server\x12+\n" +
+	"\x04grpc\x18\x01 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x121\n" +
+	"\x06outbox\x18\x02 \x01(\v2\x19.kratos.api.Server.OutboxR\x06outbox\x12+\n" +
+	"\x04auth\x18\x03 \x01(\v2\x17.kratos.api.Server.AuthR\x04auth\x12.\n" +
+	"\x05authz\x18\x04 \x01(\v2\x18.kratos.api.Server.AuthzR\x05authz\x124\n" +
+	"\atenancy\x18\x05 \x01(\v2\x1a.kratos.api.Server.TenancyR\atenancy\x124\n" +
+	"\aerasure\x18\x06 \x01(\v2\x1a.kratos.api.Server.ErasureR\aerasure\x12.\n" +
+	"\x05purge\x18\a \x01(\v2\x18.kratos.api.Server.PurgeR\x05purge\x124\n" +
+	"\ametrics\x18\b \x01(\v2\x1a.kratos.api.Server.MetricsR\ametrics\x124\n" +
+	"\atracing\x18\t \x01(\v2\x1a.kratos.api.Server.TracingR\atracing\x12;\n" +
+	"\n" +
+	"access_log\x18\n" +
+	" \x01(\v2\x1c.kratos.api.Server.AccessLogR\taccessLog\x121\n" +
+	"\x06health\x18\v \x01(\v2\x19.kratos.api.Server.HealthR\x06health\x12;\n" +
+	"\n" +
+	"rate_limit\x18\f \x01(\v2\x1c.kratos.api.Server.RateLimitR\trateLimit\x1a\x89\x01\n" +
+	"\x04GRPC\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x1e\n" +
+	"\n" +
+	"reflection\x18\x04 \x01(\bR\n" +
+	"reflection\x1a\x96\x01\n" +
+	"\x06Outbox\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x126\n" +
+	"\tlease_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bleaseTtl\x1a_\n" +
+	"\aErasure\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x1a\x95\x01\n" +
+	"\x05Purge\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x126\n" +
+	"\tlease_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bleaseTtl\x1a\xd0\x01\n" +
+	"\x04Auth\x12\x1b\n" +
+	"\tjwks_file\x18\x01 \x01(\tR\bjwksFile\x12\x1b\n" +
+	"\tkey_files\x18\x02 \x03(\tR\bkeyFiles\x12%\n" +
+	"\x0esigning_method\x18\x03 \x01(\tR\rsigningMethod\x12\x16\n" +
+	"\x06issuer\x18\x04 \x01(\tR\x06issuer\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\x123\n" +
+	"\x15allow_unauthenticated\x18\x06 \x03(\tR\x14allowUnauthenticated\x1a\x90\x01\n" +
+	"\x05Authz\x12;\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x1f.kratos.api.Server.Authz.PolicyR\bpolicies\x1aJ\n" +
+	"\x06Policy\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x14\n" +
+	"\x05allow\x18\x02 \x03(\tR\x05allow\x12\x16\n" +
+	"\x06redact\x18\x03 \x03(\tR\x06redact\x1a=\n" +
+	"\aTenancy\x12\x16\n" +
+	"\x06header\x18\x01 \x01(\tR\x06header\x12\x1a\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\x1a1\n" +
+	"\aMetrics\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1a\xb7\x01\n" +
+	"\aTracing\x12\x1a\n" +
+	"\bexporter\x18\x01 \x01(\tR\bexporter\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x12\x1a\n" +
+	"\binsecure\x18\x03 \x01(\bR\binsecure\x12\x12\n" +
+	"\x04file\x18\x04 \x01(\tR\x04file\x12!\n" +
+	"\fsample_ratio\x18\x05 \x01(\x01R\vsampleRatio\x12!\n" +
+	"\fservice_name\x18\x06 \x01(\tR\vserviceName\x1a'\n" +
+	"\tAccessLog\x12\x1a\n" +
+	"\bpayloads\x18\x01 \x01(\bR\bpayloads\x1a\xb6\x01\n" +
+	"\x06Health\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12@\n" +
+	"\x0eshutdown_delay\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rshutdownDelay\x1a\xa8\x02\n" +
+	"\tRateLimit\x12<\n" +
+	"\adefault\x18\x01 \x01(\v2\".kratos.api.Server.RateLimit.LimitR\adefault\x12:\n" +
+	"\x06limits\x18\x02 \x03(\v2\".kratos.api.Server.RateLimit.LimitR\x06limits\x1a\xa0\x01\n" +
+	"\x05Limit\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x02 \x03(\tR\n" +
+	"operations\x12\x1a\n" +
+	"\brequests\x18\x03 \x01(\x05R\brequests\x121\n" +
+	"\x06period\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x06period\x12\x14\n" +
+	"\x05burst\x18\x05 \x01(\x05R\x05burst\"\x81\x0f\n" +
+	"\x04Data\x125\n" +
+	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
+	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x128\n" +
+	"\tpublisher\x18\x03 \x01(\v2\x1a.kratos.api.Data.PublisherR\tpublisher\x12;\n" +
+	"\n" +
+	"encryption\x18\x04 \x01(\v2\x1b.kratos.api.Data.EncryptionR\n" +
+	"encryption\x122\n" +
+	"\aerasure\x18\x05 \x01(\v2\x18.kratos.api.Data.ErasureR\aerasure\x128\n" +
+	"\tretention\x18\x06 \x01(\v2\x1a.kratos.api.Data.RetentionR\tretention\x12>\n" +
+	"\vidempotency\x18\a \x01(\v2\x1c.kratos.api.Data.IdempotencyR\vidempotency\x129\n" +
+	"\n" +
+	"rate_limit\x18\b \x01(\v2\x1a.kratos.api.Data.RateLimitR\trateLimit\x1a\x97\x05\n" +
+	"\bDatabase\x12\x16\n" +
+	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12$\n" +
+	"\x0emax_open_conns\x18\x03 \x01(\x05R\fmaxOpenConns\x12$\n" +
+	"\x0emax_idle_conns\x18\x04 \x01(\x05R\fmaxIdleConns\x12E\n" +
+	"\x11conn_max_lifetime\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxLifetime\x12F\n" +
+	"\x12conn_max_idle_time\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x0fconnMaxIdleTime\x12>\n" +
+	"\rquery_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\fqueryTimeout\x12K\n" +
+	"\x14slow_query_threshold\x18\b \x01(\v2\x19.google.protobuf.DurationR\x12slowQueryThreshold\x12\x1a\n" +
+	"\breplicas\x18\t \x03(\tR\breplicas\x12C\n" +
+	"\x10read_your_writes\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\x0ereadYourWrites\x12O\n" +
+	"\x16replica_check_interval\x18\v \x01(\v2\x19.google.protobuf.DurationR\x14replicaCheckInterval\x12A\n" +
+	"\x0freplica_max_lag\x18\f \x01(\v2\x19.google.protobuf.DurationR\rreplicaMaxLag\x1a\xb3\x01\n" +
+	"\x05Redis\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12<\n" +
+	"\fread_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\vreadTimeout\x12>\n" +
+	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\x1au\n" +
+	"\tPublisher\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vwebhook_url\x18\x02 \x01(\tR\n" +
+	"webhookUrl\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a;\n" +
+	"\n" +
+	"Encryption\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x19\n" +
+	"\bkey_file\x18\x02 \x01(\tR\akeyFile\x1aG\n" +
+	"\aErasure\x12<\n" +
+	"\fgrace_period\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\vgracePeriod\x1a\xe9\x01\n" +
+	"\tRetention\x12F\n" +
+	"\x11deleted_customers\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x10deletedCustomers\x12H\n" +
+	"\x12inactive_customers\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x11inactiveCustomers\x12J\n" +
+	"\x13unverified_contacts\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x12unverifiedContacts\x1aT\n" +
+	"\vIdempotency\x12\x18\n" +
+	"\abackend\x18\x01 \x01(\tR\abackend\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x1a%\n" +
+	"\tRateLimit\x12\x18\n" +
+	"\abackend\x18\x01 \x01(\tR\abackendB\x1dZ\x1bcustomer/internal/conf;confb\x06proto3"
 
 var (
 	file_internal_conf_conf_proto_rawDescOnce sync.Once
@@ -389,30 +1984,93 @@ func file_internal_conf_conf_proto_rawDescGZIP() []byte {
 	return file_internal_conf_conf_proto_rawDescData
 }
 
-var file_internal_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_internal_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_internal_conf_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Server)(nil),              // 1: kratos.api.Server
-	(*Data)(nil),                // 2: kratos.api.Data
-	(*Server_GRPC)(nil),         // 3: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 4: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 5: kratos.api.Data.Redis
-	(*durationpb.Duration)(nil), // 6: google.protobuf.Duration
+	(*Bootstrap)(nil),              // 0: kratos.api.Bootstrap
+	(*Log)(nil),                    // 1: kratos.api.Log
+	(*Server)(nil),                 // 2: kratos.api.Server
+	(*Data)(nil),                   // 3: kratos.api.Data
+	(*Server_GRPC)(nil),            // 4: kratos.api.Server.GRPC
+	(*Server_Outbox)(nil),          // 5: kratos.api.Server.Outbox
+	(*Server_Erasure)(nil),         // 6: kratos.api.Server.Erasure
+	(*Server_Purge)(nil),           // 7: kratos.api.Server.Purge
+	(*Server_Auth)(nil),            // 8: kratos.api.Server.Auth
+	(*Server_Authz)(nil),           // 9: kratos.api.Server.Authz
+	(*Server_Tenancy)(nil),         // 10: kratos.api.Server.Tenancy
+	(*Server_Metrics)(nil),         // 11: kratos.api.Server.Metrics
+	(*Server_Tracing)(nil),         // 12: kratos.api.Server.Tracing
+	(*Server_AccessLog)(nil),       // 13: kratos.api.Server.AccessLog
+	(*Server_Health)(nil),          // 14: kratos.api.Server.Health
+	(*Server_RateLimit)(nil),       // 15: kratos.api.Server.RateLimit
+	(*Server_Authz_Policy)(nil),    // 16: kratos.api.Server.Authz.Policy
+	(*Server_RateLimit_Limit)(nil), // 17: kratos.api.Server.RateLimit.Limit
+	(*Data_Database)(nil),          // 18: kratos.api.Data.Database
+	(*Data_Redis)(nil),             // 19: kratos.api.Data.Redis
+	(*Data_Publisher)(nil),         // 20: kratos.api.Data.Publisher
+	(*Data_Encryption)(nil),        // 21: kratos.api.Data.Encryption
+	(*Data_Erasure)(nil),           // 22: kratos.api.Data.Erasure
+	(*Data_Retention)(nil),         // 23: kratos.api.Data.Retention
+	(*Data_Idempotency)(nil),       // 24: kratos.api.Data.Idempotency
+	(*Data_RateLimit)(nil),         // 25: kratos.api.Data.RateLimit
+	(*durationpb.Duration)(nil),    // 26: google.protobuf.Duration
 }
 var file_internal_conf_conf_proto_depIdxs = []int32{
-	1, // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	2, // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	3, // 2: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	4, // 3: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	5, // 4: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	6, // 5: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	6, // 6: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
-	6, // 7: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	3,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	1,  // 2: kratos.api.Bootstrap.log:type_name -> kratos.api.Log
+	4,  // 3: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	5,  // 4: kratos.api.Server.outbox:type_name -> kratos.api.Server.Outbox
+	8,  // 5: kratos.api.Server.auth:type_name -> kratos.api.Server.Auth
+	9,  // 6: kratos.api.Server.authz:type_name -> kratos.api.Server.Authz
+	10, // 7: kratos.api.Server.tenancy:type_name -> kratos.api.Server.Tenancy
+	6,  // 8: kratos.api.Server.erasure:type_name -> kratos.api.Server.Erasure
+	7,  // 9: kratos.api.Server.purge:type_name -> kratos.api.Server.Purge
+	11, // 10: kratos.api.Server.metrics:type_name -> kratos.api.Server.Metrics
+	12, // 11: kratos.api.Server.tracing:type_name -> kratos.api.Server.Tracing
+	13, // 12: kratos.api.Server.access_log:type_name -> kratos.api.Server.AccessLog
+	14, // 13: kratos.api.Server.health:type_name -> kratos.api.Server.Health
+	15, // 14: kratos.api.Server.rate_limit:type_name -> kratos.api.Server.RateLimit
+	18, // 15: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	19, // 16: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	20, // 17: kratos.api.Data.publisher:type_name -> kratos.api.Data.Publisher
+	21, // 18: kratos.api.Data.encryption:type_name -> kratos.api.Data.Encryption
+	22, // 19: kratos.api.Data.erasure:type_name -> kratos.api.Data.Erasure
+	23, // 20: kratos.api.Data.retention:type_name -> kratos.api.Data.Retention
+	24, // 21: kratos.api.Data.idempotency:type_name -> kratos.api.Data.Idempotency
+	25, // 22: kratos.api.Data.rate_limit:type_name -> kratos.api.Data.RateLimit
+	26, // 23: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	26, // 24: kratos.api.Server.Outbox.interval:type_name -> google.protobuf.Duration
+	26, // 25: kratos.api.Server.Outbox.lease_ttl:type_name -> google.protobuf.Duration
+	26, // 26: kratos.api.Server.Erasure.interval:type_name -> google.protobuf.Duration
+	26, // 27: kratos.api.Server.Purge.interval:type_name -> google.protobuf.Duration
+	26, // 28: kratos.api.Server.Purge.lease_ttl:type_name -> google.protobuf.Duration
+	16, // 29: kratos.api.Server.Authz.policies:type_name -> kratos.api.Server.Authz.Policy
+	26, // 30: kratos.api.Server.Health.interval:type_name -> google.protobuf.Duration
+	26, // 31: kratos.api.Server.Health.timeout:type_name -> google.protobuf.Duration
+	26, // 32: kratos.api.Server.Health.shutdown_delay:type_name -> google.protobuf.Duration
+	17, // 33: kratos.api.Server.RateLimit.default:type_name -> kratos.api.Server.RateLimit.Limit
+	17, // 34: kratos.api.Server.RateLimit.limits:type_name -> kratos.api.Server.RateLimit.Limit
+	26, // 35: kratos.api.Server.RateLimit.Limit.period:type_name -> google.protobuf.Duration
+	26, // 36: kratos.api.Data.Database.conn_max_lifetime:type_name -> google.protobuf.Duration
+	26, // 37: kratos.api.Data.Database.conn_max_idle_time:type_name -> google.protobuf.Duration
+	26, // 38: kratos.api.Data.Database.query_timeout:type_name -> google.protobuf.Duration
+	26, // 39: kratos.api.Data.Database.slow_query_threshold:type_name -> google.protobuf.Duration
+	26, // 40: kratos.api.Data.Database.read_your_writes:type_name -> google.protobuf.Duration
+	26, // 41: kratos.api.Data.Database.replica_check_interval:type_name -> google.protobuf.Duration
+	26, // 42: kratos.api.Data.Database.replica_max_lag:type_name -> google.protobuf.Duration
+	26, // 43: kratos.api.Data.Redis.read_timeout:type_name -> google.protobuf.Duration
+	26, // 44: kratos.api.Data.Redis.write_timeout:type_name -> google.protobuf.Duration
+	26, // 45: kratos.api.Data.Publisher.timeout:type_name -> google.protobuf.Duration
+	26, // 46: kratos.api.Data.Erasure.grace_period:type_name -> google.protobuf.Duration
+	26, // 47: kratos.api.Data.Retention.deleted_customers:type_name -> google.protobuf.Duration
+	26, // 48: kratos.api.Data.Retention.inactive_customers:type_name -> google.protobuf.Duration
+	26, // 49: kratos.api.Data.Retention.unverified_contacts:type_name -> google.protobuf.Duration
+	26, // 50: kratos.api.Data.Idempotency.ttl:type_name -> google.protobuf.Duration
+	51, // [51:51] is the sub-list for method output_type
+	51, // [51:51] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_internal_conf_conf_proto_init() }
@@ -426,7 +2084,7 @@ func file_internal_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_conf_conf_proto_rawDesc), len(file_internal_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
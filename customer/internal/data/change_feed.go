@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"customer/internal/biz"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// changeFeedLock is the postgres advisory lock held by the transactions
+// that write the change feed.
+const changeFeedLock = 0x63757374666565 // "custfee"
+
+// CustomerChange is an entry of the change feed. Rows are appended from
+// the outbox one transaction at a time, so sequences commit in order and
+// a watcher that has read up to a sequence never misses a smaller one.
+type CustomerChange struct {
+	Sequence   int64  `gorm:"primaryKey;autoIncrement"`
+	EventID    int64  `gorm:"uniqueIndex"`
+	TenantID   string `gorm:"index;not null;default:''"`
+	CustomerID int64  `gorm:"index"`
+	Type       int32
+	EventType  string
+	Actor      string
+	// Payload is the encrypted payload of the outbox event, KeyID the key
+	// it is encrypted with
+	Payload    []byte
+	KeyID      string `gorm:"not null;default:''"`
+	OccurredAt time.Time
+}
+
+type changeFeedRepo struct {
+	data *Data
+}
+
+func NewChangeFeedRepo(data *Data) biz.ChangeFeedRepo {
+	return &changeFeedRepo{data: data}
+}
+
+func (r *changeFeedRepo) AppendPending(ctx context.Context, limit int, classify func(eventType string) biz.ChangeType) (int, error) {
+	var appended int
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx)
+		if err := lockChangeFeed(db); err != nil {
+			return err
+		}
+
+		var events []OutboxEvent
+		if err := db.Where("appended_at IS NULL").Order("id").Limit(limit).Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		changes := make([]CustomerChange, 0, len(events))
+		ids := make([]int64, 0, len(events))
+		for _, e := range events {
+			changes = append(changes, CustomerChange{
+				EventID:    e.ID,
+				TenantID:   e.TenantID,
+				CustomerID: e.CustomerID,
+				Type:       int32(classify(e.Type)),
+				EventType:  e.Type,
+				Actor:      e.Actor,
+				Payload:    e.Payload,
+				KeyID:      e.KeyID,
+				OccurredAt: e.OccurredAt,
+			})
+			ids = append(ids, e.ID)
+		}
+		// an event appended before the outbox tracked it is in the feed already
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&changes).Error; err != nil {
+			return err
+		}
+		if err := db.Model(&OutboxEvent{}).Where("id IN ?", ids).Update("appended_at", time.Now()).Error; err != nil {
+			return err
+		}
+		appended = len(events)
+		return nil
+	})
+	return appended, err
+}
+
+// lockChangeFeed serializes the transactions writing the change feed until
+// the one of db ends. Sequences are drawn in commit order that way, where
+// concurrent inserts could commit a smaller sequence after a watcher read
+// past it. Other databases serialize writers anyway.
+func lockChangeFeed(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	return db.Exec("SELECT pg_advisory_xact_lock(?)", changeFeedLock).Error
+}
+
+func (r *changeFeedRepo) After(ctx context.Context, seq int64, customerIDs []int64, limit int) ([]*biz.Change, error) {
+	// watchers only ever see the changes of their own tenant
+	q := r.data.DB(ctx).Where("sequence > ? AND tenant_id = ?", seq, biz.TenantFromContext(ctx))
+	if len(customerIDs) > 0 {
+		q = q.Where("customer_id IN ?", customerIDs)
+	}
+
+	var models []CustomerChange
+	if err := q.Order("sequence").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]*biz.Change, 0, len(models))
+	for _, m := range models {
+		payload, err := r.data.fields.decryptBytes(ctx, m.KeyID, eventBinding(m.TenantID, m.CustomerID), "payload", m.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("change %d: %w", m.Sequence, err)
+		}
+		out = append(out, &biz.Change{
+			Sequence:   m.Sequence,
+			CustomerID: m.CustomerID,
+			Type:       biz.ChangeType(m.Type),
+			Event: &biz.Event{
+				ID:         m.EventID,
+				TenantID:   m.TenantID,
+				CustomerID: m.CustomerID,
+				Type:       m.EventType,
+				Actor:      m.Actor,
+				Payload:    payload,
+				OccurredAt: m.OccurredAt,
+			},
+		})
+	}
+	return out, nil
+}
+
+func (r *changeFeedRepo) Head(ctx context.Context) (int64, error) {
+	var head int64
+	err := r.data.DB(ctx).
+		Model(&CustomerChange{}).
+		Select("COALESCE(MAX(sequence), 0)").
+		Scan(&head).Error
+	return head, err
+}
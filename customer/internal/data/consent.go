@@ -0,0 +1,127 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"customer/internal/biz"
+)
+
+// CustomerConsent is one entry of the consent history. Contact may be
+// encrypted, so consents on the same contact are grouped by its blind
+// index; both are empty for consents on a whole channel.
+type CustomerConsent struct {
+	ID            int64  `gorm:"primaryKey"`
+	TenantID      string `gorm:"not null;default:'';index:idx_customer_consents_purpose"`
+	CustomerID    int64  `gorm:"index;index:idx_customer_consents_purpose"`
+	Channel       int32
+	Purpose       string `gorm:"index:idx_customer_consents_purpose"`
+	Status        int32
+	Contact       string
+	ContactHash   string
+	KeyID         string
+	Source        string
+	PolicyVersion string
+	RecordedBy    string
+	RecordedAt    time.Time
+}
+
+// the contact of a consent is bound to its customer like contact points
+func (m *CustomerConsent) binding() binding {
+	return rowBinding("customer_consents", m.TenantID, m.CustomerID)
+}
+
+func (m *CustomerConsent) encrypted() []field {
+	return []field{{"contact", &m.Contact}}
+}
+
+type consentRepo struct {
+	data *Data
+}
+
+func NewConsentRepo(data *Data) biz.ConsentRepo {
+	return &consentRepo{data: data}
+}
+
+func (r *consentRepo) Record(ctx context.Context, c *biz.Consent) error {
+	model := CustomerConsent{
+		TenantID:      biz.TenantFromContext(ctx),
+		CustomerID:    c.CustomerID,
+		Channel:       int32(c.Channel),
+		Purpose:       c.Purpose,
+		Status:        int32(c.Status),
+		Contact:       c.Contact,
+		Source:        c.Source,
+		PolicyVersion: c.PolicyVersion,
+		RecordedBy:    c.RecordedBy,
+		RecordedAt:    c.RecordedAt,
+	}
+	if c.Contact != "" {
+		model.ContactHash = r.data.fields.blindIndex("contact", c.Contact)
+		keyID, err := r.data.fields.encrypt(ctx, model.binding(), model.encrypted()...)
+		if err != nil {
+			return err
+		}
+		model.KeyID = keyID
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	c.ID = model.ID
+	return nil
+}
+
+func (r *consentRepo) List(ctx context.Context, customerIDs []int64) ([]*biz.Consent, error) {
+	var models []CustomerConsent
+	err := r.data.DB(ctx).
+		Scopes(tenantScope(ctx, "customer_consents")).
+		Where("customer_id IN ?", customerIDs).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*biz.Consent, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.Consent{
+			ID:            m.ID,
+			CustomerID:    m.CustomerID,
+			Channel:       biz.ConsentChannel(m.Channel),
+			Purpose:       m.Purpose,
+			Status:        biz.ConsentStatus(m.Status),
+			Contact:       m.Contact,
+			Source:        m.Source,
+			PolicyVersion: m.PolicyVersion,
+			RecordedBy:    m.RecordedBy,
+			RecordedAt:    m.RecordedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *consentRepo) Granted(ctx context.Context, purpose string, channel biz.ConsentChannel, afterCustomerID int64, limit int) ([]int64, error) {
+	db := r.data.DB(ctx)
+
+	// the latest consent on every channel and contact is the one in force
+	latest := db.Model(&CustomerConsent{}).
+		Select("MAX(id)").
+		Scopes(tenantScope(ctx, "customer_consents")).
+		Where("purpose = ? AND customer_id > ?", purpose, afterCustomerID).
+		Group("customer_id, channel, contact_hash")
+	if channel != biz.ConsentChannelUnspecified {
+		latest = latest.Where("channel = ?", int32(channel))
+	}
+
+	var ids []int64
+	err := db.Model(&CustomerConsent{}).
+		Distinct("customer_id").
+		Where("id IN (?) AND status = ?", latest, int32(biz.ConsentGranted)).
+		Order("customer_id").
+		Limit(limit).
+		Pluck("customer_id", &ids).Error
+	return ids, err
+}
@@ -3,46 +3,130 @@ package data
 import (
 	"context"
 	"customer/internal/biz"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //  GORM models 
 type Customer struct {
 	ID          int64  `gorm:"primaryKey"`
+	TenantID    string `gorm:"index;not null;default:''"`
 	Name        string
 	DateOfBirth string
+	// KeyID names the key Name and DateOfBirth are encrypted with, empty
+	// for plain text
+	KeyID       string `gorm:"not null;default:''"`
+	// Status is a biz.CustomerStatus; customers from before statuses were
+	// tracked are active
+	Status          int32  `gorm:"not null;default:2;index"`
+	StatusReason    string `gorm:"not null;default:''"`
+	StatusChangedAt *time.Time
+	// CreatedAt and UpdatedAt are NULL for customers from before they
+	// were tracked, retention leaves those alone
+	CreatedAt   time.Time
+	UpdatedAt   time.Time `gorm:"index"`
+	// LastActivityAt is when the last event of the customer was recorded.
+	// Unlike UpdatedAt it also moves with its contact points and consents.
+	LastActivityAt *time.Time `gorm:"index"`
+	// DeleteCustomer only hides the customer, the retention purge removes
+	// it for good
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 	Emails      []Email
 	PhoneNumbers []PhoneNumber
 	Addresses    []Address
 }
 
 
+// emails and phone numbers are unique within a tenant. The values may be
+// encrypted, so uniqueness and exact lookups go through their blind
+// index hashes.
 type Email   struct {
 	ID         int64  `gorm:"primaryKey"`
+	TenantID   string `gorm:"not null;default:'';uniqueIndex:idx_emails_tenant_email_hash"`
 	CustomerID int64  `gorm:"index"`
-	Email      string `gorm:"uniqueIndex"`
+	Email      string
+	EmailHash  string `gorm:"uniqueIndex:idx_emails_tenant_email_hash"`
+	KeyID      string `gorm:"not null;default:''"`
+	CreatedAt  time.Time `gorm:"index"`
+	// VerifiedAt is set once the customer proved to own the address
+	VerifiedAt *time.Time
 }
 
 type PhoneNumber struct {
 	ID         int64  `gorm:"primaryKey"`
+	TenantID   string `gorm:"not null;default:'';uniqueIndex:idx_phone_numbers_tenant_phone_number_hash"`
 	CustomerID int64  `gorm:"index"`
-	PhoneNumber      string `gorm:"uniqueIndex"`
+	PhoneNumber      string
+	PhoneNumberHash  string `gorm:"uniqueIndex:idx_phone_numbers_tenant_phone_number_hash"`
+	KeyID      string `gorm:"not null;default:''"`
+	CreatedAt  time.Time `gorm:"index"`
+	// VerifiedAt is set once the customer proved to own the number
+	VerifiedAt *time.Time
 }
 
 type Address  struct {
 	ID         int64  `gorm:"primaryKey"`
+	TenantID   string `gorm:"index;not null;default:''"`
 	CustomerID int64  `gorm:"index"`
 	Address    string
+	AddressHash string `gorm:"index"`
+	KeyID      string `gorm:"not null;default:''"`
+}
+
+// The encrypted values of a customer are bound to its row, those of its
+// contact points to the customer, see binding.
+
+func (m *Customer) binding() binding {
+	return rowBinding("customers", m.TenantID, m.ID)
+}
+
+func (m *Customer) encrypted() []field {
+	return []field{{"name", &m.Name}, {"date_of_birth", &m.DateOfBirth}}
+}
+
+func (m *Email) binding() binding {
+	return rowBinding("emails", m.TenantID, m.CustomerID)
+}
+
+func (m *Email) encrypted() []field {
+	return []field{{"email", &m.Email}}
+}
+
+func (m *PhoneNumber) binding() binding {
+	return rowBinding("phone_numbers", m.TenantID, m.CustomerID)
+}
+
+func (m *PhoneNumber) encrypted() []field {
+	return []field{{"phone_number", &m.PhoneNumber}}
+}
+
+func (m *Address) binding() binding {
+	return rowBinding("addresses", m.TenantID, m.CustomerID)
+}
+
+func (m *Address) encrypted() []field {
+	return []field{{"address", &m.Address}}
 }
 
 //  Repo 
 
 type customerRepo struct {
-	db *gorm.DB
+	data *Data
 }
 
 func NewCustomerRepo(data *Data) biz.CustomerRepo {
-	return &customerRepo{db: data.db}
+	return &customerRepo{data: data}
+}
+
+// tenantScope limits a query on table to the tenant of ctx. Every query
+// of the customer tables goes through it.
+func tenantScope(ctx context.Context, table string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".tenant_id = ?", biz.TenantFromContext(ctx))
+	}
 }
 
 
@@ -50,196 +134,585 @@ func NewCustomerRepo(data *Data) biz.CustomerRepo {
 //  customer 
 func (r *customerRepo) CreateCustomer(ctx context.Context, c *biz.Customer) error {
 	model := Customer{
+		TenantID:    biz.TenantFromContext(ctx),
 		Name:        c.Name,
 		DateOfBirth: c.DateOfBirth,
+		Status:      int32(c.Status),
 	}
-	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
-		return err
-	}
-	c.ID = model.ID
-	return nil
+	// the values are bound to the id the insert assigns, so they are
+	// encrypted and written after it
+	model.Name, model.DateOfBirth = "", ""
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+			return err
+		}
+		c.ID = model.ID
+		return r.UpdateCustomer(ctx, c)
+	})
 }
 
 func (r *customerRepo) UpdateCustomer(ctx context.Context, c *biz.Customer) error {
-	return r.db.WithContext(ctx).
+	m := Customer{ID: c.ID, TenantID: biz.TenantFromContext(ctx), Name: c.Name, DateOfBirth: c.DateOfBirth}
+	keyID, err := r.data.fields.encrypt(ctx, m.binding(), m.encrypted()...)
+	if err != nil {
+		return err
+	}
+	res := r.data.DB(ctx).
 		Model(&Customer{}).
+		Scopes(tenantScope(ctx, "customers")).
 		Where("id = ?", c.ID).
 		Updates(map[string]interface{}{
-			"name":          c.Name,
-			"date_of_birth": c.DateOfBirth,
-		}).Error
+			"name":          m.Name,
+			"date_of_birth": m.DateOfBirth,
+			"key_id":        keyID,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	// customers of other tenants are out of scope and look like missing ones
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 func (r *customerRepo) DeleteCustomer(ctx context.Context, id int64) error {
-	return r.db.WithContext(ctx).Delete(&Customer{}, id).Error
+	res := r.data.DB(ctx).Scopes(tenantScope(ctx, "customers")).Delete(&Customer{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *customerRepo) TouchCustomer(ctx context.Context, id int64, at time.Time) error {
+	return r.data.DB(ctx).
+		Model(&Customer{}).
+		Scopes(tenantScope(ctx, "customers")).
+		Where("id = ?", id).
+		UpdateColumn("last_activity_at", at).Error
+}
+
+func (r *customerRepo) LockCustomer(ctx context.Context, id int64) (*biz.Customer, error) {
+	db := r.data.DB(ctx).Scopes(tenantScope(ctx, "customers"))
+	// other databases lock the whole database for a write transaction
+	if r.data.db.Dialector.Name() == "postgres" {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var m Customer
+	if err := db.First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+		return nil, err
+	}
+
+	return toBizStatus(&biz.Customer{
+		ID:          m.ID,
+		Name:        m.Name,
+		DateOfBirth: m.DateOfBirth,
+	}, &m), nil
 }
 
 func (r *customerRepo) GetCustomer(ctx context.Context, id int64) (*biz.Customer, error) {
 	var m Customer
-	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+	if err := r.data.Reader(ctx).Scopes(tenantScope(ctx, "customers")).First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
 		return nil, err
 	}
 
-	return &biz.Customer{
+	return toBizStatus(&biz.Customer{
 		ID:          m.ID,
 		Name:        m.Name,
 		DateOfBirth: m.DateOfBirth,
-	}, nil
+	}, &m), nil
+}
+
+// toBizStatus copies the status of m to c.
+func toBizStatus(c *biz.Customer, m *Customer) *biz.Customer {
+	c.Status = biz.CustomerStatus(m.Status)
+	c.StatusReason = m.StatusReason
+	if m.StatusChangedAt != nil {
+		c.StatusChangedAt = *m.StatusChangedAt
+	}
+	return c
+}
+
+func (r *customerRepo) SetCustomerStatus(ctx context.Context, c *biz.Customer, from biz.CustomerStatus) (bool, error) {
+	res := r.data.DB(ctx).
+		Model(&Customer{}).
+		Scopes(tenantScope(ctx, "customers")).
+		Where("id = ? AND status = ?", c.ID, int32(from)).
+		Updates(map[string]interface{}{
+			"status":            int32(c.Status),
+			"status_reason":     c.StatusReason,
+			"status_changed_at": c.StatusChangedAt,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+// GetCustomersByKeys loads the customers matching any of keys in one
+// query, contact points preloaded, and hands them out by key.
+func (r *customerRepo) GetCustomersByKeys(ctx context.Context, keys []*biz.CustomerKey) ([]*biz.Customer, error) {
+	var (
+		ids                      []int64
+		emailHashes, phoneHashes []string
+	)
+	for _, k := range keys {
+		switch {
+		case k.ID != 0:
+			ids = append(ids, k.ID)
+		case k.Email != "":
+			emailHashes = append(emailHashes, r.data.fields.blindIndex("email", k.Email))
+		case k.PhoneNumber != "":
+			phoneHashes = append(phoneHashes, r.data.fields.blindIndex("phone_number", k.PhoneNumber))
+		}
+	}
+	out := make([]*biz.Customer, len(keys))
+
+	var (
+		conds []string
+		args  []interface{}
+	)
+	if len(ids) > 0 {
+		conds = append(conds, "customers.id IN ?")
+		args = append(args, ids)
+	}
+	if len(emailHashes) > 0 {
+		conds = append(conds, "customers.id IN (SELECT emails.customer_id FROM emails WHERE emails.tenant_id = customers.tenant_id AND emails.email_hash IN ?)")
+		args = append(args, emailHashes)
+	}
+	if len(phoneHashes) > 0 {
+		conds = append(conds, "customers.id IN (SELECT phone_numbers.customer_id FROM phone_numbers WHERE phone_numbers.tenant_id = customers.tenant_id AND phone_numbers.phone_number_hash IN ?)")
+		args = append(args, phoneHashes)
+	}
+	if len(conds) == 0 {
+		return out, nil
+	}
+
+	var models []Customer
+	err := r.data.Reader(ctx).
+		Scopes(tenantScope(ctx, "customers")).
+		Where("("+strings.Join(conds, " OR ")+")", args...).
+		Preload("Emails").
+		Preload("PhoneNumbers").
+		Preload("Addresses").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*biz.Customer, len(models))
+	// email and phone number hashes are salted with their column, they
+	// cannot collide
+	byHash := make(map[string]*biz.Customer)
+	for i := range models {
+		m := &models[i]
+		c, err := r.toBizCustomer(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		byID[c.ID] = c
+		for _, e := range m.Emails {
+			byHash[e.EmailHash] = c
+		}
+		for _, p := range m.PhoneNumbers {
+			byHash[p.PhoneNumberHash] = c
+		}
+	}
+	for i, k := range keys {
+		switch {
+		case k.ID != 0:
+			out[i] = byID[k.ID]
+		case k.Email != "":
+			out[i] = byHash[r.data.fields.blindIndex("email", k.Email)]
+		case k.PhoneNumber != "":
+			out[i] = byHash[r.data.fields.blindIndex("phone_number", k.PhoneNumber)]
+		}
+	}
+	return out, nil
 }
 
 
 func (r *customerRepo) ListCustomer(ctx context.Context) ([]*biz.Customer, error) {
     var models []Customer
-    err := r.db.WithContext(ctx).
-        Preload("Emails").
-        Preload("PhoneNumbers").
-        Preload("Addresses").
+    err := r.data.Reader(ctx).
+        Scopes(tenantScope(ctx, "customers")).
         Find(&models).Error
     if err != nil {
         return nil, err
     }
 
     out := make([]*biz.Customer, 0, len(models))
-    for _, m := range models {
-        out = append(out, &biz.Customer{
-            ID:           m.ID,
-            Name:         m.Name,
-            DateOfBirth:  m.DateOfBirth,
-            // Emails:       toBizEmails(m.Emails),
-            // PhoneNumbers: toBizPhones(m.PhoneNumbers),
-            // Addresses:    toBizAddresses(m.Addresses),
-        })
+    for i := range models {
+        c, err := r.toBizCustomer(ctx, &models[i])
+        if err != nil {
+            return nil, err
+        }
+        out = append(out, c)
     }
     return out, nil
 }
 
+// IterateCustomers pages through the matching customers in id order,
+// keyed on the last id seen, so only one batch is held in memory.
+func (r *customerRepo) IterateCustomers(ctx context.Context, filter *biz.CustomerFilter, batchSize int, fn func([]*biz.Customer) error) error {
+	var lastID int64
+	for {
+		var models []Customer
+		err := r.data.Reader(ctx).
+			Scopes(tenantScope(ctx, "customers"), r.customerFilter(filter)).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(batchSize).
+			Preload("Emails").
+			Preload("PhoneNumbers").
+			Preload("Addresses").
+			Find(&models).Error
+		if err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		out := make([]*biz.Customer, 0, len(models))
+		for i := range models {
+			c, err := r.toBizCustomer(ctx, &models[i])
+			if err != nil {
+				return err
+			}
+			if matchesFilter(filter, c) {
+				out = append(out, c)
+			}
+		}
+		if len(out) > 0 {
+			if err := fn(out); err != nil {
+				return err
+			}
+		}
+		if len(models) < batchSize {
+			return nil
+		}
+		lastID = models[len(models)-1].ID
+	}
+}
+
+// customerFilter narrows a customers query to filter. A nil filter
+// matches every customer. Encrypted names and dates cannot be compared in
+// SQL, matchesFilter checks them once the rows are decrypted.
+func (r *customerRepo) customerFilter(f *biz.CustomerFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if f == nil {
+			return db
+		}
+		if len(f.IDs) > 0 {
+			db = db.Where("customers.id IN ?", f.IDs)
+		}
+		if len(f.Statuses) > 0 {
+			statuses := make([]int32, len(f.Statuses))
+			for i, st := range f.Statuses {
+				statuses[i] = int32(st)
+			}
+			db = db.Where("customers.status IN ?", statuses)
+		}
+		if r.data.fields.enabled() {
+			return db
+		}
+		if f.Name != "" {
+			db = db.Where("LOWER(customers.name) LIKE ?", "%"+strings.ToLower(f.Name)+"%")
+		}
+		// dates of birth are stored as YYYY-MM-DD, so they compare as strings
+		if f.BornAfter != "" {
+			db = db.Where("customers.date_of_birth >= ?", f.BornAfter)
+		}
+		if f.BornBefore != "" {
+			db = db.Where("customers.date_of_birth <= ?", f.BornBefore)
+		}
+		return db
+	}
+}
+
+// matchesFilter reports whether the decrypted customer c matches the
+// name and date parts of f.
+func matchesFilter(f *biz.CustomerFilter, c *biz.Customer) bool {
+	if f == nil {
+		return true
+	}
+	if f.Name != "" && !strings.Contains(strings.ToLower(c.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.BornAfter != "" && c.DateOfBirth < f.BornAfter {
+		return false
+	}
+	if f.BornBefore != "" && c.DateOfBirth > f.BornBefore {
+		return false
+	}
+	return true
+}
+
+// toBizCustomer decrypts m and the contact points loaded with it.
+func (r *customerRepo) toBizCustomer(ctx context.Context, m *Customer) (*biz.Customer, error) {
+	if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+		return nil, err
+	}
+	c := toBizStatus(&biz.Customer{ID: m.ID, Name: m.Name, DateOfBirth: m.DateOfBirth}, m)
+	var err error
+	if c.Emails, err = r.toBizEmails(ctx, m.Emails); err != nil {
+		return nil, err
+	}
+	if c.PhoneNumbers, err = r.toBizPhones(ctx, m.PhoneNumbers); err != nil {
+		return nil, err
+	}
+	if c.Addresses, err = r.toBizAddresses(ctx, m.Addresses); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *customerRepo) toBizEmails(ctx context.Context, models []Email) ([]*biz.Email, error) {
+	out := make([]*biz.Email, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.Email{ID: m.ID, CustomerID: m.CustomerID, Email: m.Email})
+	}
+	return out, nil
+}
+
+func (r *customerRepo) toBizPhones(ctx context.Context, models []PhoneNumber) ([]*biz.PhoneNumber, error) {
+	out := make([]*biz.PhoneNumber, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.PhoneNumber{ID: m.ID, CustomerID: m.CustomerID, PhoneNumber: m.PhoneNumber})
+	}
+	return out, nil
+}
+
+func (r *customerRepo) toBizAddresses(ctx context.Context, models []Address) ([]*biz.Address, error) {
+	out := make([]*biz.Address, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.Address{ID: m.ID, CustomerID: m.CustomerID, Address: m.Address})
+	}
+	return out, nil
+}
+
 
 // email 
 
 func (r *customerRepo) AddEmail(ctx context.Context, e *biz.Email) error {
-	return r.db.WithContext(ctx).Create(&Email{
+	model := Email{
+		TenantID:   biz.TenantFromContext(ctx),
 		CustomerID: e.CustomerID,
 		Email:      e.Email,
-	}).Error
+		EmailHash:  r.data.fields.blindIndex("email", e.Email),
+	}
+	var err error
+	if model.KeyID, err = r.data.fields.encrypt(ctx, model.binding(), model.encrypted()...); err != nil {
+		return err
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	e.ID = model.ID
+	return nil
 }
 
 func (r *customerRepo) DeleteEmail(ctx context.Context, customerID int64, email string) error {
-	return r.db.WithContext(ctx).
-		Where("customer_id = ? AND email = ?", customerID, email).
+	return r.data.DB(ctx).
+		Scopes(tenantScope(ctx, "emails")).
+		Where("customer_id = ? AND email_hash = ?", customerID, r.data.fields.blindIndex("email", email)).
 		Delete(&Email{}).Error
 }
 
-func (r *customerRepo) ListEmails(ctx context.Context, customerID int64) ([]string, error) {  // duplicate issue
-	var emails []string
-	err := r.db.WithContext(ctx).
+func (r *customerRepo) VerifyEmail(ctx context.Context, customerID int64, email string, at time.Time) error {
+	res := r.data.DB(ctx).
 		Model(&Email{}).
+		Scopes(tenantScope(ctx, "emails")).
+		Where("customer_id = ? AND email_hash = ?", customerID, r.data.fields.blindIndex("email", email)).
+		Update("verified_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *customerRepo) ListEmails(ctx context.Context, customerID int64) ([]string, error) {  // duplicate issue
+	var models []Email
+	err := r.data.Reader(ctx).
+		Scopes(tenantScope(ctx, "emails")).
 		Where("customer_id = ?", customerID).
-		Pluck("email", &emails).Error //to select a single column and get in a slice!!!
-	return emails, err
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	emails := make([]string, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		emails = append(emails, m.Email)
+	}
+	return emails, nil
 }
 
 func (r *customerRepo) GetCustomerByEmail(ctx context.Context, email string) (*biz.Customer, error) {
     var c Customer
-    err := r.db.WithContext(ctx).
+    err := r.data.Reader(ctx).
         Preload("Emails").
         Preload("PhoneNumbers").
         Preload("Addresses").
-        Joins("JOIN emails ON emails.customer_id = customers.id").
-        Where("emails.email = ?", email).
+        Scopes(tenantScope(ctx, "customers")).
+        Joins("JOIN emails ON emails.customer_id = customers.id AND emails.tenant_id = customers.tenant_id").
+        Where("emails.email_hash = ?", r.data.fields.blindIndex("email", email)).
         First(&c).Error
     if err != nil {
         return nil, err
     }
 
-    return &biz.Customer{
-        ID:           c.ID,
-        Name:         c.Name,
-        DateOfBirth:  c.DateOfBirth,
-        // Emails:       toBizEmails(c.Emails),
-        // PhoneNumbers: toBizPhones(c.PhoneNumbers),
-        // Addresses:    toBizAddresses(c.Addresses),
-    }, nil
+    return r.toBizCustomer(ctx, &c)
 }
 
 
 // phone 
 func (r *customerRepo) AddPhoneNumber(ctx context.Context, p *biz.PhoneNumber) error {
-	return r.db.WithContext(ctx).Create(&PhoneNumber{
-		CustomerID: p.CustomerID,
-		PhoneNumber:      p.PhoneNumber,
-	}).Error
+	model := PhoneNumber{
+		TenantID:    biz.TenantFromContext(ctx),
+		CustomerID:  p.CustomerID,
+		PhoneNumber: p.PhoneNumber,
+		PhoneNumberHash: r.data.fields.blindIndex("phone_number", p.PhoneNumber),
+	}
+	var err error
+	if model.KeyID, err = r.data.fields.encrypt(ctx, model.binding(), model.encrypted()...); err != nil {
+		return err
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	p.ID = model.ID
+	return nil
 }
 
 func (r *customerRepo) DeletePhoneNumber(ctx context.Context, customerID int64, phone string) error {
-	return r.db.WithContext(ctx).
-		Where("customer_id = ? AND phone_number = ?", customerID, phone).
+	return r.data.DB(ctx).
+		Scopes(tenantScope(ctx, "phone_numbers")).
+		Where("customer_id = ? AND phone_number_hash = ?", customerID, r.data.fields.blindIndex("phone_number", phone)).
 		Delete(&PhoneNumber{}).Error
 }
 
-func (r *customerRepo) ListPhoneNumbers(ctx context.Context, customerID int64) ([]string, error) {
-	var phones []string
-	err := r.db.WithContext(ctx).
+func (r *customerRepo) VerifyPhoneNumber(ctx context.Context, customerID int64, phone string, at time.Time) error {
+	res := r.data.DB(ctx).
 		Model(&PhoneNumber{}).
+		Scopes(tenantScope(ctx, "phone_numbers")).
+		Where("customer_id = ? AND phone_number_hash = ?", customerID, r.data.fields.blindIndex("phone_number", phone)).
+		Update("verified_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *customerRepo) ListPhoneNumbers(ctx context.Context, customerID int64) ([]string, error) {
+	var models []PhoneNumber
+	err := r.data.Reader(ctx).
+		Scopes(tenantScope(ctx, "phone_numbers")).
 		Where("customer_id = ?", customerID).
-		Pluck("phone_number", &phones).Error
-	return phones, err
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	phones := make([]string, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		phones = append(phones, m.PhoneNumber)
+	}
+	return phones, nil
 }
 
 func (r *customerRepo) GetCustomerByPhoneNumber(ctx context.Context, phone string) (*biz.Customer, error) {
     var c Customer
-    err := r.db.WithContext(ctx).
+    err := r.data.Reader(ctx).
         Preload("Emails").
         Preload("PhoneNumbers").
         Preload("Addresses").
-        Joins("JOIN phone_numbers ON phone_numbers.customer_id = customers.id").
-        Where("phone_numbers.phone_number = ?", phone).
+        Scopes(tenantScope(ctx, "customers")).
+        Joins("JOIN phone_numbers ON phone_numbers.customer_id = customers.id AND phone_numbers.tenant_id = customers.tenant_id").
+        Where("phone_numbers.phone_number_hash = ?", r.data.fields.blindIndex("phone_number", phone)).
         First(&c).Error
     if err != nil {
         return nil, err
     }
 
-    return &biz.Customer{
-        ID:           c.ID,
-        Name:         c.Name,
-        DateOfBirth:  c.DateOfBirth,
-        // Emails:       toBizEmails(c.Emails),
-        // PhoneNumbers: toBizPhones(c.PhoneNumbers),
-        // Addresses:    toBizAddresses(c.Addresses),
-    }, nil
+    return r.toBizCustomer(ctx, &c)
 }
 
 
 // address 
 func (r *customerRepo) AddAddress(ctx context.Context, a *biz.Address) error {
-	return r.db.WithContext(ctx).Create(&Address{
+	model := Address{
+		TenantID:   biz.TenantFromContext(ctx),
 		CustomerID: a.CustomerID,
 		Address:    a.Address,
-	}).Error
+		AddressHash: r.data.fields.blindIndex("address", a.Address),
+	}
+	var err error
+	if model.KeyID, err = r.data.fields.encrypt(ctx, model.binding(), model.encrypted()...); err != nil {
+		return err
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	a.ID = model.ID
+	return nil
 }
 
 func (r *customerRepo) DeleteAddress(ctx context.Context, customerID int64, address string) error {
-	return r.db.WithContext(ctx).
-		Where("customer_id = ? AND address = ?", customerID, address).
+	return r.data.DB(ctx).
+		Scopes(tenantScope(ctx, "addresses")).
+		Where("customer_id = ? AND address_hash = ?", customerID, r.data.fields.blindIndex("address", address)).
 		Delete(&Address{}).Error
 }
 
 func (r *customerRepo) ListAddresses(ctx context.Context, customerID int64) ([]string, error) {
-	var addresses []string
-	err := r.db.WithContext(ctx).
-		Model(&Address{}).
+	var models []Address
+	err := r.data.Reader(ctx).
+		Scopes(tenantScope(ctx, "addresses")).
 		Where("customer_id = ?", customerID).
-		Pluck("address", &addresses).Error
-	return addresses, err
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, m.Address)
+	}
+	return addresses, nil
 }
 
 
-// transaction helper:
+// transaction helper: repositories called with the ctx handed to fn
+// share the transaction, see Data.InTx.
 func (r *customerRepo) Tx(ctx context.Context, fn func(ctx context.Context) error) error {
-    return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-        // temporarily replace the repo's DB with the transaction DB
-        old := r.db
-        r.db = tx
-        defer func() { r.db = old }()
-        return fn(ctx)
-    })
+    return r.data.InTx(ctx, fn)
 }
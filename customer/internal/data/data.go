@@ -1,30 +1,71 @@
 package data
 
 import (
+	"context"
+	stdlog "log"
+	"os"
 	"customer/internal/conf"
 	"gorm.io/driver/postgres"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewCustomerRepo)
+var ProviderSet = wire.NewSet(NewData, NewCustomerRepo, NewOutboxRepo, NewEventPublisher, NewChangeFeedRepo, NewMergeRepo, NewSearchRepo, NewDataExportRepo, NewErasureRepo, NewRetentionRepo, NewLeaseRepo, NewConsentRepo, NewHealthRepo, NewIdempotencyRepo, NewRateLimitRepo)
 
-// Data 
+// Data
 type Data struct {
-	db *gorm.DB
+	db       *gorm.DB
+	fields   *fieldCipher
+	replicas *replicaSet
+	// rdb is nil unless redis is configured
+	rdb *redis.Client
 }
 
-// NewData 
+// NewData
 func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
     log := log.NewHelper(logger)
 
     // connect to PostgreSQL
-    db, err := gorm.Open(postgres.Open(c.Database.Source), &gorm.Config{})
+    db, err := gorm.Open(postgres.Open(c.Database.Source), &gorm.Config{
+        // slow statements are logged by registerQueryLimits instead, and
+        // none with their values
+        Logger: gormlogger.New(stdlog.New(os.Stdout, "\r\n", stdlog.LstdFlags), gormlogger.Config{
+            LogLevel:                  gormlogger.Warn,
+            IgnoreRecordNotFoundError: true,
+            ParameterizedQueries:      true,
+            Colorful:                  true,
+        }),
+    })
     if err != nil {
         return nil, nil, err
     }
+    if err := configurePool(db, c.Database); err != nil {
+        return nil, nil, err
+    }
+    if err := registerQueryLimits(db, c.Database, logger); err != nil {
+        return nil, nil, err
+    }
+    if err := registerMetrics(db); err != nil {
+        return nil, nil, err
+    }
+    if err := registerTracing(db); err != nil {
+        return nil, nil, err
+    }
+
+    keys, err := NewKeyProvider(c)
+    if err != nil {
+        return nil, nil, err
+    }
+    fields := newFieldCipher(keys)
+
+    // the blind indexes are unique, existing rows need theirs first
+    if err := backfillBlindIndexes(db, fields); err != nil {
+        return nil, nil, err
+    }
 
     // auto-migrate models
     if err := db.AutoMigrate(
@@ -32,16 +73,127 @@ func NewData(c *conf.Data, logger log.Logger) (*Data, func(), error) {
         &Email{},
         &PhoneNumber{},
         &Address{},
+        &OutboxEvent{},
+        &CustomerChange{},
+        &CustomerMerge{},
+        &CustomerDataExport{},
+        &CustomerErasure{},
+        &JobLease{},
+        &CustomerConsent{},
+        &IdempotencyKey{},
+        &IdempotencyKeyCustomer{},
+        &BlindIndexScheme{},
+        &CiphertextScheme{},
     ); err != nil {
         return nil, nil, err
     }
 
+    // plain text rows keep the hash of the scheme they were written with
+    if err := rehashBlindIndexes(db, fields); err != nil {
+        return nil, nil, err
+    }
+
+    // events used to be appended to the change feed when published
+    if err := db.Model(&OutboxEvent{}).
+        Where("appended_at IS NULL AND published_at IS NOT NULL").
+        Update("appended_at", gorm.Expr("published_at")).Error; err != nil {
+        return nil, nil, err
+    }
+
+    // emails and phone numbers used to be unique across tenants, then
+    // unique on their plain text values
+    for _, old := range []struct {
+        model interface{}
+        index string
+    }{
+        {&Email{}, "idx_emails_email"},
+        {&PhoneNumber{}, "idx_phone_numbers_phone_number"},
+        {&Email{}, "idx_emails_tenant_email"},
+        {&PhoneNumber{}, "idx_phone_numbers_tenant_phone_number"},
+    } {
+        if db.Migrator().HasIndex(old.model, old.index) {
+            if err := db.Migrator().DropIndex(old.model, old.index); err != nil {
+                return nil, nil, err
+            }
+        }
+    }
+
+    replicas, err := newReplicaSet(c.Database, logger)
+    if err != nil {
+        return nil, nil, err
+    }
+    if replicas != nil {
+        if err := registerReadYourWrites(db, replicas); err != nil {
+            replicas.close()
+            return nil, nil, err
+        }
+    }
+
+    var rdb *redis.Client
+    if r := c.Redis; r != nil && r.Addr != "" {
+        rdb = redis.NewClient(&redis.Options{
+            Network:      r.Network,
+            Addr:         r.Addr,
+            ReadTimeout:  r.ReadTimeout.AsDuration(),
+            WriteTimeout: r.WriteTimeout.AsDuration(),
+        })
+    }
+
     cleanup := func() {
         log.Info("closing the data resources")
+        if rdb != nil {
+            rdb.Close()
+        }
+        if replicas != nil {
+            replicas.close()
+        }
         sqlDB, _ := db.DB()
         sqlDB.Close()
     }
 
-    return &Data{db: db}, cleanup, nil
+    d := &Data{db: db, fields: fields, replicas: replicas, rdb: rdb}
+
+    // values encrypted before they were bound to their rows
+    if err := bindCiphertexts(context.Background(), d); err != nil {
+        cleanup()
+        return nil, nil, err
+    }
+
+    return d, cleanup, nil
+}
+
+type contextTxKey struct{}
+
+// DB returns the transaction carried by ctx, or the shared handle when
+// the call is not part of a transaction.
+func (d *Data) DB(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(contextTxKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return d.db.WithContext(ctx)
 }
 
+// Reader returns a handle for queries that can do with data a little
+// behind: a healthy replica, unless ctx carries a transaction, the caller
+// wrote within the read-your-writes window or no replica is up. Writes
+// always go through DB.
+func (d *Data) Reader(ctx context.Context) *gorm.DB {
+	db := d.DB(ctx)
+	if _, inTx := ctx.Value(contextTxKey{}).(*gorm.DB); inTx || d.replicas == nil || d.replicas.pinned(ctx) {
+		return db
+	}
+	if r := d.replicas.pick(); r != nil {
+		// db is a session of its own, the replica stays with it
+		db.Statement.ConnPool = r.db
+	}
+	return db
+}
+
+// InTx runs fn in a transaction. Repositories called with the ctx passed
+// to fn share that transaction. A nested call runs in a savepoint of the
+// outer transaction, so its failure only undoes its own changes.
+func (d *Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return d.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, contextTxKey{}, tx))
+	})
+}
@@ -0,0 +1,42 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"customer/internal/biz"
+)
+
+// CustomerDataExport records a subject access bundle delivered for a
+// customer, by its checksum.
+type CustomerDataExport struct {
+	ID         int64  `gorm:"primaryKey"`
+	TenantID   string `gorm:"index;not null;default:''"`
+	CustomerID int64  `gorm:"index"`
+	Checksum   string
+	Actor      string
+	ExportedAt time.Time
+}
+
+type dataExportRepo struct {
+	data *Data
+}
+
+func NewDataExportRepo(data *Data) biz.DataExportRepo {
+	return &dataExportRepo{data: data}
+}
+
+func (r *dataExportRepo) Record(ctx context.Context, e *biz.DataExport) error {
+	model := CustomerDataExport{
+		TenantID:   biz.TenantFromContext(ctx),
+		CustomerID: e.CustomerID,
+		Checksum:   e.Checksum,
+		Actor:      e.Actor,
+		ExportedAt: e.ExportedAt,
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	e.ID = model.ID
+	return nil
+}
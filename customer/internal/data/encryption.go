@@ -0,0 +1,275 @@
+package data
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"customer/internal/conf"
+)
+
+// KeyProvider hands out the keys personal data is encrypted with. Every
+// encrypted row stores the id of its key, so retired keys must stay
+// available until the rows using them are rotated.
+type KeyProvider interface {
+	// CurrentKeyID names the key new values are encrypted with.
+	CurrentKeyID() string
+	// Key returns the AES key with the given id.
+	Key(ctx context.Context, id string) ([]byte, error)
+	// IndexKey returns the HMAC key of the blind indexes. Changing it
+	// breaks exact lookups until every row is rotated.
+	IndexKey() []byte
+}
+
+// NewKeyProvider returns the provider selected by c.Encryption.Kind, nil
+// when encryption is not configured.
+func NewKeyProvider(c *conf.Data) (KeyProvider, error) {
+	e := c.GetEncryption()
+	if e == nil {
+		return nil, nil
+	}
+	switch e.GetKind() {
+	case "", "file":
+		if e.GetKeyFile() == "" {
+			return nil, fmt.Errorf("encryption: key_file is required for the file provider")
+		}
+		return loadFileKeys(e.GetKeyFile())
+	default:
+		return nil, fmt.Errorf("encryption: unknown kind %q", e.GetKind())
+	}
+}
+
+// fileKeys is the key ring of a JSON file, meant for development:
+//
+//	{"current": "k2", "keys": {"k1": "<base64>", "k2": "<base64>"}, "index_key": "<base64>"}
+type fileKeys struct {
+	current  string
+	keys     map[string][]byte
+	indexKey []byte
+}
+
+func loadFileKeys(path string) (*fileKeys, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: read key file: %w", err)
+	}
+	var ring struct {
+		Current  string            `json:"current"`
+		Keys     map[string]string `json:"keys"`
+		IndexKey string            `json:"index_key"`
+	}
+	if err := json.Unmarshal(b, &ring); err != nil {
+		return nil, fmt.Errorf("encryption: %s: %w", path, err)
+	}
+
+	k := &fileKeys{current: ring.Current, keys: make(map[string][]byte, len(ring.Keys))}
+	for id, s := range ring.Keys {
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: key %q: %w", id, err)
+		}
+		if n := len(key); n != 16 && n != 24 && n != 32 {
+			return nil, fmt.Errorf("encryption: key %q: must be 16, 24 or 32 bytes, got %d", id, n)
+		}
+		k.keys[id] = key
+	}
+	if _, ok := k.keys[k.current]; !ok {
+		return nil, fmt.Errorf("encryption: current key %q is not in the key file", k.current)
+	}
+	if k.indexKey, err = base64.StdEncoding.DecodeString(ring.IndexKey); err != nil {
+		return nil, fmt.Errorf("encryption: index_key: %w", err)
+	}
+	if len(k.indexKey) < 16 {
+		return nil, fmt.Errorf("encryption: index_key must be at least 16 bytes")
+	}
+	return k, nil
+}
+
+func (k *fileKeys) CurrentKeyID() string { return k.current }
+
+func (k *fileKeys) Key(_ context.Context, id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("encryption: unknown key %q", id)
+	}
+	return key, nil
+}
+
+func (k *fileKeys) IndexKey() []byte { return k.indexKey }
+
+// fieldCipher encrypts column values with AES-GCM and derives the blind
+// indexes exact lookups use. Without a key provider values are kept in
+// plain text and indexed with an unkeyed hash. Rows with an empty key id
+// hold plain text either way, which is how rows written before
+// encryption was enabled are read until they are rotated.
+type fieldCipher struct {
+	keys KeyProvider
+	// legacy also opens values sealed before they were bound to their
+	// row, only bindCiphertexts reads those
+	legacy bool
+
+	mu    sync.Mutex
+	aeads map[string]cipher.AEAD
+}
+
+func newFieldCipher(keys KeyProvider) *fieldCipher {
+	return &fieldCipher{keys: keys, aeads: map[string]cipher.AEAD{}}
+}
+
+// binding is where encrypted values are stored. Ciphertexts are sealed
+// with it as additional data, so a value copied into another column, row
+// or tenant fails to decrypt instead of being read as its own.
+type binding struct {
+	table, tenant, owner string
+}
+
+// rowBinding binds values to the row of table with the given id, or to
+// the rows of the customer with that id for tables of contact points.
+func rowBinding(table, tenant string, id int64) binding {
+	return binding{table: table, tenant: tenant, owner: strconv.FormatInt(id, 10)}
+}
+
+func (b binding) aad(column string) []byte {
+	return []byte(b.table + "." + column + "\x00" + b.tenant + "\x00" + b.owner)
+}
+
+// field is a column value encrypted or decrypted in place.
+type field struct {
+	column string
+	value  *string
+}
+
+func (f *fieldCipher) enabled() bool {
+	return f.keys != nil
+}
+
+func (f *fieldCipher) aead(ctx context.Context, id string) (cipher.AEAD, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if a, ok := f.aeads[id]; ok {
+		return a, nil
+	}
+	key, err := f.keys.Key(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	a, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	f.aeads[id] = a
+	return a, nil
+}
+
+// encrypt replaces values with their ciphertext under the current key,
+// bound to b and their column, and returns the id of the key, the empty
+// id when encryption is off. Empty values stay empty.
+func (f *fieldCipher) encrypt(ctx context.Context, b binding, values ...field) (string, error) {
+	if !f.enabled() {
+		return "", nil
+	}
+	id := f.keys.CurrentKeyID()
+	a, err := f.aead(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range values {
+		if *v.value == "" {
+			continue
+		}
+		nonce := make([]byte, a.NonceSize(), a.NonceSize()+len(*v.value)+a.Overhead())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return "", err
+		}
+		*v.value = base64.StdEncoding.EncodeToString(a.Seal(nonce, nonce, []byte(*v.value), b.aad(v.column)))
+	}
+	return id, nil
+}
+
+// decrypt replaces values encrypted under the key keyID and bound to b
+// with their plain text.
+func (f *fieldCipher) decrypt(ctx context.Context, keyID string, b binding, values ...field) error {
+	if keyID == "" {
+		return nil
+	}
+	if !f.enabled() {
+		return fmt.Errorf("encryption: value encrypted with key %q but no key provider is configured", keyID)
+	}
+	a, err := f.aead(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if *v.value == "" {
+			continue
+		}
+		sealed, err := base64.StdEncoding.DecodeString(*v.value)
+		if err != nil {
+			return fmt.Errorf("encryption: malformed %s: %w", v.column, err)
+		}
+		if len(sealed) < a.NonceSize() {
+			return fmt.Errorf("encryption: malformed %s: too short", v.column)
+		}
+		nonce, ciphertext := sealed[:a.NonceSize()], sealed[a.NonceSize():]
+		plain, err := a.Open(nil, nonce, ciphertext, b.aad(v.column))
+		if err != nil && f.legacy {
+			plain, err = a.Open(nil, nonce, ciphertext, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("encryption: decrypt %s with key %q: %w", v.column, keyID, err)
+		}
+		*v.value = string(plain)
+	}
+	return nil
+}
+
+// encryptBytes is encrypt for a binary column.
+func (f *fieldCipher) encryptBytes(ctx context.Context, b binding, column string, p []byte) ([]byte, string, error) {
+	v := string(p)
+	keyID, err := f.encrypt(ctx, b, field{column, &v})
+	if err != nil {
+		return nil, "", err
+	}
+	return []byte(v), keyID, nil
+}
+
+// decryptBytes is decrypt for a binary column.
+func (f *fieldCipher) decryptBytes(ctx context.Context, keyID string, b binding, column string, p []byte) ([]byte, error) {
+	if keyID == "" {
+		return p, nil
+	}
+	v := string(p)
+	if err := f.decrypt(ctx, keyID, b, field{column, &v}); err != nil {
+		return nil, err
+	}
+	return []byte(v), nil
+}
+
+// blindIndex is the lookup hash of a value of column.
+func (f *fieldCipher) blindIndex(column, value string) string {
+	var h []byte
+	if f.enabled() {
+		m := hmac.New(sha256.New, f.keys.IndexKey())
+		m.Write([]byte(column + ":" + value))
+		h = m.Sum(nil)
+	} else {
+		s := sha256.Sum256([]byte(column + ":" + value))
+		h = s[:]
+	}
+	return hex.EncodeToString(h)
+}
@@ -0,0 +1,355 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+)
+
+// testKeys is a key ring whose current key tests switch.
+type testKeys struct {
+	current string
+}
+
+func (k *testKeys) CurrentKeyID() string { return k.current }
+
+func (k *testKeys) Key(_ context.Context, id string) ([]byte, error) {
+	if id != "k1" && id != "k2" {
+		return nil, fmt.Errorf("unknown key %q", id)
+	}
+	return bytes.Repeat([]byte(id[1:]), 32), nil
+}
+
+func (k *testKeys) IndexKey() []byte { return []byte("0123456789abcdef") }
+
+// newEncryptedTestData is newTestData with encryption under the key k1.
+func newEncryptedTestData(t *testing.T) (*Data, *testKeys) {
+	t.Helper()
+	d := newTestData(t)
+	keys := &testKeys{current: "k1"}
+	d.fields = newFieldCipher(keys)
+	return d, keys
+}
+
+// assertSealed fails if a personal value of the fixture is stored in
+// plain text or under another key than keyID.
+func assertSealed(t *testing.T, d *Data, keyID string) {
+	t.Helper()
+	for _, c := range []struct {
+		table, column, plain string
+	}{
+		{"customers", "name", "Jane Acme"},
+		{"emails", "email", fixtureEmail},
+		{"phone_numbers", "phone_number", fixturePhone},
+		{"addresses", "address", fixtureAddress},
+		{"outbox_events", "payload", fixtureEmail},
+	} {
+		var rows []struct {
+			Value string
+			KeyID string
+		}
+		if err := d.db.Table(c.table).Select(c.column + " AS value, key_id").Scan(&rows).Error; err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) == 0 {
+			t.Fatalf("no %s", c.table)
+		}
+		for _, row := range rows {
+			if strings.Contains(row.Value, c.plain) {
+				t.Errorf("%s.%s stored in plain text: %q", c.table, c.column, row.Value)
+			}
+			if row.KeyID != keyID {
+				t.Errorf("%s row encrypted with key %q, want %q", c.table, row.KeyID, keyID)
+			}
+		}
+	}
+}
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	d, _ := newEncryptedTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+	assertSealed(t, d, "k1")
+
+	c, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Jane Acme" || c.DateOfBirth != "1990-01-01" {
+		t.Errorf("customer = %q, %q", c.Name, c.DateOfBirth)
+	}
+	emails, err := s.ListEmail(f.acme, &pb.ListEmailReq{CustomerId: f.acmeID})
+	if err != nil || len(emails.Emails) != 1 || emails.Emails[0] != fixtureEmail {
+		t.Errorf("emails = %v, %v", emails, err)
+	}
+	addresses, err := s.ListAddress(f.acme, &pb.ListAddressReq{CustomerId: f.acmeID})
+	if err != nil || len(addresses.Addresses) != 1 || addresses.Addresses[0] != fixtureAddress {
+		t.Errorf("addresses = %v, %v", addresses, err)
+	}
+
+	if _, err := biz.NewChangeFeed(NewChangeFeedRepo(d)).Sync(f.acme, 100); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := NewChangeFeedRepo(d).After(f.acme, 0, nil, 100)
+	if err != nil || len(changes) == 0 {
+		t.Fatalf("changes = %v, %v", changes, err)
+	}
+	for _, ch := range changes {
+		if ch.Event.Type == "api.customer.v1.EmailAdded" && !bytes.Contains(ch.Event.Payload, []byte(fixtureEmail)) {
+			t.Errorf("change %d holds %q", ch.Sequence, ch.Event.Payload)
+		}
+	}
+}
+
+func TestEncryptionBlindIndexLookup(t *testing.T) {
+	d, _ := newEncryptedTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+
+	for _, c := range []struct {
+		ctx context.Context
+		id  int64
+	}{
+		{f.acme, f.acmeID},
+		{f.globex, f.globexID},
+	} {
+		byEmail, err := s.GetCustomerByEmail(c.ctx, &pb.GetCustomerByEmailReq{Email: fixtureEmail})
+		if err != nil || byEmail.Id != c.id {
+			t.Errorf("by email = %v, %v, want customer %d", byEmail, err, c.id)
+		}
+		byPhone, err := s.GetCustomerByPhoneNumber(c.ctx, &pb.GetCustomerByPhoneNumberReq{PhoneNumber: fixturePhone})
+		if err != nil || byPhone.Id != c.id {
+			t.Errorf("by phone number = %v, %v, want customer %d", byPhone, err, c.id)
+		}
+	}
+	// the hash is keyed, not the one of an unencrypted store
+	var hash string
+	if err := d.db.Table("emails").Where("tenant_id = ?", "acme").Pluck("email_hash", &hash).Error; err != nil {
+		t.Fatal(err)
+	}
+	if hash != d.fields.blindIndex("email", fixtureEmail) || hash == (&fieldCipher{}).blindIndex("email", fixtureEmail) {
+		t.Errorf("email_hash = %s, not the keyed blind index", hash)
+	}
+	if _, err := s.AddEmail(f.acme, &pb.AddEmailReq{CustomerId: f.acmeID, Email: fixtureEmail}); err == nil {
+		t.Error("AddEmail added an email the tenant already has")
+	}
+}
+
+func TestEncryptionBinding(t *testing.T) {
+	d, _ := newEncryptedTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+
+	// values copied into the row of another customer, or another column,
+	// do not decrypt
+	var name, dob string
+	if err := d.db.Table("customers").Select("name").Where("id = ?", f.acmeID).Row().Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.db.Table("customers").Select("date_of_birth").Where("id = ?", f.acmeID).Row().Scan(&dob); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.db.Table("customers").Where("id = ?", f.globexID).Update("name", name).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetCustomer(f.globex, &pb.GetCustomerReq{Id: f.globexID}); err == nil {
+		t.Error("the name of another customer decrypted")
+	}
+	if err := d.db.Table("customers").Where("id = ?", f.acmeID).Update("name", dob).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID}); err == nil {
+		t.Error("the date of birth decrypted as the name")
+	}
+}
+
+func TestEncryptionMergeReseals(t *testing.T) {
+	d, _ := newEncryptedTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+
+	dup, err := s.CreateCustomerWithDetails(f.acme, &pb.CreateCustomerWithDetailsReq{
+		Name:        "J. Acme",
+		DateOfBirth: "1990-01-01",
+		Email:       "j@example.com",
+		PhoneNumber: "+4915187654321",
+		Address:     "Side Street 2, Berlin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged, err := s.MergeCustomers(f.acme, &pb.MergeCustomersReq{SurvivorId: f.acmeID, DuplicateIds: []int64{dup.Id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Customer.Emails) != 2 || len(merged.Customer.PhoneNumbers) != 2 || len(merged.Customer.Addresses) != 2 {
+		t.Errorf("survivor = %v, want the contact points of both", merged.Customer)
+	}
+	snapshots, err := NewMergeRepo(d).Merged(f.acme, f.acmeID)
+	if err != nil || len(snapshots) != 1 || snapshots[0].Name != "J. Acme" || snapshots[0].Emails[0] != "j@example.com" {
+		t.Errorf("snapshots = %v, %v", snapshots, err)
+	}
+}
+
+func TestRotateKeys(t *testing.T) {
+	d, keys := newEncryptedTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+	if _, err := biz.NewChangeFeed(NewChangeFeedRepo(d)).Sync(f.acme, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	keys.current = "k2"
+	rotated := map[string]int{}
+	err := d.RotateKeys(context.Background(), 1, func(table string, n int) {
+		rotated[table] += n
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for table, want := range map[string]int{"customers": 2, "emails": 2, "phone_numbers": 2, "addresses": 2} {
+		if rotated[table] != want {
+			t.Errorf("%d %s rotated, want %d", rotated[table], table, want)
+		}
+	}
+	if rotated["outbox_events"] == 0 || rotated["outbox_events"] != rotated["customer_changes"] {
+		t.Errorf("%d events and %d changes rotated", rotated["outbox_events"], rotated["customer_changes"])
+	}
+	assertSealed(t, d, "k2")
+
+	// k1 is retired: every value reads, and looks up, with k2 alone
+	d.fields = newFieldCipher(&onlyKey{testKeys: keys, id: "k2"})
+	s = newTestService(d)
+	c, err := s.GetCustomerByEmail(f.acme, &pb.GetCustomerByEmailReq{Email: fixtureEmail})
+	if err != nil || c.Id != f.acmeID || c.Name != "Jane Acme" {
+		t.Errorf("by email = %v, %v", c, err)
+	}
+	if _, err := NewChangeFeedRepo(d).After(f.acme, 0, nil, 100); err != nil {
+		t.Error(err)
+	}
+
+	// nothing is left to rotate
+	rotated = map[string]int{}
+	if err := d.RotateKeys(context.Background(), 100, func(table string, n int) { rotated[table] += n }); err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 0 {
+		t.Errorf("second rotation rotated %v", rotated)
+	}
+}
+
+// onlyKey is a key ring that lost every key but one.
+type onlyKey struct {
+	*testKeys
+	id string
+}
+
+func (k *onlyKey) Key(ctx context.Context, id string) ([]byte, error) {
+	if id != k.id {
+		return nil, fmt.Errorf("key %q is retired", id)
+	}
+	return k.testKeys.Key(ctx, id)
+}
+
+func TestRehashBlindIndexes(t *testing.T) {
+	// the rows are written before encryption is enabled
+	d := newTestData(t)
+	f := newTenantFixture(t, newTestService(d))
+	if err := rehashBlindIndexes(d.db, d.fields); err != nil {
+		t.Fatal(err)
+	}
+
+	d.fields = newFieldCipher(&testKeys{current: "k1"})
+	s := newTestService(d)
+	if _, err := s.GetCustomerByEmail(f.acme, &pb.GetCustomerByEmailReq{Email: fixtureEmail}); err == nil {
+		t.Fatal("the unkeyed hash matched the keyed one")
+	}
+	if err := rehashBlindIndexes(d.db, d.fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		ctx context.Context
+		id  int64
+	}{
+		{f.acme, f.acmeID},
+		{f.globex, f.globexID},
+	} {
+		byEmail, err := s.GetCustomerByEmail(c.ctx, &pb.GetCustomerByEmailReq{Email: fixtureEmail})
+		if err != nil || byEmail.Id != c.id {
+			t.Errorf("by email = %v, %v, want customer %d", byEmail, err, c.id)
+		}
+		byPhone, err := s.GetCustomerByPhoneNumber(c.ctx, &pb.GetCustomerByPhoneNumberReq{PhoneNumber: fixturePhone})
+		if err != nil || byPhone.Id != c.id {
+			t.Errorf("by phone number = %v, %v, want customer %d", byPhone, err, c.id)
+		}
+	}
+	if _, err := s.AddEmail(f.acme, &pb.AddEmailReq{CustomerId: f.acmeID, Email: fixtureEmail}); err == nil {
+		t.Error("AddEmail added an email the tenant already has")
+	}
+
+	// the scheme is recorded, rows changed since are left alone
+	if err := d.db.Table("emails").Where("customer_id = ?", f.acmeID).Update("email_hash", "stale").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := rehashBlindIndexes(d.db, d.fields); err != nil {
+		t.Fatal(err)
+	}
+	var hash string
+	if err := d.db.Table("emails").Where("customer_id = ?", f.acmeID).Pluck("email_hash", &hash).Error; err != nil {
+		t.Fatal(err)
+	}
+	if hash != "stale" {
+		t.Errorf("the second run rehashed the rows again")
+	}
+}
+
+func TestBindCiphertexts(t *testing.T) {
+	d, _ := newEncryptedTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+
+	// rows sealed the way they were before ciphertexts were bound
+	a, err := d.fields.aead(context.Background(), "k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seal := func(v string) string {
+		nonce := make([]byte, a.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			t.Fatal(err)
+		}
+		return base64.StdEncoding.EncodeToString(a.Seal(nonce, nonce, []byte(v), nil))
+	}
+	if err := d.db.Table("customers").Where("id = ?", f.acmeID).Update("name", seal("Jane Acme")).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := d.db.Table("emails").Where("customer_id = ?", f.acmeID).Update("email", seal(fixtureEmail)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID}); err == nil {
+		t.Fatal("an unbound value decrypted")
+	}
+
+	if err := bindCiphertexts(context.Background(), d); err != nil {
+		t.Fatal(err)
+	}
+	c, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID})
+	if err != nil || c.Name != "Jane Acme" {
+		t.Errorf("customer = %v, %v", c, err)
+	}
+	emails, err := s.ListEmail(f.acme, &pb.ListEmailReq{CustomerId: f.acmeID})
+	if err != nil || len(emails.Emails) != 1 || emails.Emails[0] != fixtureEmail {
+		t.Errorf("emails = %v, %v", emails, err)
+	}
+	var scheme CiphertextScheme
+	if err := d.db.First(&scheme).Error; err != nil || !scheme.Bound {
+		t.Errorf("scheme = %v, %v, want bound", scheme, err)
+	}
+}
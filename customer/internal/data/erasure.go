@@ -0,0 +1,321 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"customer/internal/biz"
+
+	"gorm.io/gorm"
+)
+
+// CustomerErasure is an erasure request and, once completed, its entry in
+// the hash chain of erasures. PrevHash is unique so two erasures cannot be
+// chained to the same predecessor; it is NULL until completion.
+type CustomerErasure struct {
+	ID          int64  `gorm:"primaryKey"`
+	TenantID    string `gorm:"index;not null;default:''"`
+	CustomerID  int64  `gorm:"index"`
+	Status      int32  `gorm:"index"`
+	Reason      string
+	RequestedBy string
+	RequestedAt time.Time
+	EraseAfter  time.Time `gorm:"index"`
+	CompletedAt *time.Time
+	CancelledAt *time.Time
+	PrevHash    *string `gorm:"uniqueIndex"`
+	Hash        string
+}
+
+type erasureRepo struct {
+	data *Data
+}
+
+func NewErasureRepo(data *Data) biz.ErasureRepo {
+	return &erasureRepo{data: data}
+}
+
+func (r *erasureRepo) Create(ctx context.Context, e *biz.Erasure) error {
+	model := CustomerErasure{
+		TenantID:    e.TenantID,
+		CustomerID:  e.CustomerID,
+		Status:      int32(e.Status),
+		Reason:      e.Reason,
+		RequestedBy: e.RequestedBy,
+		RequestedAt: e.RequestedAt,
+		EraseAfter:  e.EraseAfter,
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	e.ID = model.ID
+	return nil
+}
+
+func (r *erasureRepo) Get(ctx context.Context, id int64) (*biz.Erasure, error) {
+	var m CustomerErasure
+	if err := r.data.DB(ctx).Scopes(tenantScope(ctx, "customer_erasures")).First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return toBizErasure(&m), nil
+}
+
+func (r *erasureRepo) Active(ctx context.Context, customerID int64) (*biz.Erasure, error) {
+	var m CustomerErasure
+	err := r.data.DB(ctx).
+		Scopes(tenantScope(ctx, "customer_erasures")).
+		Where("customer_id = ? AND status IN ?", customerID, []int32{int32(biz.ErasureScheduled), int32(biz.ErasureCompleted)}).
+		Order("id DESC").
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toBizErasure(&m), nil
+}
+
+func (r *erasureRepo) Cancel(ctx context.Context, e *biz.Erasure) error {
+	res := r.data.DB(ctx).
+		Model(&CustomerErasure{}).
+		Scopes(tenantScope(ctx, "customer_erasures")).
+		Where("id = ? AND status = ?", e.ID, int32(biz.ErasureScheduled)).
+		Updates(map[string]interface{}{
+			"status":       int32(e.Status),
+			"cancelled_at": e.CancelledAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrErasureNotScheduled
+	}
+	return nil
+}
+
+func (r *erasureRepo) Due(ctx context.Context, now time.Time, limit int) ([]*biz.Erasure, error) {
+	var models []CustomerErasure
+	err := r.data.DB(ctx).
+		Where("status = ? AND erase_after <= ?", int32(biz.ErasureScheduled), now).
+		Order("erase_after, id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*biz.Erasure, 0, len(models))
+	for i := range models {
+		out = append(out, toBizErasure(&models[i]))
+	}
+	return out, nil
+}
+
+func (r *erasureRepo) LastHash(ctx context.Context) (string, error) {
+	var m CustomerErasure
+	err := r.data.DB(ctx).
+		Where("status = ?", int32(biz.ErasureCompleted)).
+		Order("completed_at DESC, id DESC").
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	return m.Hash, err
+}
+
+func (r *erasureRepo) Complete(ctx context.Context, e *biz.Erasure) error {
+	res := r.data.DB(ctx).
+		Model(&CustomerErasure{}).
+		Where("id = ? AND status = ?", e.ID, int32(biz.ErasureScheduled)).
+		Updates(map[string]interface{}{
+			"status":       int32(e.Status),
+			"completed_at": e.CompletedAt,
+			"prev_hash":    e.PrevHash,
+			"hash":         e.Hash,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrErasureNotScheduled
+	}
+	return nil
+}
+
+func toBizErasure(m *CustomerErasure) *biz.Erasure {
+	e := &biz.Erasure{
+		ID:          m.ID,
+		TenantID:    m.TenantID,
+		CustomerID:  m.CustomerID,
+		Status:      biz.ErasureStatus(m.Status),
+		Reason:      m.Reason,
+		RequestedBy: m.RequestedBy,
+		RequestedAt: m.RequestedAt,
+		EraseAfter:  m.EraseAfter,
+		Hash:        m.Hash,
+	}
+	if m.CompletedAt != nil {
+		e.CompletedAt = *m.CompletedAt
+	}
+	if m.CancelledAt != nil {
+		e.CancelledAt = *m.CancelledAt
+	}
+	if m.PrevHash != nil {
+		e.PrevHash = *m.PrevHash
+	}
+	return e
+}
+
+// erasureToken is the random stand-in for an erased value. It is not
+// derived from the value, so nothing can be recovered from it.
+func erasureToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "erased:" + hex.EncodeToString(b), nil
+}
+
+func (r *erasureRepo) Anonymize(ctx context.Context, customerIDs []int64) error {
+	db := r.data.DB(ctx)
+	fields := r.data.fields
+
+	// merged customers are soft-deleted and keep their own copy of the
+	// name; the customer may have been deleted meanwhile too
+	var customers []int64
+	if err := db.Unscoped().Model(&Customer{}).Scopes(tenantScope(ctx, "customers")).Where("id IN ?", customerIDs).Pluck("id", &customers).Error; err != nil {
+		return err
+	}
+	for _, id := range customers {
+		token, err := erasureToken()
+		if err != nil {
+			return err
+		}
+		err = db.Unscoped().Model(&Customer{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{"name": token, "date_of_birth": "", "key_id": ""}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	// every contact point gets its own token, which also frees the
+	// unique blind indexes of emails and phone numbers
+	for _, t := range []struct {
+		model         interface{}
+		table, column string
+	}{
+		{&Email{}, "emails", "email"},
+		{&PhoneNumber{}, "phone_numbers", "phone_number"},
+		{&Address{}, "addresses", "address"},
+	} {
+		var ids []int64
+		if err := db.Model(t.model).Scopes(tenantScope(ctx, t.table)).Where("customer_id IN ?", customerIDs).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			token, err := erasureToken()
+			if err != nil {
+				return err
+			}
+			err = db.Model(t.model).Where("id = ?", id).Updates(map[string]interface{}{
+				t.column:           token,
+				t.column + "_hash": fields.blindIndex(t.column, token),
+				"key_id":           "",
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var consents []int64
+	if err := db.Model(&CustomerConsent{}).Scopes(tenantScope(ctx, "customer_consents")).Where("customer_id IN ? AND contact <> ''", customerIDs).Pluck("id", &consents).Error; err != nil {
+		return err
+	}
+	for _, id := range consents {
+		token, err := erasureToken()
+		if err != nil {
+			return err
+		}
+		err = db.Model(&CustomerConsent{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"contact":      token,
+			"contact_hash": fields.blindIndex("contact", token),
+			"key_id":       "",
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	// the snapshots of customers merged into this one are the same person
+	var merges []int64
+	if err := db.Model(&CustomerMerge{}).Scopes(tenantScope(ctx, "customer_merges")).Where("survivor_id IN ?", customerIDs).Pluck("id", &merges).Error; err != nil {
+		return err
+	}
+	for _, id := range merges {
+		token, err := erasureToken()
+		if err != nil {
+			return err
+		}
+		err = db.Model(&CustomerMerge{ID: id}).
+			Select("name", "date_of_birth", "emails", "phone_numbers", "addresses", "key_id").
+			Updates(&CustomerMerge{Name: token}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *erasureRepo) ScrubEvents(ctx context.Context, customerIDs []int64, scrub func(eventType string, payload []byte) []byte) error {
+	db := r.data.DB(ctx)
+	// keeps events from being appended to the feed between the two scrubs
+	if err := lockChangeFeed(db); err != nil {
+		return err
+	}
+
+	var events []OutboxEvent
+	if err := db.Where("tenant_id = ? AND customer_id IN ?", biz.TenantFromContext(ctx), customerIDs).Find(&events).Error; err != nil {
+		return err
+	}
+	for _, e := range events {
+		payload, keyID, err := r.scrubPayload(ctx, eventBinding(e.TenantID, e.CustomerID), e.Type, e.Payload, e.KeyID, scrub)
+		if err != nil {
+			return err
+		}
+		err = db.Model(&OutboxEvent{}).Where("id = ?", e.ID).Updates(map[string]interface{}{"payload": payload, "key_id": keyID}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	var changes []CustomerChange
+	if err := db.Where("tenant_id = ? AND customer_id IN ?", biz.TenantFromContext(ctx), customerIDs).Find(&changes).Error; err != nil {
+		return err
+	}
+	for _, c := range changes {
+		payload, keyID, err := r.scrubPayload(ctx, eventBinding(c.TenantID, c.CustomerID), c.EventType, c.Payload, c.KeyID, scrub)
+		if err != nil {
+			return err
+		}
+		err = db.Model(&CustomerChange{}).Where("sequence = ?", c.Sequence).Updates(map[string]interface{}{"payload": payload, "key_id": keyID}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrubPayload scrubs an event payload encrypted under keyID and bound to
+// b, and encrypts the result again.
+func (r *erasureRepo) scrubPayload(ctx context.Context, b binding, eventType string, sealed []byte, keyID string, scrub func(eventType string, payload []byte) []byte) ([]byte, string, error) {
+	payload, err := r.data.fields.decryptBytes(ctx, keyID, b, "payload", sealed)
+	if err != nil {
+		return nil, "", err
+	}
+	return r.data.fields.encryptBytes(ctx, b, "payload", scrub(eventType, payload))
+}
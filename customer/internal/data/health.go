@@ -0,0 +1,30 @@
+package data
+
+import (
+	"context"
+
+	"customer/internal/biz"
+)
+
+type healthRepo struct {
+	data *Data
+}
+
+func NewHealthRepo(data *Data) biz.HealthRepo {
+	return &healthRepo{data: data}
+}
+
+func (r *healthRepo) PingDatabase(ctx context.Context) error {
+	sqlDB, err := r.data.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (r *healthRepo) PingRedis(ctx context.Context) error {
+	if r.data.rdb == nil {
+		return nil
+	}
+	return r.data.rdb.Ping(ctx).Err()
+}
@@ -0,0 +1,374 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm/clause"
+)
+
+// NewIdempotencyRepo returns the store selected by c.Idempotency.Backend.
+func NewIdempotencyRepo(data *Data, c *conf.Data) (biz.IdempotencyRepo, error) {
+	switch backend := c.GetIdempotency().GetBackend(); backend {
+	case "", "postgres":
+		return &idempotencyRepo{data: data}, nil
+	case "redis":
+		if data.rdb == nil {
+			return nil, errors.New("idempotency: the redis backend needs redis to be configured")
+		}
+		return &redisIdempotencyRepo{rdb: data.rdb, fields: data.fields}, nil
+	default:
+		return nil, fmt.Errorf("idempotency: unknown backend %q", backend)
+	}
+}
+
+// IdempotencyKey is the record kept under an idempotency key, already
+// scoped to its caller.
+type IdempotencyKey struct {
+	Key         string `gorm:"primaryKey"`
+	Fingerprint string
+	// Response is NULL while the request runs. Replies hold personal data,
+	// so it is encrypted like a column value.
+	Response []byte
+	// KeyID names the key Response is encrypted with
+	KeyID     string    `gorm:"not null;default:''"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+// IdempotencyKeyCustomer links a record to a customer its response is
+// about, so erasing the customer removes the record.
+type IdempotencyKeyCustomer struct {
+	Key        string `gorm:"primaryKey"`
+	CustomerID int64  `gorm:"primaryKey;index"`
+}
+
+// responseBinding binds a stored response to its key, which is already
+// scoped to the caller.
+func responseBinding(key string) binding {
+	return binding{table: "idempotency_keys", owner: key}
+}
+
+// sealResponse encrypts the response stored under key and returns the id
+// of the encryption key, empty when encryption is off.
+func sealResponse(ctx context.Context, fields *fieldCipher, key string, response []byte) ([]byte, string, error) {
+	return fields.encryptBytes(ctx, responseBinding(key), "response", response)
+}
+
+// openResponse decrypts a response sealed under keyID.
+func openResponse(ctx context.Context, fields *fieldCipher, key string, sealed []byte, keyID string) ([]byte, error) {
+	if keyID == "" {
+		// never nil, that would read as still running
+		return append([]byte{}, sealed...), nil
+	}
+	return fields.decryptBytes(ctx, keyID, responseBinding(key), "response", sealed)
+}
+
+type idempotencyRepo struct {
+	data *Data
+}
+
+func (r *idempotencyRepo) Claim(ctx context.Context, key, fingerprint string, ttl time.Duration) (*biz.IdempotencyRecord, error) {
+	now := time.Now()
+	// take over an expired record, or create the first one
+	res := r.data.DB(ctx).
+		Model(&IdempotencyKey{}).
+		Where("key = ? AND expires_at < ?", key, now).
+		Updates(map[string]interface{}{"fingerprint": fingerprint, "response": nil, "key_id": "", "expires_at": now.Add(ttl)})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil, r.data.DB(ctx).Where("key = ?", key).Delete(&IdempotencyKeyCustomer{}).Error
+	}
+	res = r.data.DB(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&IdempotencyKey{Key: key, Fingerprint: fingerprint, ExpiresAt: now.Add(ttl)})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	// drivers may read an empty response back as nil
+	var m struct {
+		IdempotencyKey
+		Completed bool
+	}
+	err := r.data.DB(ctx).
+		Model(&IdempotencyKey{}).
+		Select("*, response IS NOT NULL AS completed").
+		Where("key = ?", key).
+		Take(&m).Error
+	if err != nil {
+		return nil, err
+	}
+	rec := &biz.IdempotencyRecord{Fingerprint: m.Fingerprint}
+	if m.Completed {
+		var err error
+		if rec.Response, err = openResponse(ctx, r.data.fields, key, m.Response, m.KeyID); err != nil {
+			return nil, err
+		}
+	}
+	return rec, nil
+}
+
+func (r *idempotencyRepo) Complete(ctx context.Context, key string, rec *biz.IdempotencyRecord, ttl time.Duration) error {
+	// NULL would read as still running
+	response, keyID, err := sealResponse(ctx, r.data.fields, key, append([]byte{}, rec.Response...))
+	if err != nil {
+		return err
+	}
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		res := r.data.DB(ctx).
+			Model(&IdempotencyKey{}).
+			Where("key = ? AND fingerprint = ? AND response IS NULL", key, rec.Fingerprint).
+			Updates(map[string]interface{}{"response": response, "key_id": keyID, "expires_at": time.Now().Add(ttl)})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errIdempotencyClaimLost
+		}
+		if len(rec.CustomerIDs) == 0 {
+			return nil
+		}
+		links := make([]IdempotencyKeyCustomer, 0, len(rec.CustomerIDs))
+		for _, id := range rec.CustomerIDs {
+			links = append(links, IdempotencyKeyCustomer{Key: key, CustomerID: id})
+		}
+		return r.data.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+}
+
+func (r *idempotencyRepo) Renew(ctx context.Context, key, fingerprint string, ttl time.Duration) error {
+	return r.data.DB(ctx).
+		Model(&IdempotencyKey{}).
+		Where("key = ? AND fingerprint = ? AND response IS NULL", key, fingerprint).
+		Update("expires_at", time.Now().Add(ttl)).Error
+}
+
+func (r *idempotencyRepo) Release(ctx context.Context, key string) error {
+	return r.data.DB(ctx).Where("key = ? AND response IS NULL", key).Delete(&IdempotencyKey{}).Error
+}
+
+func (r *idempotencyRepo) DeleteExpired(ctx context.Context, limit int) (int, error) {
+	var keys []string
+	err := r.data.DB(ctx).
+		Model(&IdempotencyKey{}).
+		Where("expires_at < ?", time.Now()).
+		Limit(limit).
+		Pluck("key", &keys).Error
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+	return len(keys), r.deleteKeys(ctx, keys)
+}
+
+func (r *idempotencyRepo) Forget(ctx context.Context, customerIDs []int64) error {
+	var keys []string
+	err := r.data.DB(ctx).
+		Model(&IdempotencyKeyCustomer{}).
+		Where("customer_id IN ?", customerIDs).
+		Distinct().
+		Pluck("key", &keys).Error
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	return r.deleteKeys(ctx, keys)
+}
+
+// deleteKeys removes the records with their customer links.
+func (r *idempotencyRepo) deleteKeys(ctx context.Context, keys []string) error {
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		if err := r.data.DB(ctx).Where("key IN ?", keys).Delete(&IdempotencyKeyCustomer{}).Error; err != nil {
+			return err
+		}
+		return r.data.DB(ctx).Where("key IN ?", keys).Delete(&IdempotencyKey{}).Error
+	})
+}
+
+// redisIdempotencyKeyPrefix namespaces the records in a shared Redis.
+const redisIdempotencyKeyPrefix = "customer:idempotency:"
+
+// redisIdempotencyCustomerPrefix namespaces the sets of record keys
+// whose responses are about a customer.
+const redisIdempotencyCustomerPrefix = "customer:idempotency-customer:"
+
+// redisIdempotencyRepo keeps the records as hashes Redis expires: the
+// fingerprint under "f" and, once the request succeeded, the encrypted
+// response under "r" with the id of its key under "k". Every change is a
+// script, so a record is never read and written back in between.
+type redisIdempotencyRepo struct {
+	rdb    *redis.Client
+	fields *fieldCipher
+}
+
+// errIdempotencyClaimLost is returned when a claim expired before its
+// request completed.
+var errIdempotencyClaimLost = errors.New("idempotency claim expired before the request completed")
+
+// claimScript claims KEYS[1] for the fingerprint ARGV[1] for ARGV[2]
+// milliseconds, unless it is claimed already. It returns nil when it
+// claimed the key, otherwise the fingerprint, response and key id of the
+// record.
+var claimScript = redis.NewScript(`
+if redis.call("HSETNX", KEYS[1], "f", ARGV[1]) == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return nil
+end
+return redis.call("HMGET", KEYS[1], "f", "r", "k")
+`)
+
+// completeScript stores the response ARGV[2], encrypted with the key
+// ARGV[3], under KEYS[1] for ARGV[4] milliseconds if the key is still
+// claimed for the fingerprint ARGV[1], and adds KEYS[1] to the customer
+// sets KEYS[2..]. It returns 0 when the claim was lost.
+var completeScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "f") ~= ARGV[1] then
+	return 0
+end
+redis.call("HSET", KEYS[1], "r", ARGV[2], "k", ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+for i = 2, #KEYS do
+	redis.call("SADD", KEYS[i], KEYS[1])
+	if redis.call("PTTL", KEYS[i]) < tonumber(ARGV[4]) then
+		redis.call("PEXPIRE", KEYS[i], ARGV[4])
+	end
+end
+return 1
+`)
+
+// forgetScript removes the ARGV[1] customer sets KEYS[1..ARGV[1]] and the
+// records KEYS[ARGV[1]+1..] listed in them. Every key it touches is
+// passed in KEYS, as Redis Cluster requires, so it returns 0 and removes
+// nothing when a set lists a record not passed since it was read.
+var forgetScript = redis.NewScript(`
+local sets = tonumber(ARGV[1])
+local given = {}
+for i = sets + 1, #KEYS do
+	given[KEYS[i]] = true
+end
+for i = 1, sets do
+	for _, key in ipairs(redis.call("SMEMBERS", KEYS[i])) do
+		if not given[key] then
+			return 0
+		end
+	end
+end
+for i = 1, #KEYS do
+	redis.call("DEL", KEYS[i])
+end
+return 1
+`)
+
+// forgetAttempts bounds how often Forget rereads sets that records were
+// added to meanwhile.
+const forgetAttempts = 5
+
+// rebindScript replaces the response ARGV[2], encrypted with the key
+// ARGV[1], under KEYS[1] with ARGV[3], encrypted with the key ARGV[4],
+// unless the record changed or expired meanwhile.
+var rebindScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "k") == ARGV[1] and redis.call("HGET", KEYS[1], "r") == ARGV[2] then
+	redis.call("HSET", KEYS[1], "r", ARGV[3], "k", ARGV[4])
+end
+return 0
+`)
+
+// renewScript extends the claim on KEYS[1] for the fingerprint ARGV[1] to
+// ARGV[2] milliseconds while it holds no response.
+var renewScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "f") == ARGV[1] and redis.call("HEXISTS", KEYS[1], "r") == 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript drops the claim on KEYS[1] unless it holds a response.
+var releaseScript = redis.NewScript(`
+if redis.call("HEXISTS", KEYS[1], "r") == 0 then
+	redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (r *redisIdempotencyRepo) Claim(ctx context.Context, key, fingerprint string, ttl time.Duration) (*biz.IdempotencyRecord, error) {
+	v, err := claimScript.Run(ctx, r.rdb, []string{redisIdempotencyKeyPrefix + key}, fingerprint, ttl.Milliseconds()).Slice()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec := &biz.IdempotencyRecord{}
+	rec.Fingerprint, _ = v[0].(string)
+	if resp, ok := v[1].(string); ok {
+		keyID, _ := v[2].(string)
+		if rec.Response, err = openResponse(ctx, r.fields, key, []byte(resp), keyID); err != nil {
+			return nil, err
+		}
+	}
+	return rec, nil
+}
+
+func (r *redisIdempotencyRepo) Complete(ctx context.Context, key string, rec *biz.IdempotencyRecord, ttl time.Duration) error {
+	response, keyID, err := sealResponse(ctx, r.fields, key, rec.Response)
+	if err != nil {
+		return err
+	}
+	keys := []string{redisIdempotencyKeyPrefix + key}
+	for _, id := range rec.CustomerIDs {
+		keys = append(keys, redisIdempotencyCustomerPrefix+strconv.FormatInt(id, 10))
+	}
+	ok, err := completeScript.Run(ctx, r.rdb, keys, rec.Fingerprint, response, keyID, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return errIdempotencyClaimLost
+	}
+	return nil
+}
+
+func (r *redisIdempotencyRepo) Renew(ctx context.Context, key, fingerprint string, ttl time.Duration) error {
+	return renewScript.Run(ctx, r.rdb, []string{redisIdempotencyKeyPrefix + key}, fingerprint, ttl.Milliseconds()).Err()
+}
+
+func (r *redisIdempotencyRepo) Release(ctx context.Context, key string) error {
+	return releaseScript.Run(ctx, r.rdb, []string{redisIdempotencyKeyPrefix + key}).Err()
+}
+
+func (r *redisIdempotencyRepo) Forget(ctx context.Context, customerIDs []int64) error {
+	sets := make([]string, 0, len(customerIDs))
+	for _, id := range customerIDs {
+		sets = append(sets, redisIdempotencyCustomerPrefix+strconv.FormatInt(id, 10))
+	}
+	for attempt := 0; attempt < forgetAttempts; attempt++ {
+		keys := append([]string{}, sets...)
+		for _, set := range sets {
+			members, err := r.rdb.SMembers(ctx, set).Result()
+			if err != nil {
+				return err
+			}
+			keys = append(keys, members...)
+		}
+		ok, err := forgetScript.Run(ctx, r.rdb, keys, len(sets)).Int()
+		if err != nil || ok == 1 {
+			return err
+		}
+	}
+	return errors.New("idempotency: customer records kept changing while they were forgotten")
+}
+
+// DeleteExpired removes nothing, Redis expires the records itself.
+func (r *redisIdempotencyRepo) DeleteExpired(context.Context, int) (int, error) {
+	return 0, nil
+}
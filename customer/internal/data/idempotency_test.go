@@ -0,0 +1,217 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"customer/internal/biz"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// idempotencyBackend is a store under test and how to let its records
+// expire.
+type idempotencyBackend struct {
+	repo   biz.IdempotencyRepo
+	expire func()
+}
+
+// idempotencyBackends returns the postgres and the Redis store, both
+// encrypting responses.
+func idempotencyBackends(t *testing.T) map[string]idempotencyBackend {
+	t.Helper()
+	d, _ := newEncryptedTestData(t)
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return map[string]idempotencyBackend{
+		"postgres": {
+			repo: &idempotencyRepo{data: d},
+			expire: func() {
+				if err := d.db.Model(&IdempotencyKey{}).Where("1 = 1").Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		"redis": {
+			repo:   &redisIdempotencyRepo{rdb: rdb, fields: d.fields},
+			expire: func() { mr.FastForward(time.Hour) },
+		},
+	}
+}
+
+func TestIdempotencyRepoReplay(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range idempotencyBackends(t) {
+		if rec, err := b.repo.Claim(ctx, "replay", "fp", time.Minute); rec != nil || err != nil {
+			t.Fatalf("%s: first claim = %v, %v, want it claimed", name, rec, err)
+		}
+		reply := []byte(`{"id":1,"name":"Jane Doe"}`)
+		if err := b.repo.Complete(ctx, "replay", &biz.IdempotencyRecord{Fingerprint: "fp", Response: reply, CustomerIDs: []int64{1}}, time.Hour); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		rec, err := b.repo.Claim(ctx, "replay", "fp", time.Minute)
+		if err != nil || rec == nil || rec.Fingerprint != "fp" || !bytes.Equal(rec.Response, reply) {
+			t.Errorf("%s: retry = %+v, %v, want the stored reply", name, rec, err)
+		}
+
+		// an empty reply is still a reply, not a request in flight
+		if _, err := b.repo.Claim(ctx, "empty", "fp", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.repo.Complete(ctx, "empty", &biz.IdempotencyRecord{Fingerprint: "fp", Response: []byte{}}, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		if rec, err := b.repo.Claim(ctx, "empty", "fp", time.Minute); err != nil || rec == nil || rec.Response == nil {
+			t.Errorf("%s: retry of an empty reply = %+v, %v", name, rec, err)
+		}
+
+		// a completed record is not released, an expired one is taken over
+		if err := b.repo.Release(ctx, "replay"); err != nil {
+			t.Fatal(err)
+		}
+		if rec, err := b.repo.Claim(ctx, "replay", "fp", time.Minute); err != nil || rec == nil || rec.Response == nil {
+			t.Errorf("%s: released a completed record: %+v, %v", name, rec, err)
+		}
+		b.expire()
+		if rec, err := b.repo.Claim(ctx, "replay", "other", time.Minute); rec != nil || err != nil {
+			t.Errorf("%s: claim after expiry = %+v, %v, want it claimed", name, rec, err)
+		}
+	}
+}
+
+func TestIdempotencyRepoConflictingRequest(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range idempotencyBackends(t) {
+		if _, err := b.repo.Claim(ctx, "key", "first", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.repo.Complete(ctx, "key", &biz.IdempotencyRecord{Fingerprint: "first", Response: []byte("reply")}, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+		rec, err := b.repo.Claim(ctx, "key", "second", time.Minute)
+		if err != nil || rec == nil || rec.Fingerprint != "first" {
+			t.Errorf("%s: claim with another body = %+v, %v, want the first fingerprint", name, rec, err)
+		}
+		// the other request cannot complete the key either
+		err = b.repo.Complete(ctx, "key", &biz.IdempotencyRecord{Fingerprint: "second", Response: []byte("other")}, time.Hour)
+		if !errors.Is(err, errIdempotencyClaimLost) {
+			t.Errorf("%s: completing another request's key: %v, want the claim lost", name, err)
+		}
+		if rec, _ := b.repo.Claim(ctx, "key", "first", time.Minute); rec == nil || string(rec.Response) != "reply" {
+			t.Errorf("%s: reply = %+v, want the first one kept", name, rec)
+		}
+	}
+}
+
+func TestIdempotencyRepoConcurrentClaim(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range idempotencyBackends(t) {
+		const n = 8
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			claimed int
+		)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rec, err := b.repo.Claim(ctx, "race", "fp", time.Minute)
+				if err != nil {
+					t.Errorf("%s: %v", name, err)
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if rec == nil {
+					claimed++
+				} else if rec.Response != nil || rec.Fingerprint != "fp" {
+					t.Errorf("%s: in-flight record = %+v", name, rec)
+				}
+			}()
+		}
+		wg.Wait()
+		if claimed != 1 {
+			t.Errorf("%s: %d of %d concurrent claims won, want 1", name, claimed, n)
+		}
+
+		// renewing keeps the claim, releasing it lets a retry run
+		if err := b.repo.Renew(ctx, "race", "fp", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		if rec, _ := b.repo.Claim(ctx, "race", "fp", time.Minute); rec == nil || rec.Response != nil {
+			t.Errorf("%s: renewed claim = %+v, want it in flight", name, rec)
+		}
+		if err := b.repo.Release(ctx, "race"); err != nil {
+			t.Fatal(err)
+		}
+		if rec, err := b.repo.Claim(ctx, "race", "fp", time.Minute); rec != nil || err != nil {
+			t.Errorf("%s: claim after release = %+v, %v, want it claimed", name, rec, err)
+		}
+	}
+}
+
+func TestIdempotencyRepoForget(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range idempotencyBackends(t) {
+		for key, ids := range map[string][]int64{"one": {1}, "both": {1, 2}, "two": {2}} {
+			if _, err := b.repo.Claim(ctx, key, "fp", time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.repo.Complete(ctx, key, &biz.IdempotencyRecord{Fingerprint: "fp", Response: []byte(key), CustomerIDs: ids}, time.Hour); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := b.repo.Forget(ctx, []int64{1}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for key, kept := range map[string]bool{"one": false, "both": false, "two": true} {
+			rec, err := b.repo.Claim(ctx, key, "fp", time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (rec != nil) != kept {
+				t.Errorf("%s: record %q kept = %v, want %v", name, key, rec != nil, kept)
+			}
+		}
+		// forgetting a customer without records is fine
+		if err := b.repo.Forget(ctx, []int64{3}); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestRedisForgetPassesEveryKey(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	set := redisIdempotencyCustomerPrefix + "1"
+	record := redisIdempotencyKeyPrefix + "a"
+	mr.HSet(record, "f", "fp")
+	if _, err := mr.SetAdd(set, record); err != nil {
+		t.Fatal(err)
+	}
+
+	// a record added to the set after it was read is not deleted unseen
+	ok, err := forgetScript.Run(ctx, rdb, []string{set}, 1).Int()
+	if err != nil || ok != 0 {
+		t.Errorf("forget without the record key = %d, %v, want it refused", ok, err)
+	}
+	if !mr.Exists(record) || !mr.Exists(set) {
+		t.Error("a refused forget removed keys")
+	}
+	ok, err = forgetScript.Run(ctx, rdb, []string{set, record}, 1).Int()
+	if err != nil || ok != 1 {
+		t.Errorf("forget = %d, %v", ok, err)
+	}
+	if mr.Exists(record) || mr.Exists(set) {
+		t.Error("forget left keys behind")
+	}
+}
@@ -0,0 +1,176 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+	"customer/internal/service"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// changeStatus calls the RPC that moves a customer to status to.
+func changeStatus(ctx context.Context, s *service.CustomerService, id int64, to biz.CustomerStatus, reason string) error {
+	var err error
+	switch to {
+	case biz.CustomerActive:
+		_, err = s.ActivateCustomer(ctx, &pb.ActivateCustomerReq{Id: id, Reason: reason})
+	case biz.CustomerSuspended:
+		_, err = s.SuspendCustomer(ctx, &pb.SuspendCustomerReq{Id: id, Reason: reason})
+	case biz.CustomerClosed:
+		_, err = s.CloseCustomer(ctx, &pb.CloseCustomerReq{Id: id, Reason: reason})
+	default:
+		err = fmt.Errorf("no RPC makes a customer %s", to)
+	}
+	return err
+}
+
+func TestCustomerTransitions(t *testing.T) {
+	d := newTestData(t)
+	s := newTestService(d)
+	ctx := biz.NewTenantContext(context.Background(), "acme")
+
+	statuses := []biz.CustomerStatus{biz.CustomerProspect, biz.CustomerActive, biz.CustomerSuspended, biz.CustomerClosed}
+	allowed := map[[2]biz.CustomerStatus]bool{
+		{biz.CustomerProspect, biz.CustomerActive}:  true,
+		{biz.CustomerProspect, biz.CustomerClosed}:  true,
+		{biz.CustomerActive, biz.CustomerSuspended}: true,
+		{biz.CustomerActive, biz.CustomerClosed}:    true,
+		{biz.CustomerSuspended, biz.CustomerActive}: true,
+		{biz.CustomerSuspended, biz.CustomerClosed}: true,
+	}
+	for _, from := range statuses {
+		for _, to := range []biz.CustomerStatus{biz.CustomerActive, biz.CustomerSuspended, biz.CustomerClosed} {
+			name := fmt.Sprintf("%s to %s", from, to)
+			created, err := s.CreateCustomer(ctx, &pb.CreateCustomerReq{Name: "Jane Doe", DateOfBirth: "1990-01-01"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := d.db.Model(&Customer{}).Where("id = ?", created.Id).Update("status", int32(from)).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if err := changeStatus(ctx, s, created.Id, to, ""); errors.Reason(err) != "STATUS_REASON_REQUIRED" {
+				t.Errorf("%s without a reason: %v, want STATUS_REASON_REQUIRED", name, err)
+			}
+			err = changeStatus(ctx, s, created.Id, to, "requested")
+			c, getErr := s.GetCustomer(ctx, &pb.GetCustomerReq{Id: created.Id})
+			if getErr != nil {
+				t.Fatal(getErr)
+			}
+			if !allowed[[2]biz.CustomerStatus{from, to}] {
+				if errors.Code(err) != 412 || errors.Reason(err) != "CUSTOMER_STATUS_TRANSITION" {
+					t.Errorf("%s: %v, want CUSTOMER_STATUS_TRANSITION", name, err)
+				}
+				if c.Status != pb.CustomerStatus(from) {
+					t.Errorf("%s: refused, but the customer is %s", name, c.Status)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if c.Status != pb.CustomerStatus(to) || c.StatusReason != "requested" || c.StatusChangedAt == nil {
+				t.Errorf("%s: customer is %s, reason %q, changed at %v", name, c.Status, c.StatusReason, c.StatusChangedAt)
+			}
+			var events int64
+			err = d.db.Model(&OutboxEvent{}).
+				Where("customer_id = ? AND type = ?", created.Id, "api.customer.v1.CustomerStatusChanged").
+				Count(&events).Error
+			if err != nil || events != 1 {
+				t.Errorf("%s: %d status events, %v, want 1", name, events, err)
+			}
+		}
+	}
+}
+
+func TestClosedCustomerRefusesChanges(t *testing.T) {
+	d := newTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+	if _, err := s.CloseCustomer(f.acme, &pb.CloseCustomerReq{Id: f.acmeID, Reason: "requested"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, call := range map[string]func() error{
+		"AddEmail": func() error {
+			_, err := s.AddEmail(f.acme, &pb.AddEmailReq{CustomerId: f.acmeID, Email: "new@example.com"})
+			return err
+		},
+		"AddPhoneNumber": func() error {
+			_, err := s.AddPhoneNumber(f.acme, &pb.AddPhoneNumberReq{CustomerId: f.acmeID, PhoneNumber: "+4915187654321"})
+			return err
+		},
+		"AddAddress": func() error {
+			_, err := s.AddAddress(f.acme, &pb.AddAddressReq{CustomerId: f.acmeID, Address: "Side Street 2, Berlin"})
+			return err
+		},
+		"UpdateCustomer": func() error {
+			_, err := s.UpdateCustomer(f.acme, &pb.UpdateCustomerReq{Id: f.acmeID, Name: "Mallory"})
+			return err
+		},
+		"BatchUpdateCustomers": func() error {
+			_, err := s.BatchUpdateCustomers(f.acme, &pb.BatchUpdateCustomersReq{Updates: []*pb.UpdateCustomerReq{{Id: f.acmeID, Name: "Mallory"}}})
+			return err
+		},
+	} {
+		if err := call(); errors.Code(err) != 412 || errors.Reason(err) != "CUSTOMER_CLOSED" {
+			t.Errorf("%s on a closed customer: %v, want CUSTOMER_CLOSED", name, err)
+		}
+	}
+
+	// best effort batches report it per update
+	reply, err := s.BatchUpdateCustomers(f.acme, &pb.BatchUpdateCustomersReq{
+		Updates:    []*pb.UpdateCustomerReq{{Id: f.acmeID, Name: "Mallory"}, {Id: f.globexID, Name: "Mallory"}},
+		BestEffort: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Results[0].Error == "" {
+		t.Error("the closed customer was updated")
+	}
+
+	emails, err := s.ListEmail(f.acme, &pb.ListEmailReq{CustomerId: f.acmeID})
+	if err != nil || len(emails.Emails) != 1 {
+		t.Errorf("emails = %v, %v, want the one it had", emails, err)
+	}
+	c, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID})
+	if err != nil || c.Name != "Jane Acme" {
+		t.Errorf("customer = %v, %v, want it unchanged", c, err)
+	}
+}
+
+func TestErasureClosesCustomer(t *testing.T) {
+	d := newTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+
+	// without a grace period the erasure runs right away
+	if _, err := s.EraseCustomer(f.acme, &pb.EraseCustomerReq{Id: f.acmeID, Reason: "request"}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Status != pb.CustomerStatus(biz.CustomerClosed) || c.StatusReason != "erased" || c.StatusChangedAt == nil {
+		t.Errorf("erased customer is %s, reason %q, changed at %v", c.Status, c.StatusReason, c.StatusChangedAt)
+	}
+	var events int64
+	err = d.db.Model(&OutboxEvent{}).
+		Where("customer_id = ? AND type = ?", f.acmeID, "api.customer.v1.CustomerStatusChanged").
+		Count(&events).Error
+	if err != nil || events != 1 {
+		t.Errorf("%d status events, %v, want 1", events, err)
+	}
+
+	_, err = s.AddEmail(f.acme, &pb.AddEmailReq{CustomerId: f.acmeID, Email: "new@example.com"})
+	if errors.Code(err) != 412 || errors.Reason(err) != "CUSTOMER_CLOSED" {
+		t.Errorf("AddEmail on an erased customer: %v, want CUSTOMER_CLOSED", err)
+	}
+}
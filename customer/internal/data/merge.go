@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"customer/internal/biz"
+
+	"gorm.io/gorm"
+)
+
+// CustomerMerge records a customer merged into a survivor. It doubles as
+// the redirect from the merged id, so MergedID is unique.
+type CustomerMerge struct {
+	ID           int64  `gorm:"primaryKey"`
+	TenantID     string `gorm:"index;not null;default:''"`
+	SurvivorID   int64  `gorm:"index"`
+	MergedID     int64  `gorm:"uniqueIndex"`
+	Name         string
+	DateOfBirth  string
+	Emails       []string `gorm:"serializer:json"`
+	PhoneNumbers []string `gorm:"serializer:json"`
+	Addresses    []string `gorm:"serializer:json"`
+	MergedAt     time.Time
+	// KeyID names the key the snapshot is encrypted with
+	KeyID string `gorm:"not null;default:''"`
+}
+
+type mergeRepo struct {
+	data *Data
+}
+
+func NewMergeRepo(data *Data) biz.MergeRepo {
+	return &mergeRepo{data: data}
+}
+
+func (r *mergeRepo) Merge(ctx context.Context, m *biz.CustomerMerge) error {
+	db := r.data.DB(ctx)
+	fields := r.data.fields
+
+	// emails and phone numbers are unique, so they move over as they are.
+	// Their values are bound to the customer and are encrypted again.
+	var emails []Email
+	if err := db.Scopes(tenantScope(ctx, "emails")).Where("customer_id = ?", m.MergedID).Find(&emails).Error; err != nil {
+		return err
+	}
+	for _, e := range emails {
+		if err := fields.decrypt(ctx, e.KeyID, e.binding(), e.encrypted()...); err != nil {
+			return err
+		}
+		m.Emails = append(m.Emails, e.Email)
+		e.CustomerID = m.SurvivorID
+		if err := reseal(ctx, db, fields, &e, e.ID, e.CustomerID); err != nil {
+			return err
+		}
+	}
+	var phones []PhoneNumber
+	if err := db.Scopes(tenantScope(ctx, "phone_numbers")).Where("customer_id = ?", m.MergedID).Find(&phones).Error; err != nil {
+		return err
+	}
+	for _, p := range phones {
+		if err := fields.decrypt(ctx, p.KeyID, p.binding(), p.encrypted()...); err != nil {
+			return err
+		}
+		m.PhoneNumbers = append(m.PhoneNumbers, p.PhoneNumber)
+		p.CustomerID = m.SurvivorID
+		if err := reseal(ctx, db, fields, &p, p.ID, p.CustomerID); err != nil {
+			return err
+		}
+	}
+
+	// addresses the survivor already has are dropped instead of doubled
+	var existing []string
+	if err := db.Model(&Address{}).Scopes(tenantScope(ctx, "addresses")).Where("customer_id = ?", m.SurvivorID).Pluck("address_hash", &existing).Error; err != nil {
+		return err
+	}
+	dropped := db.Scopes(tenantScope(ctx, "addresses")).Where("customer_id = ?", m.MergedID)
+	if len(existing) > 0 {
+		if err := dropped.Where("address_hash IN ?", existing).Delete(&Address{}).Error; err != nil {
+			return err
+		}
+	}
+	var addresses []Address
+	if err := db.Scopes(tenantScope(ctx, "addresses")).Where("customer_id = ?", m.MergedID).Find(&addresses).Error; err != nil {
+		return err
+	}
+	for _, a := range addresses {
+		if err := fields.decrypt(ctx, a.KeyID, a.binding(), a.encrypted()...); err != nil {
+			return err
+		}
+		m.Addresses = append(m.Addresses, a.Address)
+		a.CustomerID = m.SurvivorID
+		if err := reseal(ctx, db, fields, &a, a.ID, a.CustomerID); err != nil {
+			return err
+		}
+	}
+
+	// the consent history follows the contact points, the latest record
+	// on a channel and contact stays the one in force
+	var consents []CustomerConsent
+	if err := db.Scopes(tenantScope(ctx, "customer_consents")).Where("customer_id = ?", m.MergedID).Find(&consents).Error; err != nil {
+		return err
+	}
+	for _, c := range consents {
+		if err := fields.decrypt(ctx, c.KeyID, c.binding(), c.encrypted()...); err != nil {
+			return err
+		}
+		c.CustomerID = m.SurvivorID
+		if err := reseal(ctx, db, fields, &c, c.ID, c.CustomerID); err != nil {
+			return err
+		}
+	}
+
+	// customers merged into the merged one now resolve to the survivor
+	if err := db.Model(&CustomerMerge{}).Scopes(tenantScope(ctx, "customer_merges")).Where("survivor_id = ?", m.MergedID).Update("survivor_id", m.SurvivorID).Error; err != nil {
+		return err
+	}
+
+	model := CustomerMerge{
+		TenantID:     biz.TenantFromContext(ctx),
+		SurvivorID:   m.SurvivorID,
+		MergedID:     m.MergedID,
+		Name:         m.Name,
+		DateOfBirth:  m.DateOfBirth,
+		Emails:       append([]string(nil), m.Emails...),
+		PhoneNumbers: append([]string(nil), m.PhoneNumbers...),
+		Addresses:    append([]string(nil), m.Addresses...),
+		MergedAt:     m.MergedAt,
+	}
+	var err error
+	if model.KeyID, err = fields.encrypt(ctx, model.binding(), model.encrypted()...); err != nil {
+		return err
+	}
+	if err := db.Create(&model).Error; err != nil {
+		return err
+	}
+	m.ID = model.ID
+
+	return db.Scopes(tenantScope(ctx, "customers")).Delete(&Customer{}, m.MergedID).Error
+}
+
+// sealedRow is a model with encrypted values.
+type sealedRow interface {
+	binding() binding
+	encrypted() []field
+}
+
+// reseal encrypts the decrypted values of row again, for the binding of
+// the customer it moves to, and stores them with the customer id.
+func reseal(ctx context.Context, db *gorm.DB, fields *fieldCipher, row sealedRow, id, customerID int64) error {
+	keyID, err := fields.encrypt(ctx, row.binding(), row.encrypted()...)
+	if err != nil {
+		return err
+	}
+	updates := map[string]interface{}{"customer_id": customerID, "key_id": keyID}
+	for _, v := range row.encrypted() {
+		updates[v.column] = *v.value
+	}
+	return db.Model(row).Where("id = ?", id).Updates(updates).Error
+}
+
+// the snapshot of a merged customer is bound to the merged id
+func (m *CustomerMerge) binding() binding {
+	return rowBinding("customer_merges", m.TenantID, m.MergedID)
+}
+
+// encrypted points at the personal data of the snapshot.
+func (m *CustomerMerge) encrypted() []field {
+	v := []field{{"name", &m.Name}, {"date_of_birth", &m.DateOfBirth}}
+	for _, l := range []struct {
+		column string
+		list   []string
+	}{{"emails", m.Emails}, {"phone_numbers", m.PhoneNumbers}, {"addresses", m.Addresses}} {
+		for i := range l.list {
+			v = append(v, field{l.column, &l.list[i]})
+		}
+	}
+	return v
+}
+
+func (r *mergeRepo) Merged(ctx context.Context, survivorID int64) ([]*biz.CustomerMerge, error) {
+	var models []CustomerMerge
+	err := r.data.DB(ctx).
+		Scopes(tenantScope(ctx, "customer_merges")).
+		Where("survivor_id = ?", survivorID).
+		Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*biz.CustomerMerge, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.CustomerMerge{
+			ID:           m.ID,
+			SurvivorID:   m.SurvivorID,
+			MergedID:     m.MergedID,
+			Name:         m.Name,
+			DateOfBirth:  m.DateOfBirth,
+			Emails:       m.Emails,
+			PhoneNumbers: m.PhoneNumbers,
+			Addresses:    m.Addresses,
+			MergedAt:     m.MergedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *mergeRepo) Survivor(ctx context.Context, id int64) (int64, error) {
+	var m CustomerMerge
+	err := r.data.DB(ctx).Scopes(tenantScope(ctx, "customer_merges")).Where("merged_id = ?", id).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return m.SurvivorID, nil
+}
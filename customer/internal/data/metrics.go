@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gorm"
+)
+
+// queryStartKey holds the start time of a statement on its gorm.DB.
+const queryStartKey = "customer:query_start"
+
+// registerMetrics times every statement gorm runs, counts the failed ones
+// and reports the connection pool of db.
+func registerMetrics(db *gorm.DB) error {
+	meter := otel.Meter("customer/internal/data")
+	seconds, err := meter.Float64Histogram("db_query_seconds",
+		metric.WithDescription("duration of database statements by operation and table"),
+		metric.WithExplicitBucketBoundaries(0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5))
+	if err != nil {
+		return err
+	}
+	errs, err := meter.Int64Counter("db_query_errors_total",
+		metric.WithDescription("failed database statements by operation and table"))
+	if err != nil {
+		return err
+	}
+
+	before := func(db *gorm.DB) {
+		db.InstanceSet(queryStartKey, time.Now())
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			v, ok := db.InstanceGet(queryStartKey)
+			if !ok {
+				return
+			}
+			ctx := db.Statement.Context
+			attrs := metric.WithAttributes(
+				attribute.String("operation", operation),
+				attribute.String("table", db.Statement.Table),
+			)
+			seconds.Record(ctx, time.Since(v.(time.Time)).Seconds(), attrs)
+			// a missing record is an answer, not a failure
+			if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+				errs.Add(ctx, 1, attrs)
+			}
+		}
+	}
+
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	open, err := meter.Int64ObservableGauge("db_pool_open_connections",
+		metric.WithDescription("open connections, in use and idle"))
+	if err != nil {
+		return err
+	}
+	inUse, err := meter.Int64ObservableGauge("db_pool_in_use_connections")
+	if err != nil {
+		return err
+	}
+	idle, err := meter.Int64ObservableGauge("db_pool_idle_connections")
+	if err != nil {
+		return err
+	}
+	maxOpen, err := meter.Int64ObservableGauge("db_pool_max_open_connections")
+	if err != nil {
+		return err
+	}
+	waits, err := meter.Int64ObservableCounter("db_pool_waits_total",
+		metric.WithDescription("connections waited for because the pool was exhausted"))
+	if err != nil {
+		return err
+	}
+	waited, err := meter.Float64ObservableCounter("db_pool_wait_seconds_total",
+		metric.WithDescription("time spent waiting for connections"))
+	if err != nil {
+		return err
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s := sqlDB.Stats()
+		o.ObserveInt64(open, int64(s.OpenConnections))
+		o.ObserveInt64(inUse, int64(s.InUse))
+		o.ObserveInt64(idle, int64(s.Idle))
+		o.ObserveInt64(maxOpen, int64(s.MaxOpenConnections))
+		o.ObserveInt64(waits, s.WaitCount)
+		o.ObserveFloat64(waited, s.WaitDuration.Seconds())
+		return nil
+	}, open, inUse, idle, maxOpen, waits, waited)
+	return err
+}
@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"customer/internal/biz"
+)
+
+// OutboxEvent is a domain event waiting to be relayed. Rows are written in
+// the transaction of the change, marked appended once in the change feed
+// and published once delivered.
+type OutboxEvent struct {
+	ID         int64 `gorm:"primaryKey"`
+	CustomerID int64 `gorm:"index"`
+	Type       string
+	// Payload carries the personal data of the change, so it is encrypted
+	// like a column value
+	Payload []byte
+	// KeyID names the key Payload is encrypted with
+	KeyID         string `gorm:"not null;default:''"`
+	OccurredAt    time.Time
+	TenantID      string
+	Actor         string
+	TraceContext  map[string]string `gorm:"serializer:json"`
+	AppendedAt    *time.Time        `gorm:"index"`
+	PublishedAt   *time.Time        `gorm:"index"`
+	Attempts      int32
+	NextAttemptAt time.Time
+	LastError     string
+}
+
+// eventBinding binds the payload of an event to its customer. The change
+// feed shares it, so it copies the payload of an outbox event as it is.
+func eventBinding(tenant string, customerID int64) binding {
+	return rowBinding("events", tenant, customerID)
+}
+
+type outboxRepo struct {
+	data *Data
+}
+
+func NewOutboxRepo(data *Data) biz.OutboxRepo {
+	return &outboxRepo{data: data}
+}
+
+func (r *outboxRepo) Save(ctx context.Context, e *biz.Event) error {
+	model := OutboxEvent{
+		CustomerID:   e.CustomerID,
+		Type:         e.Type,
+		OccurredAt:   e.OccurredAt,
+		TenantID:     e.TenantID,
+		Actor:        e.Actor,
+		TraceContext: e.TraceContext,
+	}
+	var err error
+	if model.Payload, model.KeyID, err = r.data.fields.encryptBytes(ctx, eventBinding(e.TenantID, e.CustomerID), "payload", e.Payload); err != nil {
+		return err
+	}
+	if err := r.data.DB(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	e.ID = model.ID
+	return nil
+}
+
+func (r *outboxRepo) Pending(ctx context.Context, limit int) ([]*biz.Event, error) {
+	now := time.Now()
+	var models []OutboxEvent
+	err := r.data.DB(ctx).
+		Where("appended_at IS NOT NULL AND published_at IS NULL AND next_attempt_at <= ?", now).
+		Where(`NOT EXISTS (SELECT 1 FROM outbox_events b WHERE b.customer_id = outbox_events.customer_id
+			AND b.published_at IS NULL AND b.id < outbox_events.id AND b.next_attempt_at > ?)`, now).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*biz.Event, 0, len(models))
+	for _, m := range models {
+		e, err := r.toBizEvent(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func (r *outboxRepo) MarkPublished(ctx context.Context, id int64) error {
+	return r.data.DB(ctx).
+		Model(&OutboxEvent{}).
+		Where("id = ?", id).
+		Update("published_at", time.Now()).Error
+}
+
+func (r *outboxRepo) MarkFailed(ctx context.Context, id int64, attempts int32, next time.Time, reason string) error {
+	return r.data.DB(ctx).
+		Model(&OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        attempts,
+			"next_attempt_at": next,
+			"last_error":      reason,
+		}).Error
+}
+
+func (r *outboxRepo) toBizEvent(ctx context.Context, m OutboxEvent) (*biz.Event, error) {
+	payload, err := r.data.fields.decryptBytes(ctx, m.KeyID, eventBinding(m.TenantID, m.CustomerID), "payload", m.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("event %d: %w", m.ID, err)
+	}
+	return &biz.Event{
+		ID:            m.ID,
+		CustomerID:    m.CustomerID,
+		Type:          m.Type,
+		Payload:       payload,
+		OccurredAt:    m.OccurredAt,
+		TenantID:      m.TenantID,
+		Actor:         m.Actor,
+		TraceContext:  m.TraceContext,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+	}, nil
+}
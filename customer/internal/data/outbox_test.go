@@ -0,0 +1,164 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// recordingPublisher records what it published, and fails the events in
+// failing once each.
+type recordingPublisher struct {
+	mu        sync.Mutex
+	failing   map[int64]bool
+	published []*biz.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e *biz.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing[e.ID] {
+		delete(p.failing, e.ID)
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, e)
+	return nil
+}
+
+// publishedIDs returns the ids of the events published for each customer,
+// in the order they were.
+func (p *recordingPublisher) publishedIDs() map[int64][]int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ids := map[int64][]int64{}
+	for _, e := range p.published {
+		ids[e.CustomerID] = append(ids[e.CustomerID], e.ID)
+	}
+	return ids
+}
+
+// saveEvents saves events[id] events for each customer id, interleaved,
+// and returns their ids for each customer in order.
+func saveEvents(t *testing.T, d *Data, events map[int64]int) map[int64][]int64 {
+	t.Helper()
+	outbox := NewOutboxRepo(d)
+	ids := map[int64][]int64{}
+	for i := 0; i < 3; i++ {
+		for customerID, n := range events {
+			if i >= n {
+				continue
+			}
+			e, err := biz.NewEvent(customerID, &pb.EmailAdded{CustomerId: customerID, Email: "jane@example.com"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			e.TenantID = "acme"
+			if err := outbox.Save(context.Background(), e); err != nil {
+				t.Fatal(err)
+			}
+			ids[customerID] = append(ids[customerID], e.ID)
+		}
+	}
+	return ids
+}
+
+func newTestRelay(d *Data, pub biz.EventPublisher) *biz.OutboxRelay {
+	return biz.NewOutboxRelay(NewOutboxRepo(d), pub, biz.NewChangeFeed(NewChangeFeedRepo(d)), NewLeaseRepo(d), log.DefaultLogger)
+}
+
+func TestOutboxRelayLeaseTakeover(t *testing.T) {
+	ctx := context.Background()
+	d := newTestData(t)
+	pub := &recordingPublisher{}
+	relay := newTestRelay(d, pub)
+	saveEvents(t, d, map[int64]int{1: 1})
+
+	if n, err := relay.Relay(ctx, "replica-a", 10, time.Minute); n != 1 || err != nil {
+		t.Fatalf("replica-a relayed %d, %v, want 1", n, err)
+	}
+	saveEvents(t, d, map[int64]int{2: 1})
+	if n, err := relay.Relay(ctx, "replica-b", 10, time.Minute); n != 0 || err != nil {
+		t.Errorf("replica-b relayed %d, %v while replica-a holds the lease", n, err)
+	}
+
+	// replica-a stops renewing, its lease runs out
+	if err := d.db.Model(&JobLease{}).Where("name = ?", "outbox-relay").Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if n, err := relay.Relay(ctx, "replica-b", 10, time.Minute); n != 1 || err != nil {
+		t.Errorf("replica-b relayed %d, %v after the lease expired, want 1", n, err)
+	}
+	saveEvents(t, d, map[int64]int{3: 1})
+	if n, err := relay.Relay(ctx, "replica-a", 10, time.Minute); n != 0 || err != nil {
+		t.Errorf("replica-a relayed %d, %v after replica-b took over", n, err)
+	}
+
+	// a released lease is free at once
+	if err := relay.Release(ctx, "replica-b"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := relay.Relay(ctx, "replica-a", 10, time.Minute); n != 1 || err != nil {
+		t.Errorf("replica-a relayed %d, %v after replica-b released the lease, want 1", n, err)
+	}
+	if len(pub.publishedIDs()) != 3 {
+		t.Errorf("published %d events, want each of the 3 once", len(pub.published))
+	}
+}
+
+func TestOutboxRelayOrdersFailingCustomer(t *testing.T) {
+	ctx := context.Background()
+	d := newTestData(t)
+	pub := &recordingPublisher{failing: map[int64]bool{}}
+	relay := newTestRelay(d, pub)
+	ids := saveEvents(t, d, map[int64]int{1: 3, 2: 2})
+	// the second event of customer 1 fails once
+	pub.failing[ids[1][1]] = true
+
+	start := time.Now()
+	if n, err := relay.Relay(ctx, "replica-a", 10, time.Minute); n != 3 || err != nil {
+		t.Fatalf("relayed %d, %v, want 3", n, err)
+	}
+	got := pub.publishedIDs()
+	if len(got[1]) != 1 || got[1][0] != ids[1][0] {
+		t.Errorf("customer 1 got %v, want only %d ahead of the failed event", got[1], ids[1][0])
+	}
+	if len(got[2]) != 2 || got[2][0] != ids[2][0] || got[2][1] != ids[2][1] {
+		t.Errorf("customer 2 got %v, want %v despite the failure", got[2], ids[2])
+	}
+
+	var failed OutboxEvent
+	if err := d.db.First(&failed, ids[1][1]).Error; err != nil {
+		t.Fatal(err)
+	}
+	if failed.Attempts != 1 || failed.LastError != "broker unavailable" || failed.PublishedAt != nil {
+		t.Errorf("failed event = %d attempts, error %q, published %v", failed.Attempts, failed.LastError, failed.PublishedAt)
+	}
+	if wait := failed.NextAttemptAt.Sub(start); wait < time.Second || wait > 2*time.Second {
+		t.Errorf("next attempt in %s, want the first backoff of 1s", wait)
+	}
+
+	// while it backs off, the events after it wait too
+	if n, err := relay.Relay(ctx, "replica-a", 10, time.Minute); n != 0 || err != nil {
+		t.Errorf("relayed %d, %v during the backoff, want nothing", n, err)
+	}
+	if err := d.db.Model(&OutboxEvent{}).Where("id = ?", failed.ID).Update("next_attempt_at", time.Now().Add(-time.Millisecond)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if n, err := relay.Relay(ctx, "replica-a", 10, time.Minute); n != 2 || err != nil {
+		t.Errorf("relayed %d, %v after the backoff, want 2", n, err)
+	}
+	got = pub.publishedIDs()
+	for i, id := range ids[1] {
+		if i >= len(got[1]) || got[1][i] != id {
+			t.Errorf("customer 1 got %v, want %v in order", got[1], ids[1])
+			break
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewEventPublisher returns the publisher selected by c.Publisher.Kind.
+func NewEventPublisher(c *conf.Data, logger log.Logger) (biz.EventPublisher, error) {
+	p := c.GetPublisher()
+	switch p.GetKind() {
+	case "", "log":
+		return &logPublisher{log: log.NewHelper(logger)}, nil
+	case "webhook":
+		if p.GetWebhookUrl() == "" {
+			return nil, fmt.Errorf("publisher: webhook_url is required for the webhook publisher")
+		}
+		timeout := 5 * time.Second
+		if p.GetTimeout() != nil {
+			timeout = p.GetTimeout().AsDuration()
+		}
+		return &webhookPublisher{url: p.GetWebhookUrl(), client: &http.Client{Timeout: timeout}}, nil
+	default:
+		return nil, fmt.Errorf("publisher: unknown kind %q", p.GetKind())
+	}
+}
+
+// logPublisher writes events to the service log. It is the default for
+// local development where no consumer is listening.
+type logPublisher struct {
+	log *log.Helper
+}
+
+func (p *logPublisher) Publish(ctx context.Context, e *biz.Event) error {
+	p.log.WithContext(ctx).Infow("msg", "event published", "event.id", e.ID, "event.type", e.Type, "customer.id", e.CustomerID, "tenant.id", e.TenantID, "event.actor", e.Actor)
+	return nil
+}
+
+// webhookPublisher POSTs every event as JSON to a fixed URL. Any non-2xx
+// answer is treated as a failed delivery and retried by the relay.
+type webhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+type webhookEvent struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	CustomerID int64           `json:"customer_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	TenantID   string          `json:"tenant_id,omitempty"`
+	Actor      string          `json:"actor,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, e *biz.Event) error {
+	msg, err := e.Message()
+	if err != nil {
+		return err
+	}
+	payload, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(webhookEvent{
+		ID:         e.ID,
+		Type:       e.Type,
+		CustomerID: e.CustomerID,
+		OccurredAt: e.OccurredAt,
+		TenantID:   e.TenantID,
+		Actor:      e.Actor,
+		Payload:    payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", strconv.FormatInt(e.ID, 10))
+	// consumers continue the trace the event was recorded in
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// queryLimitKey holds the state of a statement registerQueryLimits needs
+// once it ran.
+const queryLimitKey = "customer:query_limit"
+
+type queryLimit struct {
+	start  time.Time
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// configurePool sizes the connection pool of db after c.
+func configurePool(db *gorm.DB, c *conf.Data_Database) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if c.GetMaxOpenConns() > 0 {
+		sqlDB.SetMaxOpenConns(int(c.GetMaxOpenConns()))
+	}
+	if c.GetMaxIdleConns() > 0 {
+		sqlDB.SetMaxIdleConns(int(c.GetMaxIdleConns()))
+	}
+	if c.GetConnMaxLifetime() != nil {
+		sqlDB.SetConnMaxLifetime(c.GetConnMaxLifetime().AsDuration())
+	}
+	if c.GetConnMaxIdleTime() != nil {
+		sqlDB.SetConnMaxIdleTime(c.GetConnMaxIdleTime().AsDuration())
+	}
+	return nil
+}
+
+// registerQueryLimits cuts statements off after the query timeout of c
+// and logs the ones slower than its threshold. Statements of a request
+// run under its deadline either way, the timeout only ever shortens it.
+//
+// Row statements are left out of the timeout: their rows are read after
+// the callbacks ran, when cancelling would cut them off.
+func registerQueryLimits(db *gorm.DB, c *conf.Data_Database, logger log.Logger) error {
+	timeout := c.GetQueryTimeout().AsDuration()
+	threshold := c.GetSlowQueryThreshold().AsDuration()
+	if timeout <= 0 && threshold <= 0 {
+		return nil
+	}
+
+	before := func(limit bool) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			l := &queryLimit{start: time.Now(), ctx: db.Statement.Context}
+			if limit && timeout > 0 {
+				db.Statement.Context, l.cancel = context.WithTimeout(l.ctx, timeout)
+			}
+			db.InstanceSet(queryLimitKey, l)
+		}
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			v, ok := db.InstanceGet(queryLimitKey)
+			if !ok {
+				return
+			}
+			l := v.(*queryLimit)
+			if l.cancel != nil {
+				l.cancel()
+				// the statement may be run again, e.g. a Count before a Find
+				db.Statement.Context = l.ctx
+			}
+			if took := time.Since(l.start); threshold > 0 && took >= threshold {
+				// the SQL has placeholders, the values may be personal data
+				_ = log.WithContext(l.ctx, logger).Log(log.LevelWarn,
+					"msg", "slow query",
+					"operation", operation,
+					"table", db.Statement.Table,
+					"sql", db.Statement.SQL.String(),
+					"rows", db.Statement.RowsAffected,
+					"latency", took.Seconds(),
+				)
+			}
+		}
+	}
+
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("limits:before_create", before(true)),
+		cb.Create().After("gorm:create").Register("limits:after_create", after("create")),
+		cb.Query().Before("gorm:query").Register("limits:before_query", before(true)),
+		cb.Query().After("gorm:query").Register("limits:after_query", after("query")),
+		cb.Update().Before("gorm:update").Register("limits:before_update", before(true)),
+		cb.Update().After("gorm:update").Register("limits:after_update", after("update")),
+		cb.Delete().Before("gorm:delete").Register("limits:before_delete", before(true)),
+		cb.Delete().After("gorm:delete").Register("limits:after_delete", after("delete")),
+		cb.Row().Before("gorm:row").Register("limits:before_row", before(false)),
+		cb.Row().After("gorm:row").Register("limits:after_row", after("row")),
+		cb.Raw().Before("gorm:raw").Register("limits:before_raw", before(true)),
+		cb.Raw().After("gorm:raw").Register("limits:after_raw", after("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRateLimitRepo returns the store selected by c.RateLimit.Backend.
+func NewRateLimitRepo(data *Data, c *conf.Data) (biz.RateLimitRepo, error) {
+	switch backend := c.GetRateLimit().GetBackend(); backend {
+	case "", "memory":
+		return &rateLimitRepo{buckets: make(map[string]time.Time), now: time.Now}, nil
+	case "redis":
+		if data.rdb == nil {
+			return nil, errors.New("rate limit: the redis backend needs redis to be configured")
+		}
+		return &redisRateLimitRepo{rdb: data.rdb}, nil
+	default:
+		return nil, fmt.Errorf("rate limit: unknown backend %q", backend)
+	}
+}
+
+// rateLimitSweepInterval is how often the in-memory buckets of clients
+// gone quiet are dropped.
+const rateLimitSweepInterval = time.Minute
+
+// rateLimitRepo keeps the buckets of this replica in memory.
+type rateLimitRepo struct {
+	mu        sync.Mutex
+	buckets   map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func (r *rateLimitRepo) Take(_ context.Context, key string, limit biz.RateLimit) (bool, time.Duration, error) {
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.lastSweep) >= rateLimitSweepInterval {
+		// a bucket behind now is full, dropping it changes nothing
+		for k, tat := range r.buckets {
+			if tat.Before(now) {
+				delete(r.buckets, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	next, ok, retryAfter := limit.Take(r.buckets[key], now)
+	if ok {
+		r.buckets[key] = next
+	}
+	return ok, retryAfter, nil
+}
+
+// redisRateLimitKeyPrefix namespaces the buckets in a shared Redis.
+const redisRateLimitKeyPrefix = "customer:ratelimit:"
+
+// takeScript is biz.RateLimit.Take on the bucket KEYS[1] with the
+// emission interval ARGV[1] and burst ARGV[2], both in microseconds. It
+// returns 0 when the request may go through, otherwise the microseconds
+// until it may. The time is Redis', so replicas with skewed clocks agree.
+var takeScript = redis.NewScript(`
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+local next = tat + interval
+local allow_at = next - burst
+if now < allow_at then
+	return allow_at - now
+end
+redis.call("SET", KEYS[1], next, "PX", math.max(1, math.ceil((next - now) / 1000)))
+return 0
+`)
+
+// redisRateLimitRepo shares the buckets between replicas, as keys Redis
+// expires once they are full again.
+type redisRateLimitRepo struct {
+	rdb *redis.Client
+}
+
+func (r *redisRateLimitRepo) Take(ctx context.Context, key string, limit biz.RateLimit) (bool, time.Duration, error) {
+	interval, burst := limit.Emission()
+	wait, err := takeScript.Run(ctx, r.rdb, []string{redisRateLimitKeyPrefix + key},
+		interval.Microseconds(), burst.Microseconds()).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+	if wait > 0 {
+		return false, time.Duration(wait) * time.Microsecond, nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"customer/internal/biz"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRateLimitBackendsAgree(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	memory := &rateLimitRepo{buckets: make(map[string]time.Time), now: func() time.Time { return now }}
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	shared := &redisRateLimitRepo{rdb: rdb}
+
+	limits := map[string]biz.RateLimit{
+		"writes": {Requests: 10, Period: time.Second, Burst: 3},
+		"reads":  {Requests: 100, Period: time.Minute, Burst: 1},
+	}
+	steps := []time.Duration{0, 0, 0, 0, 30 * time.Millisecond, 70 * time.Millisecond, 0, 0, 250 * time.Millisecond, time.Second, 599 * time.Millisecond, time.Millisecond}
+	allowed := 0
+	for i, step := range steps {
+		now = now.Add(step)
+		mr.SetTime(now)
+		for name, limit := range limits {
+			for _, client := range []string{"a", "b"} {
+				key := name + "\x00" + client
+				mOK, mRetry, err := memory.Take(ctx, key, limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				rOK, rRetry, err := shared.Take(ctx, key, limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if mOK != rOK || mRetry != rRetry {
+					t.Errorf("step %d, %s of %s: memory %v, %s, redis %v, %s", i, name, client, mOK, mRetry, rOK, rRetry)
+				}
+				if mOK {
+					allowed++
+				}
+			}
+		}
+	}
+	// per client, writes get the burst of 3, then one at each step from
+	// 100ms on; reads one at 0, 1.35s and 1.95s, 600ms apart at least
+	if want := 2 * (3 + 5 + 3); allowed != want {
+		t.Errorf("allowed %d requests, want %d", allowed, want)
+	}
+}
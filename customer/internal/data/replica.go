@@ -0,0 +1,257 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/peer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	defaultReplicaCheckInterval = 5 * time.Second
+	// how long one replica check may take
+	replicaCheckTimeout = 2 * time.Second
+)
+
+// replicaLagQuery is how far a replica is behind, zero while it has
+// replayed everything it received.
+const replicaLagQuery = `SELECT CASE
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+type replica struct {
+	// replicas are named by position, their DSNs carry credentials
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// replicaSet routes reads over the healthy replicas, checks them in the
+// background and remembers who wrote recently for read-your-writes.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	interval time.Duration
+	maxLag   time.Duration
+	log      *log.Helper
+
+	// window pins callers to the primary after their writes; writes holds
+	// the time of the last write of every caller seen within it
+	window time.Duration
+	mu     sync.Mutex
+	writes map[string]time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newReplicaSet opens the replicas of c and checks them once, so reads
+// only go to replicas known to be up. It returns nil without replicas.
+// Replicas that are down are not an error, they join once they are up.
+func newReplicaSet(c *conf.Data_Database, logger log.Logger) (*replicaSet, error) {
+	if len(c.GetReplicas()) == 0 {
+		return nil, nil
+	}
+	s := &replicaSet{
+		interval: defaultReplicaCheckInterval,
+		maxLag:   c.GetReplicaMaxLag().AsDuration(),
+		log:      log.NewHelper(logger),
+		window:   c.GetReadYourWrites().AsDuration(),
+		writes:   make(map[string]time.Time),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if c.GetReplicaCheckInterval() != nil {
+		s.interval = c.GetReplicaCheckInterval().AsDuration()
+	}
+	for i, dsn := range c.GetReplicas() {
+		// only the connection pool is used, statements run through the
+		// callbacks of the primary
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: gormlogger.Discard})
+		if err != nil {
+			s.closeDBs()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			s.closeDBs()
+			return nil, err
+		}
+		s.replicas = append(s.replicas, &replica{name: fmt.Sprintf("replica %d", i), db: sqlDB})
+		if err := configurePool(db, c); err != nil {
+			s.closeDBs()
+			return nil, err
+		}
+	}
+	s.check()
+	go s.run()
+	return s, nil
+}
+
+// pick returns the next healthy replica, nil when none is.
+func (s *replicaSet) pick() *replica {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := s.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *replicaSet) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+		s.check()
+		s.forgetWrites()
+	}
+}
+
+// check takes the replicas that are down or lag too far behind out of
+// rotation, and puts the others back.
+func (s *replicaSet) check() {
+	for _, r := range s.replicas {
+		err := s.checkReplica(r)
+		switch healthy := err == nil; {
+		case healthy && !r.healthy.Load():
+			s.log.Infof("[replicas] %s is up, reading from it", r.name)
+		case !healthy && r.healthy.Load():
+			s.log.Warnf("[replicas] %s taken out of rotation: %v", r.name, err)
+		case !healthy:
+			s.log.Debugf("[replicas] %s still out of rotation: %v", r.name, err)
+		}
+		r.healthy.Store(err == nil)
+	}
+}
+
+func (s *replicaSet) checkReplica(r *replica) error {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaCheckTimeout)
+	defer cancel()
+	if err := r.db.PingContext(ctx); err != nil {
+		return err
+	}
+	if s.maxLag <= 0 {
+		return nil
+	}
+	var lag float64
+	if err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&lag); err != nil {
+		return err
+	}
+	if d := time.Duration(lag * float64(time.Second)); d > s.maxLag {
+		return fmt.Errorf("lagging %s behind", d.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// close stops the checks and closes the replicas.
+func (s *replicaSet) close() {
+	close(s.stop)
+	<-s.done
+	s.closeDBs()
+}
+
+func (s *replicaSet) closeDBs() {
+	for _, r := range s.replicas {
+		_ = r.db.Close()
+	}
+}
+
+// wrote notes a write by the caller of ctx.
+func (s *replicaSet) wrote(ctx context.Context) {
+	if s.window <= 0 {
+		return
+	}
+	key := callerKey(ctx)
+	if key == "" {
+		return
+	}
+	s.mu.Lock()
+	s.writes[key] = time.Now()
+	s.mu.Unlock()
+}
+
+// pinned tells whether the caller of ctx wrote within the window.
+func (s *replicaSet) pinned(ctx context.Context) bool {
+	if s.window <= 0 {
+		return false
+	}
+	key := callerKey(ctx)
+	if key == "" {
+		return false
+	}
+	s.mu.Lock()
+	t, ok := s.writes[key]
+	s.mu.Unlock()
+	return ok && time.Since(t) < s.window
+}
+
+func (s *replicaSet) forgetWrites() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, t := range s.writes {
+		if time.Since(t) >= s.window {
+			delete(s.writes, key)
+		}
+	}
+}
+
+// callerKey names who made a request for read-your-writes: the
+// authenticated subject, or the host of an anonymous caller, within the
+// tenant. Jobs have no caller and are never pinned.
+func callerKey(ctx context.Context) string {
+	tenant := biz.TenantFromContext(ctx)
+	if subject, ok := biz.SubjectFromContext(ctx); ok {
+		return tenant + "\x00" + subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return tenant + "\x00@" + host
+	}
+	return ""
+}
+
+// registerReadYourWrites notes every successful write to the primary
+// with s.
+func registerReadYourWrites(db *gorm.DB, s *replicaSet) error {
+	after := func(db *gorm.DB) {
+		if db.Error == nil {
+			s.wrote(db.Statement.Context)
+		}
+	}
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().After("gorm:create").Register("replicas:after_create", after),
+		cb.Update().After("gorm:update").Register("replicas:after_update", after),
+		cb.Delete().After("gorm:delete").Register("replicas:after_delete", after),
+		cb.Raw().After("gorm:raw").Register("replicas:after_raw", after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"customer/internal/biz"
+
+	"gorm.io/gorm/clause"
+)
+
+type retentionRepo struct {
+	data *Data
+}
+
+func NewRetentionRepo(data *Data) biz.RetentionRepo {
+	return &retentionRepo{data: data}
+}
+
+func (r *retentionRepo) DeletedCustomers(ctx context.Context, t time.Time, limit int) ([]*biz.PurgeTarget, error) {
+	var targets []*biz.PurgeTarget
+	err := r.data.DB(ctx).
+		Unscoped().
+		Model(&Customer{}).
+		Select("id AS customer_id, tenant_id").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", t).
+		Order("id").
+		Limit(limit).
+		Scan(&targets).Error
+	return targets, err
+}
+
+func (r *retentionRepo) InactiveCustomers(ctx context.Context, t time.Time, limit int) ([]*biz.PurgeTarget, error) {
+	// customers from before updated_at was tracked have NULL and never
+	// match; suspended customers and those awaiting erasure are left to
+	// whoever handles them
+	var targets []*biz.PurgeTarget
+	err := r.data.DB(ctx).
+		Model(&Customer{}).
+		Select("id AS customer_id, tenant_id").
+		Where("COALESCE(last_activity_at, updated_at) < ?", t).
+		Where("status <> ?", int32(biz.CustomerSuspended)).
+		Where("NOT EXISTS (SELECT 1 FROM customer_erasures e WHERE e.customer_id = customers.id AND e.status = ?)", int32(biz.ErasureScheduled)).
+		Order("id").
+		Limit(limit).
+		Scan(&targets).Error
+	return targets, err
+}
+
+func (r *retentionRepo) DeleteCustomers(ctx context.Context, ids []int64) error {
+	return r.data.DB(ctx).Where("id IN ?", ids).Delete(&Customer{}).Error
+}
+
+func (r *retentionRepo) PurgeCustomers(ctx context.Context, ids []int64) error {
+	db := r.data.DB(ctx)
+	for _, model := range []interface{}{&Email{}, &PhoneNumber{}, &Address{}, &CustomerConsent{}} {
+		if err := db.Where("customer_id IN ?", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := db.Where("survivor_id IN ?", ids).Delete(&CustomerMerge{}).Error; err != nil {
+		return err
+	}
+	// the history holds personal data too; events not yet delivered, like
+	// the deletion of an inactive customer, still go out
+	if err := db.Where("customer_id IN ?", ids).Delete(&CustomerChange{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("customer_id IN ? AND published_at IS NOT NULL", ids).Delete(&OutboxEvent{}).Error; err != nil {
+		return err
+	}
+	return db.Unscoped().Where("id IN ?", ids).Delete(&Customer{}).Error
+}
+
+func (r *retentionRepo) PurgeUnverifiedEmails(ctx context.Context, t time.Time, limit int) ([]*biz.PurgedContact, error) {
+	var models []Email
+	err := r.data.DB(ctx).
+		Where("verified_at IS NULL AND created_at < ?", t).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return nil, err
+	}
+
+	out := make([]*biz.PurgedContact, 0, len(models))
+	ids := make([]int64, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.PurgedContact{TenantID: m.TenantID, CustomerID: m.CustomerID, Value: m.Email})
+		ids = append(ids, m.ID)
+	}
+	return out, r.data.DB(ctx).Delete(&Email{}, ids).Error
+}
+
+func (r *retentionRepo) PurgeUnverifiedPhoneNumbers(ctx context.Context, t time.Time, limit int) ([]*biz.PurgedContact, error) {
+	var models []PhoneNumber
+	err := r.data.DB(ctx).
+		Where("verified_at IS NULL AND created_at < ?", t).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return nil, err
+	}
+
+	out := make([]*biz.PurgedContact, 0, len(models))
+	ids := make([]int64, 0, len(models))
+	for _, m := range models {
+		if err := r.data.fields.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+			return nil, err
+		}
+		out = append(out, &biz.PurgedContact{TenantID: m.TenantID, CustomerID: m.CustomerID, Value: m.PhoneNumber})
+		ids = append(ids, m.ID)
+	}
+	return out, r.data.DB(ctx).Delete(&PhoneNumber{}, ids).Error
+}
+
+// JobLease is held by the replica running a job. A lease whose holder
+// stopped renewing it expires and can be taken over.
+type JobLease struct {
+	Name      string `gorm:"primaryKey"`
+	Holder    string
+	ExpiresAt time.Time
+}
+
+type leaseRepo struct {
+	data *Data
+}
+
+func NewLeaseRepo(data *Data) biz.LeaseRepo {
+	return &leaseRepo{data: data}
+}
+
+func (r *leaseRepo) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	res := r.data.DB(ctx).
+		Model(&JobLease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": now.Add(ttl)})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// first use of the lease
+	res = r.data.DB(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&JobLease{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *leaseRepo) Release(ctx context.Context, name, holder string) error {
+	return r.data.DB(ctx).Where("name = ? AND holder = ?", name, holder).Delete(&JobLease{}).Error
+}
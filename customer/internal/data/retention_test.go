@@ -0,0 +1,64 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestPurgeUnverifiedContacts(t *testing.T) {
+	d := newTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+
+	if _, err := s.VerifyEmail(f.acme, &pb.VerifyEmailReq{CustomerId: f.acmeID, Email: fixtureEmail}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.VerifyEmail(f.globex, &pb.VerifyEmailReq{CustomerId: f.acmeID, Email: fixtureEmail}); err == nil {
+		t.Error("VerifyEmail verified an email of another tenant")
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	for _, model := range []interface{}{&Email{}, &PhoneNumber{}} {
+		if err := d.db.Model(model).Where("1 = 1").Update("created_at", old).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// added within the period, kept although not verified yet
+	if _, err := s.AddEmail(f.acme, &pb.AddEmailReq{CustomerId: f.acmeID, Email: "new@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	uc := biz.NewRetentionUsecase(
+		&conf.Data{Retention: &conf.Data_Retention{UnverifiedContacts: durationpb.New(24 * time.Hour)}},
+		NewCustomerRepo(d), NewOutboxRepo(d), NewRetentionRepo(d), NewLeaseRepo(d), log.DefaultLogger,
+	)
+	// batches of one make the purge go through several of them
+	sum, err := uc.Purge(f.acme, "test", 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.UnverifiedEmails != 1 || sum.UnverifiedPhoneNumbers != 2 {
+		t.Errorf("purged %d emails and %d phone numbers, want 1 and 2", sum.UnverifiedEmails, sum.UnverifiedPhoneNumbers)
+	}
+
+	repo := NewCustomerRepo(d)
+	if emails, err := repo.ListEmails(f.acme, f.acmeID); err != nil || len(emails) != 2 {
+		t.Errorf("acme emails = %v, %v, want the verified and the new one", emails, err)
+	}
+	if emails, err := repo.ListEmails(f.globex, f.globexID); err != nil || len(emails) != 0 {
+		t.Errorf("globex emails = %v, %v, want none", emails, err)
+	}
+	var removed int64
+	err = d.db.Model(&OutboxEvent{}).
+		Where("type IN ?", []string{"api.customer.v1.EmailRemoved", "api.customer.v1.PhoneNumberRemoved"}).
+		Count(&removed).Error
+	if err != nil || removed != 3 {
+		t.Errorf("%d removal events, %v, want 3", removed, err)
+	}
+}
@@ -0,0 +1,474 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// rotatedRow is a row re-encrypted in memory, with the updates that
+// store it.
+type rotatedRow struct {
+	id      int64
+	keyID   string
+	updates map[string]interface{}
+}
+
+// rotation re-encrypts the rows of one table, whose primary key is pk.
+// next reads the rows db selects and encrypts them again.
+type rotation struct {
+	table string
+	pk    string
+	next  func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error)
+}
+
+// RotateKeys re-encrypts every row, of all tenants, that is not encrypted
+// with the current key and recomputes its blind index. Plain text rows
+// written before encryption was enabled are encrypted too. It works in
+// transactions of batchSize rows and calls report after each of them.
+func (d *Data) RotateKeys(ctx context.Context, batchSize int, report func(table string, rotated int)) error {
+	if !d.fields.enabled() {
+		return errors.New("encryption is not configured")
+	}
+	return d.reencrypt(ctx, d.fields, "key_id <> ?", d.fields.keys.CurrentKeyID(), batchSize, report)
+}
+
+// reencrypt re-encrypts the rows matching where with f, see RotateKeys.
+func (d *Data) reencrypt(ctx context.Context, f *fieldCipher, where string, arg interface{}, batchSize int, report func(table string, rotated int)) error {
+	for _, r := range d.rotations(f) {
+		var lastID int64
+		for {
+			db := d.db.WithContext(ctx).Where(where, arg).Where(r.pk+" > ?", lastID).Order(r.pk).Limit(batchSize)
+			rows, err := r.next(ctx, db)
+			if err != nil {
+				return fmt.Errorf("%s: %w", r.table, err)
+			}
+			if len(rows) == 0 {
+				break
+			}
+
+			err = d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				for _, row := range rows {
+					// a row written since it was read keeps its new value
+					err := tx.Table(r.table).
+						Where(r.pk+" = ? AND key_id = ?", row.id, row.keyID).
+						Updates(row.updates).Error
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("%s: %w", r.table, err)
+			}
+			report(r.table, len(rows))
+			lastID = rows[len(rows)-1].id
+		}
+	}
+	return nil
+}
+
+// rotations are the rotations of every table with encrypted values, with
+// f decrypting and encrypting them.
+func (d *Data) rotations(f *fieldCipher) []rotation {
+	return []rotation{
+		{"customers", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			// deleted customers are encrypted too until they are purged
+			var models []Customer
+			if err := db.Unscoped().Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				row := rotatedRow{id: m.ID, keyID: m.KeyID}
+				if err := f.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				keyID, err := f.encrypt(ctx, m.binding(), m.encrypted()...)
+				if err != nil {
+					return nil, err
+				}
+				row.updates = map[string]interface{}{"name": m.Name, "date_of_birth": m.DateOfBirth, "key_id": keyID}
+				out = append(out, row)
+			}
+			return out, nil
+		}},
+		{"emails", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			var models []Email
+			if err := db.Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				row := rotatedRow{id: m.ID, keyID: m.KeyID}
+				if err := f.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				hash := f.blindIndex("email", m.Email)
+				keyID, err := f.encrypt(ctx, m.binding(), m.encrypted()...)
+				if err != nil {
+					return nil, err
+				}
+				row.updates = map[string]interface{}{"email": m.Email, "email_hash": hash, "key_id": keyID}
+				out = append(out, row)
+			}
+			return out, nil
+		}},
+		{"phone_numbers", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			var models []PhoneNumber
+			if err := db.Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				row := rotatedRow{id: m.ID, keyID: m.KeyID}
+				if err := f.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				hash := f.blindIndex("phone_number", m.PhoneNumber)
+				keyID, err := f.encrypt(ctx, m.binding(), m.encrypted()...)
+				if err != nil {
+					return nil, err
+				}
+				row.updates = map[string]interface{}{"phone_number": m.PhoneNumber, "phone_number_hash": hash, "key_id": keyID}
+				out = append(out, row)
+			}
+			return out, nil
+		}},
+		{"addresses", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			var models []Address
+			if err := db.Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				row := rotatedRow{id: m.ID, keyID: m.KeyID}
+				if err := f.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				hash := f.blindIndex("address", m.Address)
+				keyID, err := f.encrypt(ctx, m.binding(), m.encrypted()...)
+				if err != nil {
+					return nil, err
+				}
+				row.updates = map[string]interface{}{"address": m.Address, "address_hash": hash, "key_id": keyID}
+				out = append(out, row)
+			}
+			return out, nil
+		}},
+		{"customer_consents", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			// consents on a whole channel have nothing to encrypt
+			var models []CustomerConsent
+			if err := db.Where("contact <> ''").Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				row := rotatedRow{id: m.ID, keyID: m.KeyID}
+				if err := f.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				hash := f.blindIndex("contact", m.Contact)
+				keyID, err := f.encrypt(ctx, m.binding(), m.encrypted()...)
+				if err != nil {
+					return nil, err
+				}
+				row.updates = map[string]interface{}{"contact": m.Contact, "contact_hash": hash, "key_id": keyID}
+				out = append(out, row)
+			}
+			return out, nil
+		}},
+		{"customer_merges", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			var models []CustomerMerge
+			if err := db.Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				row := rotatedRow{id: m.ID, keyID: m.KeyID}
+				if err := f.decrypt(ctx, m.KeyID, m.binding(), m.encrypted()...); err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				keyID, err := f.encrypt(ctx, m.binding(), m.encrypted()...)
+				if err != nil {
+					return nil, err
+				}
+				row.updates = map[string]interface{}{"name": m.Name, "date_of_birth": m.DateOfBirth, "key_id": keyID}
+				// the lists are stored the way their json serializer writes them
+				for column, list := range map[string][]string{"emails": m.Emails, "phone_numbers": m.PhoneNumbers, "addresses": m.Addresses} {
+					b, err := json.Marshal(list)
+					if err != nil {
+						return nil, err
+					}
+					row.updates[column] = string(b)
+				}
+				out = append(out, row)
+			}
+			return out, nil
+		}},
+		{"outbox_events", "id", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			var models []OutboxEvent
+			if err := db.Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				payload, err := f.decryptBytes(ctx, m.KeyID, eventBinding(m.TenantID, m.CustomerID), "payload", m.Payload)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.ID, err)
+				}
+				sealed, keyID, err := f.encryptBytes(ctx, eventBinding(m.TenantID, m.CustomerID), "payload", payload)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, rotatedRow{id: m.ID, keyID: m.KeyID, updates: map[string]interface{}{"payload": sealed, "key_id": keyID}})
+			}
+			return out, nil
+		}},
+		{"customer_changes", "sequence", func(ctx context.Context, db *gorm.DB) ([]rotatedRow, error) {
+			var models []CustomerChange
+			if err := db.Find(&models).Error; err != nil {
+				return nil, err
+			}
+			out := make([]rotatedRow, 0, len(models))
+			for _, m := range models {
+				payload, err := f.decryptBytes(ctx, m.KeyID, eventBinding(m.TenantID, m.CustomerID), "payload", m.Payload)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", m.Sequence, err)
+				}
+				sealed, keyID, err := f.encryptBytes(ctx, eventBinding(m.TenantID, m.CustomerID), "payload", payload)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, rotatedRow{id: m.Sequence, keyID: m.KeyID, updates: map[string]interface{}{"payload": sealed, "key_id": keyID}})
+			}
+			return out, nil
+		}},
+	}
+}
+
+// backfillBlindIndexes adds the blind index columns to tables created
+// before they existed and fills them from the plain text values, so the
+// unique indexes on them can be built.
+func backfillBlindIndexes(db *gorm.DB, fields *fieldCipher) error {
+	for _, t := range []struct {
+		model         interface{}
+		table, column string
+	}{
+		{&Email{}, "emails", "email"},
+		{&PhoneNumber{}, "phone_numbers", "phone_number"},
+		{&Address{}, "addresses", "address"},
+	} {
+		m := db.Migrator()
+		hash := t.column + "_hash"
+		if !m.HasTable(t.model) || m.HasColumn(t.model, hash) {
+			continue
+		}
+		if err := m.AddColumn(t.model, hash); err != nil {
+			return err
+		}
+
+		var lastID int64
+		for {
+			var rows []struct {
+				ID    int64
+				Value string
+			}
+			err := db.Table(t.table).
+				Select("id, "+t.column+" AS value").
+				Where("id > ?", lastID).
+				Order("id").
+				Limit(500).
+				Scan(&rows).Error
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			for _, row := range rows {
+				if err := db.Table(t.table).Where("id = ?", row.ID).Update(hash, fields.blindIndex(t.column, row.Value)).Error; err != nil {
+					return err
+				}
+			}
+			lastID = rows[len(rows)-1].ID
+		}
+	}
+	return nil
+}
+
+// BlindIndexScheme records which hash the blind indexes of plain text rows
+// were computed with. It has a single row.
+type BlindIndexScheme struct {
+	ID          int64 `gorm:"primaryKey"`
+	Fingerprint string
+}
+
+// rehashBlindIndexes recomputes the blind indexes of the plain text rows
+// when the hash changed since they were written, which is the case when
+// encryption is enabled: rows written before hold unkeyed hashes, exact
+// lookups would miss them and their values could be added a second
+// time. Encrypted rows are rehashed by RotateKeys.
+func rehashBlindIndexes(db *gorm.DB, fields *fieldCipher) error {
+	// a hash of nothing tells the schemes apart without storing the key
+	fingerprint := fields.blindIndex("blind_index", "")
+	var scheme BlindIndexScheme
+	if err := db.Limit(1).Find(&scheme).Error; err != nil {
+		return err
+	}
+	if scheme.Fingerprint == fingerprint {
+		return nil
+	}
+
+	for _, t := range []struct {
+		table, column, hash, where string
+	}{
+		{"emails", "email", "email_hash", ""},
+		{"phone_numbers", "phone_number", "phone_number_hash", ""},
+		{"addresses", "address", "address_hash", ""},
+		{"customer_consents", "contact", "contact_hash", "contact <> ''"},
+	} {
+		var lastID int64
+		for {
+			var rows []struct {
+				ID    int64
+				Value string
+				Hash  string
+			}
+			q := db.Table(t.table).
+				Select("id, "+t.column+" AS value, "+t.hash+" AS hash").
+				Where("key_id = '' AND id > ?", lastID)
+			if t.where != "" {
+				q = q.Where(t.where)
+			}
+			if err := q.Order("id").Limit(500).Scan(&rows).Error; err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			for _, row := range rows {
+				hash := fields.blindIndex(t.column, row.Value)
+				if hash == row.Hash {
+					continue
+				}
+				if err := db.Table(t.table).Where("id = ?", row.ID).Update(t.hash, hash).Error; err != nil {
+					return fmt.Errorf("%s row %d: %w", t.table, row.ID, err)
+				}
+			}
+			lastID = rows[len(rows)-1].ID
+		}
+	}
+
+	scheme.ID = 1
+	scheme.Fingerprint = fingerprint
+	return db.Save(&scheme).Error
+}
+
+// CiphertextScheme records that the encrypted values are bound to where
+// they are stored, see binding. It has a single row.
+type CiphertextScheme struct {
+	ID    int64 `gorm:"primaryKey"`
+	Bound bool
+}
+
+// bindCiphertexts encrypts the values sealed before ciphertexts were
+// bound to their rows again, bound this time. It reads both kinds of
+// values, so a run that was interrupted is simply started over.
+func bindCiphertexts(ctx context.Context, d *Data) error {
+	if !d.fields.enabled() {
+		return nil
+	}
+	var scheme CiphertextScheme
+	if err := d.db.WithContext(ctx).Limit(1).Find(&scheme).Error; err != nil {
+		return err
+	}
+	if scheme.Bound {
+		return nil
+	}
+
+	legacy := newFieldCipher(d.fields.keys)
+	legacy.legacy = true
+	if err := d.reencrypt(ctx, legacy, "key_id <> ?", "", 500, func(string, int) {}); err != nil {
+		return err
+	}
+	if err := bindResponses(ctx, d, legacy); err != nil {
+		return fmt.Errorf("idempotency_keys: %w", err)
+	}
+
+	scheme.ID = 1
+	scheme.Bound = true
+	return d.db.WithContext(ctx).Save(&scheme).Error
+}
+
+// bindResponses binds the stored idempotent responses, which are keyed by
+// string and left out of the rotations.
+func bindResponses(ctx context.Context, d *Data, legacy *fieldCipher) error {
+	reseal := func(key string, sealed []byte, keyID string) ([]byte, string, error) {
+		response, err := openResponse(ctx, legacy, key, sealed, keyID)
+		if err != nil {
+			return nil, "", err
+		}
+		return sealResponse(ctx, d.fields, key, response)
+	}
+
+	var lastKey string
+	for {
+		var models []IdempotencyKey
+		err := d.db.WithContext(ctx).
+			Where("key_id <> '' AND response IS NOT NULL AND key > ?", lastKey).
+			Order("key").
+			Limit(500).
+			Find(&models).Error
+		if err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			break
+		}
+		for _, m := range models {
+			response, keyID, err := reseal(m.Key, m.Response, m.KeyID)
+			if err != nil {
+				return fmt.Errorf("key %q: %w", m.Key, err)
+			}
+			err = d.db.WithContext(ctx).
+				Model(&IdempotencyKey{}).
+				Where("key = ? AND key_id = ?", m.Key, m.KeyID).
+				Updates(map[string]interface{}{"response": response, "key_id": keyID}).Error
+			if err != nil {
+				return err
+			}
+		}
+		lastKey = models[len(models)-1].Key
+	}
+
+	if d.rdb == nil {
+		return nil
+	}
+	iter := d.rdb.Scan(ctx, 0, redisIdempotencyKeyPrefix+"*", 500).Iterator()
+	for iter.Next(ctx) {
+		v, err := d.rdb.HMGet(ctx, iter.Val(), "r", "k").Result()
+		if err != nil {
+			return err
+		}
+		sealed, _ := v[0].(string)
+		keyID, _ := v[1].(string)
+		if sealed == "" || keyID == "" {
+			continue
+		}
+		key := strings.TrimPrefix(iter.Val(), redisIdempotencyKeyPrefix)
+		response, keyID, err := reseal(key, []byte(sealed), keyID)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+		if err := rebindScript.Run(ctx, d.rdb, []string{iter.Val()}, v[1], sealed, response, keyID).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
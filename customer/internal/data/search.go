@@ -0,0 +1,140 @@
+package data
+
+import (
+	"context"
+	"sort"
+
+	"customer/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// searchIndexes back the Postgres search query. pg_trgm serves the typo
+// tolerant word_similarity matching, the tsvector indexes whole words.
+var searchIndexes = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS idx_customers_name_trgm ON customers USING gin (lower(name) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_customers_name_fts ON customers USING gin (to_tsvector('simple', name))`,
+	`CREATE INDEX IF NOT EXISTS idx_emails_email_trgm ON emails USING gin (lower(email) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_phone_numbers_digits_trgm ON phone_numbers USING gin ((regexp_replace(phone_number, '\D', '', 'g')) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_addresses_address_trgm ON addresses USING gin (lower(address) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_addresses_address_fts ON addresses USING gin (to_tsvector('simple', address))`,
+}
+
+// searchQuery ranks the customers of a tenant by their best matching
+// field. Deleted customers are left out.
+const searchQuery = `
+SELECT m.customer_id, MAX(m.score) AS score FROM (
+    SELECT id AS customer_id,
+        word_similarity(@q, lower(name)) + ts_rank(to_tsvector('simple', name), plainto_tsquery('simple', @q)) AS score
+    FROM customers
+    WHERE tenant_id = @tenant
+        AND (@q <% lower(name) OR to_tsvector('simple', name) @@ plainto_tsquery('simple', @q))
+    UNION ALL
+    SELECT customer_id, word_similarity(@q, lower(email))
+    FROM emails
+    WHERE tenant_id = @tenant AND @q <% lower(email)
+    UNION ALL
+    SELECT customer_id, word_similarity(@digits, regexp_replace(phone_number, '\D', '', 'g'))
+    FROM phone_numbers
+    WHERE tenant_id = @tenant AND @digits <> '' AND @digits <% regexp_replace(phone_number, '\D', '', 'g')
+    UNION ALL
+    SELECT customer_id,
+        word_similarity(@q, lower(address)) + ts_rank(to_tsvector('simple', address), plainto_tsquery('simple', @q))
+    FROM addresses
+    WHERE tenant_id = @tenant
+        AND (@q <% lower(address) OR to_tsvector('simple', address) @@ plainto_tsquery('simple', @q))
+) m
+JOIN customers c ON c.id = m.customer_id AND c.deleted_at IS NULL
+GROUP BY m.customer_id
+ORDER BY score DESC, m.customer_id
+LIMIT @limit OFFSET @offset`
+
+type searchRepo struct {
+	data *Data
+	log  *log.Helper
+	// indexed is set when the Postgres indexes are in place, other
+	// drivers and encrypted columns use the fallback scan.
+	indexed bool
+}
+
+func NewSearchRepo(data *Data, logger log.Logger) biz.SearchRepo {
+	r := &searchRepo{data: data, log: log.NewHelper(logger)}
+	if data.db.Dialector.Name() == "postgres" && !data.fields.enabled() {
+		r.indexed = true
+		for _, stmt := range searchIndexes {
+			if err := data.db.Exec(stmt).Error; err != nil {
+				r.log.Warnf("search index not created, falling back to scanning: %v", err)
+				r.indexed = false
+				break
+			}
+		}
+	}
+	return r
+}
+
+func (r *searchRepo) Search(ctx context.Context, query string, offset, limit int) ([]*biz.SearchMatch, error) {
+	if !r.indexed {
+		return r.scan(ctx, query, offset, limit)
+	}
+
+	var rows []struct {
+		CustomerID int64
+		Score      float64
+	}
+	err := r.data.DB(ctx).Raw(searchQuery, map[string]interface{}{
+		"q":      query,
+		"tenant": biz.TenantFromContext(ctx),
+		"digits": digitsOf(query),
+		"limit":  limit,
+		"offset": offset,
+	}).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*biz.SearchMatch, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, &biz.SearchMatch{CustomerID: row.CustomerID, Score: row.Score})
+	}
+	return out, nil
+}
+
+// scan is the search of drivers without trigram support and of encrypted
+// columns: it scores every customer of the tenant in Go, reading and
+// decrypting all of them.
+func (r *searchRepo) scan(ctx context.Context, query string, offset, limit int) ([]*biz.SearchMatch, error) {
+	var out []*biz.SearchMatch
+	repo := &customerRepo{data: r.data}
+	err := repo.IterateCustomers(ctx, nil, 500, func(customers []*biz.Customer) error {
+		for _, c := range customers {
+			if score, _ := biz.MatchCustomer(query, c); score > 0 {
+				out = append(out, &biz.SearchMatch{CustomerID: c.ID, Score: score})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if offset >= len(out) {
+		return nil, nil
+	}
+	out = out[offset:]
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func digitsOf(s string) string {
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			b = append(b, s[i])
+		}
+	}
+	return string(b)
+}
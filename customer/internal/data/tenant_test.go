@@ -0,0 +1,456 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+	"customer/internal/conf"
+	"customer/internal/service"
+
+	"github.com/glebarez/sqlite"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestData opens a fresh SQLite database with the schema of NewData.
+func newTestData(t *testing.T) *Data {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "customer.db") + "?_pragma=busy_timeout(5000)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.AutoMigrate(
+		&Customer{},
+		&Email{},
+		&PhoneNumber{},
+		&Address{},
+		&OutboxEvent{},
+		&CustomerChange{},
+		&CustomerMerge{},
+		&CustomerDataExport{},
+		&CustomerErasure{},
+		&JobLease{},
+		&CustomerConsent{},
+		&IdempotencyKey{},
+		&IdempotencyKeyCustomer{},
+		&BlindIndexScheme{},
+		&CiphertextScheme{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Data{db: db, fields: &fieldCipher{}}
+}
+
+func newTestService(d *Data) *service.CustomerService {
+	repo := NewCustomerRepo(d)
+	outbox := NewOutboxRepo(d)
+	merges := NewMergeRepo(d)
+	consents := NewConsentRepo(d)
+	uc := biz.NewCustomerUsecase(repo, outbox, merges, NewSearchRepo(d, log.DefaultLogger), NewChangeFeedRepo(d), NewDataExportRepo(d), consents, NewErasureRepo(d), biz.NewRuleEngine())
+	erasures := biz.NewErasureUsecase(&conf.Data{}, repo, outbox, merges, NewErasureRepo(d), consents, &idempotencyRepo{data: d}, log.DefaultLogger)
+	return service.NewCustomerService(uc, biz.NewChangeFeed(NewChangeFeedRepo(d)), erasures)
+}
+
+// tenantFixture is a customer of tenant "acme" and one of tenant
+// "globex", created with the same contact points: emails and phone
+// numbers are only unique within a tenant.
+type tenantFixture struct {
+	acme, globex     context.Context
+	acmeID, globexID int64
+}
+
+const (
+	fixtureEmail   = "jane@example.com"
+	fixturePhone   = "+4915112345678"
+	fixtureAddress = "Main Street 1, Berlin"
+)
+
+func newTenantFixture(t *testing.T, s *service.CustomerService) *tenantFixture {
+	t.Helper()
+	f := &tenantFixture{
+		acme:   biz.NewTenantContext(context.Background(), "acme"),
+		globex: biz.NewTenantContext(context.Background(), "globex"),
+	}
+	for _, c := range []struct {
+		ctx  context.Context
+		name string
+		id   *int64
+	}{
+		{f.acme, "Jane Acme", &f.acmeID},
+		{f.globex, "Jane Globex", &f.globexID},
+	} {
+		reply, err := s.CreateCustomerWithDetails(c.ctx, &pb.CreateCustomerWithDetailsReq{
+			Name:        c.name,
+			DateOfBirth: "1990-01-01",
+			Email:       fixtureEmail,
+			PhoneNumber: fixturePhone,
+			Address:     fixtureAddress,
+		})
+		if err != nil {
+			t.Fatalf("create customer of %s: %v", biz.TenantFromContext(c.ctx), err)
+		}
+		*c.id = reply.Id
+	}
+	return f
+}
+
+// assertUntouched fails unless the acme customer is still as created,
+// with the one consent it was granted.
+func (f *tenantFixture) assertUntouched(t *testing.T, s *service.CustomerService) {
+	t.Helper()
+	c, err := s.GetCustomer(f.acme, &pb.GetCustomerReq{Id: f.acmeID})
+	if err != nil {
+		t.Fatalf("acme customer gone: %v", err)
+	}
+	if c.Name != "Jane Acme" || c.Status != pb.CustomerStatus_CUSTOMER_STATUS_PROSPECT {
+		t.Errorf("acme customer changed: name %q, status %s", c.Name, c.Status)
+	}
+	emails, err := s.ListEmail(f.acme, &pb.ListEmailReq{CustomerId: f.acmeID})
+	if err != nil || len(emails.Emails) != 1 {
+		t.Errorf("acme emails changed: %v, %v", emails, err)
+	}
+	phones, err := s.ListPhoneNumber(f.acme, &pb.ListPhoneNumberReq{CustomerId: f.acmeID})
+	if err != nil || len(phones.PhoneNumbers) != 1 {
+		t.Errorf("acme phone numbers changed: %v, %v", phones, err)
+	}
+	addresses, err := s.ListAddress(f.acme, &pb.ListAddressReq{CustomerId: f.acmeID})
+	if err != nil || len(addresses.Addresses) != 1 {
+		t.Errorf("acme addresses changed: %v, %v", addresses, err)
+	}
+	consents, err := s.ListConsents(f.acme, &pb.ListConsentsReq{CustomerId: f.acmeID, History: true})
+	if err != nil || len(consents.Consents) != 1 {
+		t.Errorf("acme consents changed: %v, %v", consents, err)
+	}
+}
+
+func TestTenantIsolationRepos(t *testing.T) {
+	d := newTestData(t)
+	f := newTenantFixture(t, newTestService(d))
+	repo := NewCustomerRepo(d)
+
+	if _, err := repo.GetCustomer(f.globex, f.acmeID); err == nil {
+		t.Error("GetCustomer read a customer of another tenant")
+	}
+	if c, err := repo.GetCustomerByEmail(f.globex, fixtureEmail); err != nil || c == nil || c.ID != f.globexID {
+		t.Errorf("GetCustomerByEmail = %v, %v, want the globex customer", c, err)
+	}
+	if c, err := repo.GetCustomerByPhoneNumber(f.globex, fixturePhone); err != nil || c == nil || c.ID != f.globexID {
+		t.Errorf("GetCustomerByPhoneNumber = %v, %v, want the globex customer", c, err)
+	}
+	if _, err := repo.GetCustomerByEmail(biz.NewTenantContext(context.Background(), "initech"), fixtureEmail); err == nil {
+		t.Error("GetCustomerByEmail found a customer of another tenant")
+	}
+	if _, err := repo.GetCustomerByPhoneNumber(biz.NewTenantContext(context.Background(), "initech"), fixturePhone); err == nil {
+		t.Error("GetCustomerByPhoneNumber found a customer of another tenant")
+	}
+	list, err := repo.ListCustomer(f.globex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != f.globexID {
+		t.Errorf("ListCustomer returned %d customers, want only the globex one", len(list))
+	}
+	found, err := repo.GetCustomersByKeys(f.globex, []*biz.CustomerKey{{ID: f.acmeID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found[0] != nil {
+		t.Error("GetCustomersByKeys found a customer of another tenant")
+	}
+	for name, list := range map[string]func(context.Context, int64) ([]string, error){
+		"ListEmails":       repo.ListEmails,
+		"ListPhoneNumbers": repo.ListPhoneNumbers,
+		"ListAddresses":    repo.ListAddresses,
+	} {
+		if values, err := list(f.globex, f.acmeID); err != nil || len(values) != 0 {
+			t.Errorf("%s = %v, %v for a customer of another tenant", name, values, err)
+		}
+	}
+
+	if err := repo.UpdateCustomer(f.globex, &biz.Customer{ID: f.acmeID, Name: "Mallory"}); err == nil {
+		t.Error("UpdateCustomer updated a customer of another tenant")
+	}
+	if err := repo.DeleteCustomer(f.globex, f.acmeID); err == nil {
+		t.Error("DeleteCustomer deleted a customer of another tenant")
+	}
+	ok, err := repo.SetCustomerStatus(f.globex, &biz.Customer{ID: f.acmeID, Status: biz.CustomerClosed, StatusReason: "x"}, biz.CustomerProspect)
+	if err != nil || ok {
+		t.Errorf("SetCustomerStatus = %v, %v for a customer of another tenant", ok, err)
+	}
+	if err := repo.DeleteEmail(f.globex, f.acmeID, fixtureEmail); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.DeletePhoneNumber(f.globex, f.acmeID, fixturePhone); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.DeleteAddress(f.globex, f.acmeID, fixtureAddress); err != nil {
+		t.Fatal(err)
+	}
+
+	consents := NewConsentRepo(d)
+	err = consents.Record(f.acme, &biz.Consent{CustomerID: f.acmeID, Channel: biz.ConsentChannelEmail, Purpose: "newsletter", Status: biz.ConsentGranted, PolicyVersion: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history, err := consents.List(f.globex, []int64{f.acmeID}); err != nil || len(history) != 0 {
+		t.Errorf("consent List = %v, %v for a customer of another tenant", history, err)
+	}
+	if ids, err := consents.Granted(f.globex, "newsletter", biz.ConsentChannelEmail, 0, 10); err != nil || len(ids) != 0 {
+		t.Errorf("consent Granted = %v, %v, want no customers of another tenant", ids, err)
+	}
+
+	if err := d.db.Create(&CustomerChange{EventID: 1, TenantID: "acme", CustomerID: f.acmeID, EventType: "api.customer.v1.CustomerUpdated"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := NewChangeFeedRepo(d).After(f.globex, 0, nil, 10); err != nil || len(changes) != 0 {
+		t.Errorf("change feed After = %d changes, %v, want none of another tenant", len(changes), err)
+	}
+
+	c, err := repo.GetCustomer(f.acme, f.acmeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Jane Acme" || c.Status != biz.CustomerProspect {
+		t.Errorf("acme customer changed: name %q, status %s", c.Name, c.Status)
+	}
+	for name, list := range map[string]func(context.Context, int64) ([]string, error){
+		"ListEmails":       repo.ListEmails,
+		"ListPhoneNumbers": repo.ListPhoneNumbers,
+		"ListAddresses":    repo.ListAddresses,
+	} {
+		if values, err := list(f.acme, f.acmeID); err != nil || len(values) != 1 {
+			t.Errorf("%s = %v, %v, want the acme contact point back", name, values, err)
+		}
+	}
+}
+
+func TestTenantIsolationService(t *testing.T) {
+	d := newTestData(t)
+	s := newTestService(d)
+	f := newTenantFixture(t, s)
+	ctx := f.globex
+	_, err := s.GrantConsent(f.acme, &pb.GrantConsentReq{CustomerId: f.acmeID, Channel: pb.ConsentChannel_CONSENT_CHANNEL_EMAIL, Purpose: "newsletter", PolicyVersion: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mustFail := map[string]func() error{
+		"GetCustomer": func() error {
+			_, err := s.GetCustomer(ctx, &pb.GetCustomerReq{Id: f.acmeID})
+			return err
+		},
+		"UpdateCustomer": func() error {
+			_, err := s.UpdateCustomer(ctx, &pb.UpdateCustomerReq{Id: f.acmeID, Name: "Mallory"})
+			return err
+		},
+		"DeleteCustomer": func() error {
+			_, err := s.DeleteCustomer(ctx, &pb.DeleteCustomerReq{Id: f.acmeID})
+			return err
+		},
+		"AddEmail": func() error {
+			_, err := s.AddEmail(ctx, &pb.AddEmailReq{CustomerId: f.acmeID, Email: "mallory@example.com"})
+			return err
+		},
+		"AddPhoneNumber": func() error {
+			_, err := s.AddPhoneNumber(ctx, &pb.AddPhoneNumberReq{CustomerId: f.acmeID, PhoneNumber: "+4915199999999"})
+			return err
+		},
+		"AddAddress": func() error {
+			_, err := s.AddAddress(ctx, &pb.AddAddressReq{CustomerId: f.acmeID, Address: "Elsewhere 2, Hamburg"})
+			return err
+		},
+		"DeleteEmail": func() error {
+			_, err := s.DeleteEmail(ctx, &pb.DeleteEmailReq{CustomerId: f.acmeID, Email: fixtureEmail})
+			return err
+		},
+		"DeletePhoneNumber": func() error {
+			_, err := s.DeletePhoneNumber(ctx, &pb.DeletePhoneNumberReq{CustomerId: f.acmeID, PhoneNumber: fixturePhone})
+			return err
+		},
+		"DeleteAddress": func() error {
+			_, err := s.DeleteAddress(ctx, &pb.DeleteAddressReq{CustomerId: f.acmeID, Address: fixtureAddress})
+			return err
+		},
+		"VerifyEmail": func() error {
+			_, err := s.VerifyEmail(ctx, &pb.VerifyEmailReq{CustomerId: f.acmeID, Email: fixtureEmail})
+			return err
+		},
+		"VerifyPhoneNumber": func() error {
+			_, err := s.VerifyPhoneNumber(ctx, &pb.VerifyPhoneNumberReq{CustomerId: f.acmeID, PhoneNumber: fixturePhone})
+			return err
+		},
+		"MergeCustomers into own": func() error {
+			_, err := s.MergeCustomers(ctx, &pb.MergeCustomersReq{SurvivorId: f.globexID, DuplicateIds: []int64{f.acmeID}})
+			return err
+		},
+		"MergeCustomers into other": func() error {
+			_, err := s.MergeCustomers(ctx, &pb.MergeCustomersReq{SurvivorId: f.acmeID, DuplicateIds: []int64{f.globexID}})
+			return err
+		},
+		"ExportCustomerData": func() error {
+			_, err := s.ExportCustomerData(ctx, &pb.ExportCustomerDataReq{Id: f.acmeID})
+			return err
+		},
+		"EraseCustomer": func() error {
+			_, err := s.EraseCustomer(ctx, &pb.EraseCustomerReq{Id: f.acmeID, Reason: "request"})
+			return err
+		},
+		"GrantConsent": func() error {
+			_, err := s.GrantConsent(ctx, &pb.GrantConsentReq{CustomerId: f.acmeID, Channel: pb.ConsentChannel_CONSENT_CHANNEL_EMAIL, Purpose: "newsletter", PolicyVersion: "1"})
+			return err
+		},
+		"WithdrawConsent": func() error {
+			_, err := s.WithdrawConsent(ctx, &pb.WithdrawConsentReq{CustomerId: f.acmeID, Channel: pb.ConsentChannel_CONSENT_CHANNEL_EMAIL, Purpose: "newsletter", PolicyVersion: "1"})
+			return err
+		},
+		"ActivateCustomer": func() error {
+			_, err := s.ActivateCustomer(ctx, &pb.ActivateCustomerReq{Id: f.acmeID, Reason: "verified"})
+			return err
+		},
+		"SuspendCustomer": func() error {
+			_, err := s.SuspendCustomer(ctx, &pb.SuspendCustomerReq{Id: f.acmeID, Reason: "fraud"})
+			return err
+		},
+		"CloseCustomer": func() error {
+			_, err := s.CloseCustomer(ctx, &pb.CloseCustomerReq{Id: f.acmeID, Reason: "fraud"})
+			return err
+		},
+		"BatchUpdateCustomers": func() error {
+			_, err := s.BatchUpdateCustomers(ctx, &pb.BatchUpdateCustomersReq{Updates: []*pb.UpdateCustomerReq{{Id: f.acmeID, Name: "Mallory"}}})
+			return err
+		},
+	}
+	for name, call := range mustFail {
+		if err := call(); err == nil {
+			t.Errorf("%s succeeded on a customer of another tenant", name)
+		}
+	}
+
+	byEmail, err := s.GetCustomerByEmail(ctx, &pb.GetCustomerByEmailReq{Email: fixtureEmail})
+	if err != nil || byEmail.Id != f.globexID {
+		t.Errorf("GetCustomerByEmail = %v, %v, want the globex customer", byEmail, err)
+	}
+	byPhone, err := s.GetCustomerByPhoneNumber(ctx, &pb.GetCustomerByPhoneNumberReq{PhoneNumber: fixturePhone})
+	if err != nil || byPhone.Id != f.globexID {
+		t.Errorf("GetCustomerByPhoneNumber = %v, %v, want the globex customer", byPhone, err)
+	}
+	other := biz.NewTenantContext(context.Background(), "initech")
+	if _, err := s.GetCustomerByEmail(other, &pb.GetCustomerByEmailReq{Email: fixtureEmail}); err == nil {
+		t.Error("GetCustomerByEmail found a customer of another tenant")
+	}
+	if _, err := s.GetCustomerByPhoneNumber(other, &pb.GetCustomerByPhoneNumberReq{PhoneNumber: fixturePhone}); err == nil {
+		t.Error("GetCustomerByPhoneNumber found a customer of another tenant")
+	}
+
+	if r, err := s.ListEmail(ctx, &pb.ListEmailReq{CustomerId: f.acmeID}); err == nil && len(r.Emails) != 0 {
+		t.Errorf("ListEmail returned %v of a customer of another tenant", r.Emails)
+	}
+	if r, err := s.ListPhoneNumber(ctx, &pb.ListPhoneNumberReq{CustomerId: f.acmeID}); err == nil && len(r.PhoneNumbers) != 0 {
+		t.Errorf("ListPhoneNumber returned %v of a customer of another tenant", r.PhoneNumbers)
+	}
+	if r, err := s.ListAddress(ctx, &pb.ListAddressReq{CustomerId: f.acmeID}); err == nil && len(r.Addresses) != 0 {
+		t.Errorf("ListAddress returned %v of a customer of another tenant", r.Addresses)
+	}
+	if r, err := s.ListConsents(ctx, &pb.ListConsentsReq{CustomerId: f.acmeID, History: true}); err == nil && len(r.Consents) != 0 {
+		t.Errorf("ListConsents returned %d consents of a customer of another tenant", len(r.Consents))
+	}
+
+	list, err := s.ListCustomer(ctx, &pb.ListCustomerReq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range list.Customers {
+		if c.Id != f.globexID {
+			t.Errorf("ListCustomer returned customer %d of another tenant", c.Id)
+		}
+	}
+	search, err := s.SearchCustomers(ctx, &pb.SearchCustomersReq{Query: "Jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range search.Hits {
+		if h.Customer.Id != f.globexID {
+			t.Errorf("SearchCustomers returned customer %d of another tenant", h.Customer.Id)
+		}
+	}
+	dups, err := s.FindDuplicateCustomers(ctx, &pb.FindDuplicateCustomersReq{MinScore: 0.01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dups.Candidates) != 0 {
+		t.Errorf("FindDuplicateCustomers paired customers of different tenants: %v", dups.Candidates)
+	}
+	batch, err := s.BatchGetCustomers(ctx, &pb.BatchGetCustomersReq{Keys: []*pb.CustomerKey{
+		{Key: &pb.CustomerKey_Id{Id: f.acmeID}},
+		{Key: &pb.CustomerKey_Email{Email: fixtureEmail}},
+		{Key: &pb.CustomerKey_PhoneNumber{PhoneNumber: fixturePhone}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batch.Results[0].Found {
+		t.Error("BatchGetCustomers found a customer of another tenant by id")
+	}
+	for _, r := range batch.Results[1:] {
+		if !r.Found || r.Customer.Id != f.globexID {
+			t.Errorf("BatchGetCustomers by %v = %v, want the globex customer", r.Key, r.Customer)
+		}
+	}
+	bestEffort, err := s.BatchUpdateCustomers(ctx, &pb.BatchUpdateCustomersReq{
+		Updates:    []*pb.UpdateCustomerReq{{Id: f.acmeID, Name: "Mallory"}},
+		BestEffort: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bestEffort.Results[0].Error == "" {
+		t.Error("BatchUpdateCustomers updated a customer of another tenant")
+	}
+
+	reachable, err := s.ListReachableContacts(ctx, &pb.ListReachableContactsReq{Purpose: "newsletter"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reachable.Contacts) != 0 {
+		t.Errorf("ListReachableContacts returned %d contacts of another tenant", len(reachable.Contacts))
+	}
+
+	export := &exportStream{ctx: ctx}
+	if err := s.ExportCustomers(&pb.ExportCustomersReq{}, export); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(export.data.Bytes(), []byte("Jane Acme")) {
+		t.Error("ExportCustomers exported a customer of another tenant")
+	}
+
+	var leaked int64
+	if err := d.db.Model(&OutboxEvent{}).Where("tenant_id = ? AND customer_id = ?", "globex", f.acmeID).Count(&leaked).Error; err != nil {
+		t.Fatal(err)
+	}
+	if leaked != 0 {
+		t.Errorf("%d events of tenant globex recorded for a customer of another tenant", leaked)
+	}
+	f.assertUntouched(t, s)
+}
+
+// exportStream collects the chunks of ExportCustomers.
+type exportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	data bytes.Buffer
+}
+
+func (s *exportStream) Context() context.Context { return s.ctx }
+
+func (s *exportStream) Send(r *pb.ExportCustomersReply) error {
+	s.data.Write(r.Data)
+	return nil
+}
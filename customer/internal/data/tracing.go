@@ -0,0 +1,72 @@
+package data
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// querySpanKey holds the span of a statement on its gorm.DB.
+const querySpanKey = "customer:query_span"
+
+// registerTracing runs every statement gorm runs in a span of the trace of
+// its context. The spans carry the SQL with placeholders, never the
+// values, which may be personal data.
+func registerTracing(db *gorm.DB) error {
+	tracer := otel.Tracer("customer/internal/data")
+
+	before := func(operation string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			ctx := db.Statement.Context
+			if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+				// statements outside of a traced request or job
+				return
+			}
+			_, span := tracer.Start(ctx, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient))
+			db.InstanceSet(querySpanKey, span)
+		}
+	}
+	after := func(db *gorm.DB) {
+		v, ok := db.InstanceGet(querySpanKey)
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		span.SetAttributes(
+			attribute.String("db.system", db.Dialector.Name()),
+			attribute.String("db.sql.table", db.Statement.Table),
+			attribute.String("db.statement", db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+		span.End()
+	}
+
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", after),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", after),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", after),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", after),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
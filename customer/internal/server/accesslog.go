@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// requestIDHeader carries the request id. Callers may send their own,
+// otherwise one is generated; it is returned in the reply header.
+const requestIDHeader = "x-request-id"
+
+// accessEntry collects what the access log line of a request says. The
+// caller is only known once auth ran, further in than the access log.
+type accessEntry struct {
+	requestID string
+	subject   string
+	tenant    string
+	reply     interface{}
+}
+
+type accessEntryKey struct{}
+
+// AccessLog writes one log line per request with the Kratos logging
+// middleware, adding the request id and the caller and masking personal
+// data in the payloads and errors it logs.
+type AccessLog struct {
+	log      log.Logger
+	payloads bool
+}
+
+func NewAccessLog(c *conf.Server, logger log.Logger) *AccessLog {
+	return &AccessLog{log: logger, payloads: c.GetAccessLog().GetPayloads()}
+}
+
+// Middleware logs the request once it is served. It should run outside
+// of everything that can reject a request, so rejected requests are
+// logged too.
+func (a *AccessLog) Middleware() middleware.Middleware {
+	kv := []interface{}{
+		"request.id", RequestID(),
+		"caller.subject", entryValuer(func(e *accessEntry) interface{} { return e.subject }),
+		"caller.tenant", entryValuer(func(e *accessEntry) interface{} { return e.tenant }),
+		"peer", peerValuer(),
+	}
+	if a.payloads {
+		kv = append(kv, "reply", entryValuer(func(e *accessEntry) interface{} { return logPayload(e.reply) }))
+	}
+	logged := logging.Server(log.With(a.log, kv...))
+
+	return func(handler middleware.Handler) middleware.Handler {
+		// the logging middleware gets the request as a loggedRequest and
+		// the error as a maskedError, so both are logged masked
+		inner := logged(func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req.(*loggedRequest).req)
+			if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+				entry.reply = reply
+			}
+			if err != nil {
+				return reply, &maskedError{err: err}
+			}
+			return reply, nil
+		})
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			entry := &accessEntry{}
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				entry.requestID = tr.RequestHeader().Get(requestIDHeader)
+				if entry.requestID == "" {
+					entry.requestID = newRequestID()
+				}
+				tr.ReplyHeader().Set(requestIDHeader, entry.requestID)
+			}
+			ctx = context.WithValue(ctx, accessEntryKey{}, entry)
+
+			reply, err := inner(ctx, &loggedRequest{req: req, payloads: a.payloads})
+			if me, ok := err.(*maskedError); ok {
+				err = me.err
+			}
+			return reply, err
+		}
+	}
+}
+
+// Identify notes the caller of the request for its access log line. It
+// must run after auth and tenancy.
+func (a *AccessLog) Identify() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+				entry.subject, _ = biz.SubjectFromContext(ctx)
+				entry.tenant = biz.TenantFromContext(ctx)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// RequestID returns the request id of ctx for log.With, so every line
+// logged while serving a request carries it.
+func RequestID() log.Valuer {
+	return entryValuer(func(e *accessEntry) interface{} { return e.requestID })
+}
+
+func entryValuer(field func(*accessEntry) interface{}) log.Valuer {
+	return func(ctx context.Context) interface{} {
+		if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+			return field(entry)
+		}
+		return ""
+	}
+}
+
+func peerValuer() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		if p, ok := peer.FromContext(ctx); ok {
+			return p.Addr.String()
+		}
+		return ""
+	}
+}
+
+// loggedRequest is what the logging middleware gets as the request: its
+// args are the masked payload, or nothing when payloads are not logged.
+type loggedRequest struct {
+	req      interface{}
+	payloads bool
+}
+
+// Redact implements logging.Redacter.
+func (r *loggedRequest) Redact() string {
+	if !r.payloads {
+		return ""
+	}
+	return logPayload(r.req)
+}
+
+// maskedError is the error the logging middleware gets. Its text is
+// masked; the code and reason still come from the wrapped error.
+type maskedError struct {
+	err error
+}
+
+func (e *maskedError) Error() string { return maskText(e.err.Error()) }
+
+func (e *maskedError) Unwrap() error { return e.err }
+
+// logPayload renders a request or reply with personal data masked.
+// Streams have none.
+func logPayload(v interface{}) string {
+	m, ok := v.(proto.Message)
+	if !ok || m == nil {
+		return ""
+	}
+	b, err := protojson.Marshal(maskPayload(m))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
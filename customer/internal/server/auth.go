@@ -0,0 +1,343 @@
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+	jwtv5 "github.com/golang-jwt/jwt/v5"
+	ggrpc "google.golang.org/grpc"
+)
+
+// defaultUnauthenticated are served without a token unless the config
+// lists its own operations.
+var defaultUnauthenticated = []string{
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+// NewAuthMiddleware validates the bearer token of every operation not on
+// the allowlist and puts its subject into the context. It returns nil
+// when auth is not configured. Keys are read once here, nothing is
+// fetched while serving.
+func NewAuthMiddleware(c *conf.Server_Auth) (middleware.Middleware, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	method := jwtv5.GetSigningMethod(c.SigningMethod)
+	if c.SigningMethod == "" {
+		method = jwtv5.SigningMethodRS256
+	}
+	if method == nil {
+		return nil, fmt.Errorf("auth: unknown signing method %q", c.SigningMethod)
+	}
+
+	keys, err := loadVerificationKeys(c, method)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := unauthenticatedOperations(c)
+	return selector.Server(
+		jwt.Server(keys.keyFunc(method),
+			jwt.WithSigningMethod(method),
+			jwt.WithClaims(func() jwtv5.Claims { return &tokenClaims{} }),
+		),
+		subjectMiddleware(c.Issuer, c.Audience),
+	).Match(func(_ context.Context, operation string) bool {
+		return !containsOperation(allowed, operation)
+	}).Build(), nil
+}
+
+// unauthenticatedOperations lists the operations served without a token.
+func unauthenticatedOperations(c *conf.Server_Auth) []string {
+	if c == nil || len(c.AllowUnauthenticated) == 0 {
+		return defaultUnauthenticated
+	}
+	return c.AllowUnauthenticated
+}
+
+func containsOperation(ops []string, operation string) bool {
+	for _, op := range ops {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenClaims are the claims read from a token: the registered ones plus
+// the roles and OAuth scopes authz maps to policies and the tenant.
+type tokenClaims struct {
+	jwtv5.RegisteredClaims
+	Roles  jwtv5.ClaimStrings `json:"roles,omitempty"`
+	Scope  string             `json:"scope,omitempty"`
+	Tenant string             `json:"tenant,omitempty"`
+}
+
+// roles returns the roles and scopes of the token together.
+func (c *tokenClaims) roles() []string {
+	return append(append([]string(nil), c.Roles...), strings.Fields(c.Scope)...)
+}
+
+type rolesKey struct{}
+
+// rolesFromContext returns the roles of the authenticated caller.
+func rolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey{}).([]string)
+	return roles
+}
+
+// subjectMiddleware checks the claims jwt.Server verified, hands the
+// subject to biz and keeps the roles for authz.
+func subjectMiddleware(issuer, audience string) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			claims, ok := jwt.FromContext(ctx)
+			if !ok {
+				return nil, jwt.ErrMissingJwtToken
+			}
+			rc, ok := claims.(*tokenClaims)
+			if !ok {
+				return nil, jwt.ErrTokenInvalid
+			}
+			if issuer != "" && rc.Issuer != issuer {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "token issuer is not accepted")
+			}
+			if audience != "" && !containsAudience(rc.Audience, audience) {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "token audience is not accepted")
+			}
+			if rc.Subject == "" {
+				return nil, errors.Unauthorized("UNAUTHORIZED", "token has no subject")
+			}
+			ctx = context.WithValue(ctx, rolesKey{}, rc.roles())
+			return handler(biz.NewSubjectContext(ctx, rc.Subject), req)
+		}
+	}
+}
+
+func containsAudience(aud jwtv5.ClaimStrings, want string) bool {
+	for _, a := range aud {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+// streamMiddleware runs m once when a stream opens, with the stream
+// context, and serves the stream with the context m passes on. Kratos
+// stream middleware runs for every message instead, too late to reject
+// the call and unable to change its context.
+func streamMiddleware(m middleware.Middleware) ggrpc.StreamServerInterceptor {
+	return func(srv interface{}, ss ggrpc.ServerStream, _ *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) error {
+		// the error of the stream goes through m, so metrics see it
+		_, err := m(func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return nil, handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		})(ss.Context(), nil)
+		return err
+	}
+}
+
+type contextStream struct {
+	ggrpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// verificationKeys holds the keys tokens may be signed with. Keys from a
+// JWKS are looked up by the kid header, static keys are all tried.
+type verificationKeys struct {
+	byID   map[string]crypto.PublicKey
+	static []jwtv5.VerificationKey
+}
+
+func (k *verificationKeys) keyFunc(method jwtv5.SigningMethod) jwtv5.Keyfunc {
+	return func(t *jwtv5.Token) (interface{}, error) {
+		if t.Method.Alg() != method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+		if kid, ok := t.Header["kid"].(string); ok {
+			if key, ok := k.byID[kid]; ok {
+				return key, nil
+			}
+		}
+		if len(k.static) == 0 {
+			return nil, fmt.Errorf("no key for token")
+		}
+		return jwtv5.VerificationKeySet{Keys: k.static}, nil
+	}
+}
+
+func loadVerificationKeys(c *conf.Server_Auth, method jwtv5.SigningMethod) (*verificationKeys, error) {
+	keys := &verificationKeys{byID: map[string]crypto.PublicKey{}}
+	hmac := strings.HasPrefix(method.Alg(), "HS")
+
+	if c.JwksFile != "" {
+		b, err := os.ReadFile(c.JwksFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth: read jwks: %w", err)
+		}
+		if err := keys.addJWKS(b); err != nil {
+			return nil, fmt.Errorf("auth: %s: %w", c.JwksFile, err)
+		}
+	}
+	for _, path := range c.KeyFiles {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("auth: read key: %w", err)
+		}
+		key, err := parseStaticKey(b, hmac)
+		if err != nil {
+			return nil, fmt.Errorf("auth: %s: %w", path, err)
+		}
+		keys.static = append(keys.static, key)
+	}
+	if len(keys.byID) == 0 && len(keys.static) == 0 {
+		return nil, fmt.Errorf("auth: no verification keys configured")
+	}
+	return keys, nil
+}
+
+func parseStaticKey(b []byte, hmac bool) (jwtv5.VerificationKey, error) {
+	if hmac {
+		secret := []byte(strings.TrimSpace(string(b)))
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("empty secret")
+		}
+		return secret, nil
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data")
+	}
+	if key, err := jwtv5.ParseRSAPublicKeyFromPEM(b); err == nil {
+		return key, nil
+	}
+	if key, err := jwtv5.ParseECPublicKeyFromPEM(b); err == nil {
+		return key, nil
+	}
+	if key, err := jwtv5.ParseEdPublicKeyFromPEM(b); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported public key")
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// addJWKS adds the signing keys of a JWKS document. Keys without a kid
+// are treated like static keys.
+func (k *verificationKeys) addJWKS(b []byte) error {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return err
+	}
+	for _, j := range set.Keys {
+		if j.Use != "" && j.Use != "sig" {
+			continue
+		}
+		key, err := j.publicKey()
+		if err != nil {
+			return fmt.Errorf("key %q: %w", j.Kid, err)
+		}
+		if j.Kid == "" {
+			k.static = append(k.static, key)
+			continue
+		}
+		k.byID[j.Kid] = key
+	}
+	return nil
+}
+
+func (j jwk) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(j.K)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
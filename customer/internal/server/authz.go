@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "customer/api/customer/v1"
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Authz decides per operation whether the roles of the caller may call it
+// and which reply fields they may see.
+type Authz struct {
+	policies map[string]*conf.Server_Authz_Policy
+	// unauthenticated operations have no roles to check
+	unauthenticated []string
+}
+
+// NewAuthz builds the policy layer from config. It returns nil when no
+// policies are configured.
+func NewAuthz(c *conf.Server) (*Authz, error) {
+	if c.Authz == nil || len(c.Authz.Policies) == 0 {
+		return nil, nil
+	}
+	if c.Auth == nil {
+		return nil, fmt.Errorf("authz: policies need auth to be configured")
+	}
+
+	a := &Authz{
+		policies:        make(map[string]*conf.Server_Authz_Policy, len(c.Authz.Policies)),
+		unauthenticated: unauthenticatedOperations(c.Auth),
+	}
+	for _, p := range c.Authz.Policies {
+		if p.Role == "" {
+			return nil, fmt.Errorf("authz: policy without role")
+		}
+		if _, ok := a.policies[p.Role]; ok {
+			return nil, fmt.Errorf("authz: duplicate policy for role %q", p.Role)
+		}
+		for _, pattern := range p.Allow {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("authz: role %q: invalid pattern %q", p.Role, pattern)
+			}
+		}
+		a.policies[p.Role] = p
+	}
+	return a, nil
+}
+
+// authorize returns the reply fields to redact for the caller, or a
+// PermissionDenied error.
+func (a *Authz) authorize(ctx context.Context, operation string) (map[string]bool, error) {
+	if containsOperation(a.unauthenticated, operation) {
+		return nil, nil
+	}
+
+	var granted []*conf.Server_Authz_Policy
+	roles := rolesFromContext(ctx)
+	for _, role := range roles {
+		if p, ok := a.policies[role]; ok && allowsOperation(p.Allow, operation) {
+			granted = append(granted, p)
+		}
+	}
+	if len(granted) == 0 {
+		if len(roles) == 0 {
+			return nil, errors.Forbidden("NO_ROLE", "caller has no role")
+		}
+		return nil, errors.Forbidden("OPERATION_NOT_PERMITTED",
+			fmt.Sprintf("roles %s may not call %s", strings.Join(roles, ", "), operation))
+	}
+
+	// a field stays visible if any granting role may see it
+	redact := make(map[string]bool)
+	for _, f := range granted[0].Redact {
+		redact[f] = true
+	}
+	for _, p := range granted[1:] {
+		for f := range redact {
+			if !containsString(p.Redact, f) {
+				delete(redact, f)
+			}
+		}
+	}
+	for f := range redact {
+		for _, alias := range redactAliases[f] {
+			redact[alias] = true
+		}
+	}
+	return redact, nil
+}
+
+// redactAliases are the other reply fields holding the data of a
+// redactable field: requests and events carry one value where customers
+// carry lists.
+var redactAliases = map[string][]string{
+	"emails":        {"email"},
+	"phone_numbers": {"phone_number"},
+	"addresses":     {"address"},
+}
+
+// contactFields are the fields whose redaction covers the contact of a
+// consent on each channel.
+var contactFields = map[v1.ConsentChannel]string{
+	v1.ConsentChannel_CONSENT_CHANNEL_EMAIL: "emails",
+	v1.ConsentChannel_CONSENT_CHANNEL_SMS:   "phone_numbers",
+}
+
+// redactsContact reports whether the contact field of m, a consent or a
+// reachable contact, is to be cleared. Without a known channel it is
+// cleared when any contact field is.
+func redactsContact(m protoreflect.Message, fields map[string]bool) bool {
+	if fd := m.Descriptor().Fields().ByName("channel"); fd != nil && fd.Enum() != nil {
+		if f, ok := contactFields[v1.ConsentChannel(m.Get(fd).Enum())]; ok {
+			return fields[f]
+		}
+	}
+	return fields["emails"] || fields["phone_numbers"]
+}
+
+// allowsOperation matches operation against full names, method names and
+// globs of either.
+func allowsOperation(patterns []string, operation string) bool {
+	method := operation[strings.LastIndexByte(operation, '/')+1:]
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, method); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, operation); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// unredactableOperations reply with documents that must be complete, so
+// callers with redacted fields may not call them.
+var unredactableOperations = []string{
+	"/api.customer.v1.Customer/ExportCustomerData",
+}
+
+// Middleware checks unary calls and redacts their replies.
+func (a *Authz) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, errors.Forbidden("NO_TRANSPORT", "operation unknown")
+			}
+			fields, err := a.authorize(ctx, tr.Operation())
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) > 0 && containsOperation(unredactableOperations, tr.Operation()) {
+				return nil, errors.Forbidden("OPERATION_NOT_PERMITTED",
+					fmt.Sprintf("%s cannot leave out the fields the caller may not see", tr.Operation()))
+			}
+			reply, err := handler(ctx, req)
+			if err != nil || len(fields) == 0 {
+				return reply, err
+			}
+			if m, ok := reply.(proto.Message); ok {
+				redact(m, fields)
+			}
+			return reply, nil
+		}
+	}
+}
+
+// StreamInterceptor checks streams when they open and redacts every
+// message they send.
+func (a *Authz) StreamInterceptor() ggrpc.StreamServerInterceptor {
+	return func(srv interface{}, ss ggrpc.ServerStream, info *ggrpc.StreamServerInfo, handler ggrpc.StreamHandler) error {
+		fields, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return handler(srv, ss)
+		}
+		return handler(srv, &redactingStream{ServerStream: ss, fields: fields})
+	}
+}
+
+var exportColumns = []string{
+	biz.ColumnID, biz.ColumnName, biz.ColumnDateOfBirth,
+	biz.ColumnEmails, biz.ColumnPhoneNumbers, biz.ColumnAddresses,
+}
+
+type redactingStream struct {
+	ggrpc.ServerStream
+	fields map[string]bool
+}
+
+func (s *redactingStream) SendMsg(m interface{}) error {
+	if pm, ok := m.(proto.Message); ok {
+		redact(pm, s.fields)
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// RecvMsg leaves redacted fields out of exports, whose reply is an opaque
+// file the fields cannot be removed from afterwards.
+func (s *redactingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if req, ok := m.(*v1.ExportCustomersReq); ok {
+		for f := range s.fields {
+			if containsString(exportColumns, f) && !containsString(req.ExcludeColumns, f) {
+				req.ExcludeColumns = append(req.ExcludeColumns, f)
+			}
+		}
+	}
+	return nil
+}
+
+// redact clears the named fields of m at any depth, including inside the
+// events of google.protobuf.Any fields. Search highlights of a redacted
+// field are dropped as they quote its value.
+func redact(m proto.Message, fields map[string]bool) {
+	if reply, ok := m.(*v1.SearchCustomersReply); ok {
+		for _, hit := range reply.Hits {
+			kept := hit.Highlights[:0]
+			for _, h := range hit.Highlights {
+				if !fields[h.Field] && !fields[h.Field+"s"] {
+					kept = append(kept, h)
+				}
+			}
+			hit.Highlights = kept
+		}
+	}
+	redactMessage(m.ProtoReflect(), fields)
+}
+
+func redactMessage(m protoreflect.Message, fields map[string]bool) {
+	if a, ok := m.Interface().(*anypb.Any); ok {
+		inner, err := a.UnmarshalNew()
+		if err != nil {
+			// unknown payload, drop it rather than leak it
+			a.Value = nil
+			return
+		}
+		redactMessage(inner.ProtoReflect(), fields)
+		_ = a.MarshalFrom(inner)
+		return
+	}
+
+	var cleared []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fields[string(fd.Name())]:
+			cleared = append(cleared, fd)
+		case fd.Name() == "contact" && fd.Kind() == protoreflect.StringKind && redactsContact(m, fields):
+			cleared = append(cleared, fd)
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message(), fields)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message(), fields)
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			redactMessage(v.Message(), fields)
+		}
+		return true
+	})
+	for _, fd := range cleared {
+		m.Clear(fd)
+	}
+}
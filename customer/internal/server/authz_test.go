@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "customer/api/customer/v1"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// newTestAuthz has an agent role seeing no contact points, a marketing
+// role seeing emails only, and an admin seeing everything.
+func newTestAuthz(t *testing.T) *Authz {
+	t.Helper()
+	a, err := NewAuthz(&conf.Server{
+		Auth: &conf.Server_Auth{},
+		Authz: &conf.Server_Authz{Policies: []*conf.Server_Authz_Policy{
+			{Role: "agent", Allow: []string{"*"}, Redact: []string{"emails", "phone_numbers", "addresses", "date_of_birth"}},
+			{Role: "marketing", Allow: []string{"*"}, Redact: []string{"phone_numbers", "addresses", "date_of_birth"}},
+			{Role: "admin", Allow: []string{"*"}},
+			{Role: "reader", Allow: []string{"Get*", "List*"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// redactedFields returns the fields authz redacts for callers with roles.
+func redactedFields(t *testing.T, a *Authz, roles ...string) map[string]bool {
+	t.Helper()
+	fields, err := a.authorize(context.WithValue(context.Background(), rolesKey{}, roles), "/api.customer.v1.Customer/GetCustomer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+func TestAuthzFields(t *testing.T) {
+	a := newTestAuthz(t)
+	for _, c := range []struct {
+		roles []string
+		want  []string
+	}{
+		{[]string{"agent"}, []string{"address", "addresses", "date_of_birth", "email", "emails", "phone_number", "phone_numbers"}},
+		{[]string{"marketing"}, []string{"address", "addresses", "date_of_birth", "phone_number", "phone_numbers"}},
+		// a field stays visible if any granting role may see it
+		{[]string{"agent", "marketing"}, []string{"address", "addresses", "date_of_birth", "phone_number", "phone_numbers"}},
+		{[]string{"agent", "admin"}, nil},
+	} {
+		var got []string
+		for f := range redactedFields(t, a, c.roles...) {
+			got = append(got, f)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("roles %v redact %v, want %v", c.roles, got, c.want)
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), rolesKey{}, []string{"reader"})
+	if _, err := a.authorize(ctx, "/api.customer.v1.Customer/DeleteCustomer"); errors.Reason(err) != "OPERATION_NOT_PERMITTED" {
+		t.Errorf("reader deleting: %v, want OPERATION_NOT_PERMITTED", err)
+	}
+	if _, err := a.authorize(context.Background(), "/api.customer.v1.Customer/GetCustomer"); errors.Reason(err) != "NO_ROLE" {
+		t.Errorf("caller without roles: %v, want NO_ROLE", err)
+	}
+}
+
+func TestRedactAliasFields(t *testing.T) {
+	fields := redactedFields(t, newTestAuthz(t), "agent")
+	for name, c := range map[string]struct {
+		reply, want proto.Message
+	}{
+		"customer": {
+			reply: &v1.GetCustomerReply{Id: 1, Name: "Jane Doe", Emails: []string{"jane@example.com"}, PhoneNumbers: []string{"+4915112345678"}, Addresses: []string{"Main Street 1"}, DateOfBirth: "1990-04-12"},
+			want:  &v1.GetCustomerReply{Id: 1, Name: "Jane Doe"},
+		},
+		"email": {
+			reply: &v1.AddEmailReply{Id: 2, CustomerId: 1, Email: "jane@example.com"},
+			want:  &v1.AddEmailReply{Id: 2, CustomerId: 1},
+		},
+		"phone number": {
+			reply: &v1.AddPhoneNumberReply{Id: 2, CustomerId: 1, PhoneNumber: "+4915112345678"},
+			want:  &v1.AddPhoneNumberReply{Id: 2, CustomerId: 1},
+		},
+		"address": {
+			reply: &v1.AddAddressReply{Id: 2, CustomerId: 1, Address: "Main Street 1"},
+			want:  &v1.AddAddressReply{Id: 2, CustomerId: 1},
+		},
+	} {
+		redact(c.reply, fields)
+		if !proto.Equal(c.reply, c.want) {
+			t.Errorf("%s: redacted to %v, want %v", name, c.reply, c.want)
+		}
+	}
+}
+
+func TestRedactInsideAny(t *testing.T) {
+	fields := redactedFields(t, newTestAuthz(t), "agent")
+	added, err := anypb.New(&v1.EmailAdded{CustomerId: 1, EmailId: 2, Email: "jane@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := &v1.WatchCustomersReply{Sequence: 3, CustomerId: 1, Event: added}
+	redact(reply, fields)
+	var event v1.EmailAdded
+	if err := reply.Event.UnmarshalTo(&event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Email != "" || event.EmailId != 2 {
+		t.Errorf("event redacted to %v, want the email cleared only", &event)
+	}
+
+	// nested in a message of the reply
+	created, err := anypb.New(&v1.CustomerCreated{CustomerId: 1, Name: "Jane Doe", DateOfBirth: "1990-04-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := &v1.CustomerDataBundle{History: []*v1.CustomerDataChange{{Sequence: 1, Event: created}}}
+	redact(bundle, fields)
+	var c v1.CustomerCreated
+	if err := bundle.History[0].Event.UnmarshalTo(&c); err != nil {
+		t.Fatal(err)
+	}
+	if c.DateOfBirth != "" || c.Name != "Jane Doe" {
+		t.Errorf("event redacted to %v, want the date of birth cleared only", &c)
+	}
+
+	// a payload that cannot be read is dropped rather than leaked
+	unknown := &v1.WatchCustomersReply{Event: &anypb.Any{TypeUrl: "example.com/Unknown", Value: []byte("jane@example.com")}}
+	redact(unknown, fields)
+	if unknown.Event.Value != nil {
+		t.Errorf("unknown event kept %q", unknown.Event.Value)
+	}
+}
+
+func TestRedactDropsHighlights(t *testing.T) {
+	fields := redactedFields(t, newTestAuthz(t), "marketing")
+	reply := &v1.SearchCustomersReply{Hits: []*v1.SearchHit{{
+		Customer: &v1.GetCustomerReply{Id: 1, Name: "Jane Doe", Emails: []string{"jane@example.com"}, Addresses: []string{"Main Street 1"}},
+		Highlights: []*v1.SearchHighlight{
+			{Field: "name", Value: "Jane Doe", Highlighted: "<em>Jane</em> Doe"},
+			{Field: "email", Value: "jane@example.com", Highlighted: "<em>jane</em>@example.com"},
+			{Field: "phone_number", Value: "+4915112345678", Highlighted: "<em>+49151</em>12345678"},
+			{Field: "address", Value: "Main Street 1", Highlighted: "<em>Main</em> Street 1"},
+		},
+	}}}
+	redact(reply, fields)
+	var kept []string
+	for _, h := range reply.Hits[0].Highlights {
+		kept = append(kept, h.Field)
+	}
+	if !reflect.DeepEqual(kept, []string{"name", "email"}) {
+		t.Errorf("kept highlights of %v, want name and email", kept)
+	}
+	if c := reply.Hits[0].Customer; len(c.Addresses) != 0 || len(c.Emails) != 1 {
+		t.Errorf("customer redacted to %v", c)
+	}
+}
+
+func TestRedactsContact(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		channel v1.ConsentChannel
+		fields  []string
+		cleared bool
+	}{
+		{"email consent, emails redacted", v1.ConsentChannel_CONSENT_CHANNEL_EMAIL, []string{"emails"}, true},
+		{"email consent, phone numbers redacted", v1.ConsentChannel_CONSENT_CHANNEL_EMAIL, []string{"phone_numbers"}, false},
+		{"sms consent, phone numbers redacted", v1.ConsentChannel_CONSENT_CHANNEL_SMS, []string{"phone_numbers"}, true},
+		{"sms consent, emails redacted", v1.ConsentChannel_CONSENT_CHANNEL_SMS, []string{"emails"}, false},
+		{"no channel, emails redacted", v1.ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED, []string{"emails"}, true},
+		{"no channel, addresses redacted", v1.ConsentChannel_CONSENT_CHANNEL_UNSPECIFIED, []string{"addresses"}, false},
+	} {
+		fields := map[string]bool{}
+		for _, f := range c.fields {
+			fields[f] = true
+		}
+		consent := &v1.Consent{Id: 1, Channel: c.channel, Purpose: "marketing", Contact: "jane@example.com"}
+		reachable := &v1.ListReachableContactsReply{Contacts: []*v1.ReachableContact{{CustomerId: 1, Channel: c.channel, Contact: "jane@example.com"}}}
+		redact(consent, fields)
+		redact(reachable, fields)
+		if got := consent.Contact == ""; got != c.cleared {
+			t.Errorf("%s: consent contact cleared = %v, want %v", c.name, got, c.cleared)
+		}
+		if got := reachable.Contacts[0].Contact == ""; got != c.cleared {
+			t.Errorf("%s: reachable contact cleared = %v, want %v", c.name, got, c.cleared)
+		}
+		if consent.Purpose != "marketing" {
+			t.Errorf("%s: purpose cleared", c.name)
+		}
+	}
+}
+
+// recvStream is a server stream receiving req.
+type recvStream struct {
+	ggrpc.ServerStream
+	req *v1.ExportCustomersReq
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestRedactingStreamExcludesColumns(t *testing.T) {
+	a := newTestAuthz(t)
+	for _, c := range []struct {
+		roles   []string
+		exclude []string
+		want    []string
+	}{
+		{[]string{"agent"}, nil, []string{"addresses", "date_of_birth", "emails", "phone_numbers"}},
+		{[]string{"agent"}, []string{"emails", "name"}, []string{"addresses", "date_of_birth", "emails", "name", "phone_numbers"}},
+		{[]string{"marketing"}, []string{"id"}, []string{"addresses", "date_of_birth", "id", "phone_numbers"}},
+	} {
+		s := &redactingStream{
+			ServerStream: &recvStream{req: &v1.ExportCustomersReq{ExcludeColumns: c.exclude}},
+			fields:       redactedFields(t, a, c.roles...),
+		}
+		var req v1.ExportCustomersReq
+		if err := s.RecvMsg(&req); err != nil {
+			t.Fatal(err)
+		}
+		got := append([]string{}, req.ExcludeColumns...)
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("roles %v excluding %v export without %v, want %v", c.roles, c.exclude, got, c.want)
+		}
+	}
+}
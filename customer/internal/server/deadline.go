@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+)
+
+// NewDeadlineMiddleware reports requests cut off by their deadline, the
+// one of the caller or the server timeout, as DEADLINE_EXCEEDED and the
+// ones the caller gave up on as CANCELLED. Otherwise the context errors
+// the repositories return would surface as unknown errors.
+func NewDeadlineMiddleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req)
+			switch {
+			case err == nil:
+			case stderrors.Is(err, context.DeadlineExceeded):
+				err = errors.GatewayTimeout("DEADLINE_EXCEEDED", "request did not finish in time").WithCause(err)
+			case stderrors.Is(err, context.Canceled):
+				err = errors.ClientClosed("CANCELLED", "request was cancelled").WithCause(err)
+			}
+			return reply, err
+		}
+	}
+}
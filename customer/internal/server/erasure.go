@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultErasureInterval  = time.Minute
+	defaultErasureBatchSize = 100
+)
+
+// ErasureServer runs the erasures whose grace period is over as a kratos
+// transport.Server so it starts and stops with the application.
+type ErasureServer struct {
+	erasures  *biz.ErasureUsecase
+	interval  time.Duration
+	batchSize int
+	log       *log.Helper
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewErasureServer new an erasure server.
+func NewErasureServer(c *conf.Server, erasures *biz.ErasureUsecase, logger log.Logger) *ErasureServer {
+	s := &ErasureServer{
+		erasures:  erasures,
+		interval:  defaultErasureInterval,
+		batchSize: defaultErasureBatchSize,
+		log:       log.NewHelper(logger),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if c.Erasure != nil {
+		if c.Erasure.Interval != nil {
+			s.interval = c.Erasure.Interval.AsDuration()
+		}
+		if c.Erasure.BatchSize > 0 {
+			s.batchSize = int(c.Erasure.BatchSize)
+		}
+	}
+	return s
+}
+
+func (s *ErasureServer) Start(ctx context.Context) error {
+	defer close(s.done)
+	s.log.Infof("[erasure] started, interval %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.erasures.RunDue(ctx, s.batchSize)
+		if err != nil {
+			s.log.Errorf("[erasure] run: %v", err)
+		} else if n > 0 {
+			s.log.Infof("[erasure] %d customers erased", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ErasureServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.log.Info("[erasure] stopped")
+	return nil
+}
@@ -2,20 +2,84 @@ package server
 
 import (
 	v1 "customer/api/customer/v1"
+	"customer/internal/biz"
 	"customer/internal/conf"
 	"customer/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"go.opentelemetry.io/otel/trace"
+	ggrpc "google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, customer *service.CustomerService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, customer *service.CustomerService, hs *HealthServer, idempotency *biz.IdempotencyUsecase, ratelimit *biz.RateLimitUsecase, tp trace.TracerProvider, logger log.Logger) (*grpc.Server, error) {
+	auth, err := NewAuthMiddleware(c.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	authz, err := NewAuthz(c)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter, err := NewRateLimitMiddleware(c, ratelimit)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := NewMetricsMiddleware()
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := tracing.Server(tracing.WithTracerProvider(tp))
+	access := NewAccessLog(c, logger)
+	deadline := NewDeadlineMiddleware()
+
+	middlewares := []middleware.Middleware{
+		recovery.Recovery(),
+		tracer,
+		access.Middleware(),
+		metrics,
+		deadline,
+	}
+	streamInts := []ggrpc.StreamServerInterceptor{
+		streamMiddleware(tracer),
+		streamMiddleware(access.Middleware()),
+		streamMiddleware(metrics),
+		streamMiddleware(deadline),
+	}
+	if auth != nil {
+		middlewares = append(middlewares, auth)
+		streamInts = append(streamInts, streamMiddleware(auth))
+	}
+	tenant := NewTenantMiddleware(c)
+	middlewares = append(middlewares, tenant, access.Identify())
+	streamInts = append(streamInts, streamMiddleware(tenant), streamMiddleware(access.Identify()))
+	if limiter != nil {
+		middlewares = append(middlewares, limiter)
+		streamInts = append(streamInts, streamMiddleware(limiter))
+	}
+	if authz != nil {
+		middlewares = append(middlewares, authz.Middleware())
+		streamInts = append(streamInts, authz.StreamInterceptor())
+	}
+	// replays are redacted by authz like the replies they were stored from
+	middlewares = append(middlewares, NewIdempotencyMiddleware(idempotency))
 	var opts = []grpc.ServerOption{
-		grpc.Middleware(
-			recovery.Recovery(),
-		),
+		grpc.Middleware(middlewares...),
+		grpc.StreamInterceptor(streamInts...),
+		// the health service below reports on the dependencies
+		grpc.CustomHealth(),
+	}
+	if !c.Grpc.Reflection {
+		opts = append(opts, grpc.DisableReflection())
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -23,12 +87,15 @@ func NewGRPCServer(c *conf.Server, customer *service.CustomerService, logger log
 	if c.Grpc.Addr != "" {
 		opts = append(opts, grpc.Address(c.Grpc.Addr))
 	}
+	// the deadline of every unary request, and so of the statements the
+	// repositories run for it; kratos defaults to 1s
 	if c.Grpc.Timeout != nil {
 		opts = append(opts, grpc.Timeout(c.Grpc.Timeout.AsDuration()))
 	}
 	srv := grpc.NewServer(opts...)
 	v1.RegisterCustomerServer(srv, customer)
-	return srv
+	healthpb.RegisterHealthServer(srv, hs.health)
+	return srv, nil
 }
 
 
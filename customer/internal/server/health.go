@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "customer/api/customer/v1"
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	defaultHealthInterval = 5 * time.Second
+	defaultHealthTimeout  = 2 * time.Second
+)
+
+// HealthServer checks the dependencies as a kratos transport.Server and
+// reports the outcome through the grpc.health.v1 service, for the whole
+// server and for the Customer service.
+type HealthServer struct {
+	health        *health.Server
+	uc            *biz.HealthUsecase
+	interval      time.Duration
+	timeout       time.Duration
+	shutdownDelay time.Duration
+	log           *log.Helper
+	stop          chan struct{}
+	done          chan struct{}
+
+	mu      sync.Mutex
+	serving bool
+}
+
+// NewHealthServer new a health server. It reports NOT_SERVING until the
+// first check passes.
+func NewHealthServer(c *conf.Server, uc *biz.HealthUsecase, logger log.Logger) *HealthServer {
+	s := &HealthServer{
+		health:   health.NewServer(),
+		uc:       uc,
+		interval: defaultHealthInterval,
+		timeout:  defaultHealthTimeout,
+		log:      log.NewHelper(logger),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if c.Health != nil {
+		if c.Health.Interval != nil {
+			s.interval = c.Health.Interval.AsDuration()
+		}
+		if c.Health.Timeout != nil {
+			s.timeout = c.Health.Timeout.AsDuration()
+		}
+		s.shutdownDelay = c.Health.ShutdownDelay.AsDuration()
+	}
+	s.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+func (s *HealthServer) Start(ctx context.Context) error {
+	defer close(s.done)
+	s.log.Infof("[health] checks started, interval %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *HealthServer) Stop(ctx context.Context) error {
+	s.health.Shutdown()
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.log.Info("[health] checks stopped")
+	return nil
+}
+
+// Shutdown reports NOT_SERVING for good and waits out the shutdown delay.
+// It runs before the servers stop, so clients and probes learn of the
+// shutdown while the listener still accepts them.
+func (s *HealthServer) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	s.log.Info("[health] shutting down, reporting NOT_SERVING")
+	if s.shutdownDelay <= 0 {
+		return nil
+	}
+	t := time.NewTimer(s.shutdownDelay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (s *HealthServer) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	err := s.uc.Check(ctx)
+	cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case err != nil && s.serving:
+		s.log.Errorf("[health] NOT_SERVING: %v", err)
+	case err != nil:
+		s.log.Debugf("[health] still NOT_SERVING: %v", err)
+	case !s.serving:
+		s.log.Info("[health] SERVING")
+	}
+	s.serving = err == nil
+	if s.serving {
+		s.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		s.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// setStatus is ignored by the health service once it is shut down.
+func (s *HealthServer) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(v1.Customer_ServiceDesc.ServiceName, status)
+}
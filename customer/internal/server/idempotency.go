@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+
+	"customer/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// idempotencyKeyHeader carries the key a client retries a request
+	// under, e.g. a UUID it generated for the first attempt.
+	idempotencyKeyHeader = "idempotency-key"
+	// idempotentReplayHeader is set on replies stored from an earlier
+	// attempt.
+	idempotentReplayHeader  = "idempotent-replayed"
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotentOperations are the mutating operations that honour an
+// idempotency key.
+var idempotentOperations = []string{
+	"/api.customer.v1.Customer/CreateCustomer",
+	"/api.customer.v1.Customer/CreateCustomerWithDetails",
+	"/api.customer.v1.Customer/UpdateCustomer",
+	"/api.customer.v1.Customer/DeleteCustomer",
+	"/api.customer.v1.Customer/AddEmail",
+	"/api.customer.v1.Customer/AddPhoneNumber",
+	"/api.customer.v1.Customer/AddAddress",
+	"/api.customer.v1.Customer/DeleteEmail",
+	"/api.customer.v1.Customer/DeletePhoneNumber",
+	"/api.customer.v1.Customer/DeleteAddress",
+	"/api.customer.v1.Customer/VerifyEmail",
+	"/api.customer.v1.Customer/VerifyPhoneNumber",
+	"/api.customer.v1.Customer/MergeCustomers",
+	"/api.customer.v1.Customer/EraseCustomer",
+	"/api.customer.v1.Customer/CancelCustomerErasure",
+	"/api.customer.v1.Customer/GrantConsent",
+	"/api.customer.v1.Customer/WithdrawConsent",
+	"/api.customer.v1.Customer/BatchUpdateCustomers",
+	"/api.customer.v1.Customer/ActivateCustomer",
+	"/api.customer.v1.Customer/SuspendCustomer",
+	"/api.customer.v1.Customer/CloseCustomer",
+}
+
+// NewIdempotencyMiddleware runs mutating requests sent with an
+// idempotency key once: an exact retry gets the stored reply, the same
+// key with another request is rejected. Requests without a key run as
+// they are. It must run after auth and tenancy, keys are scoped to the
+// caller.
+func NewIdempotencyMiddleware(uc *biz.IdempotencyUsecase) middleware.Middleware {
+	m := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			key := tr.RequestHeader().Get(idempotencyKeyHeader)
+			msg, isProto := req.(proto.Message)
+			if key == "" || !isProto {
+				return handler(ctx, req)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return nil, errors.BadRequest("IDEMPOTENCY_KEY_INVALID", "idempotency key is longer than 255 bytes")
+			}
+			fingerprint, err := requestFingerprint(tr.Operation(), msg)
+			if err != nil {
+				return nil, err
+			}
+
+			var reply interface{}
+			stored, replayed, err := uc.Do(ctx, key, fingerprint, func(ctx context.Context) ([]byte, []int64, error) {
+				r, err := handler(ctx, req)
+				if err != nil {
+					return nil, nil, err
+				}
+				reply = r
+				a, err := anypb.New(r.(proto.Message))
+				if err != nil {
+					return nil, nil, err
+				}
+				b, err := proto.Marshal(a)
+				if err != nil {
+					return nil, nil, err
+				}
+				return b, customerIDs(msg, r.(proto.Message)), nil
+			})
+			switch {
+			case stderrors.Is(err, biz.ErrIdempotencyKeyReused):
+				return nil, errors.BadRequest("IDEMPOTENCY_KEY_REUSED", err.Error())
+			case stderrors.Is(err, biz.ErrIdempotencyKeyInProgress):
+				return nil, errors.Conflict("IDEMPOTENCY_KEY_IN_PROGRESS", err.Error())
+			case err != nil:
+				return nil, err
+			case !replayed:
+				return reply, nil
+			}
+
+			var a anypb.Any
+			if err := proto.Unmarshal(stored, &a); err != nil {
+				return nil, err
+			}
+			r, err := a.UnmarshalNew()
+			if err != nil {
+				return nil, err
+			}
+			tr.ReplyHeader().Set(idempotentReplayHeader, "true")
+			return r, nil
+		}
+	}
+	return selector.Server(m).Match(func(_ context.Context, operation string) bool {
+		return containsOperation(idempotentOperations, operation)
+	}).Build()
+}
+
+// customerIDFields are the fields naming a customer in requests and
+// replies. id does too, except in messages with a customer_id of their
+// own, like Erasure.
+var customerIDFields = map[protoreflect.Name]bool{
+	"customer_id":   true,
+	"survivor_id":   true,
+	"merged_id":     true,
+	"duplicate_ids": true,
+	"id":            true,
+}
+
+// customerIDs collects the customers the messages are about, so the
+// stored reply can be dropped when one of them is erased. Taking one id
+// too many only costs an early expiry.
+func customerIDs(msgs ...proto.Message) []int64 {
+	seen := map[int64]bool{}
+	var ids []int64
+	add := func(id int64) {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	var walk func(m protoreflect.Message)
+	walk = func(m protoreflect.Message) {
+		ownCustomer := m.Descriptor().Fields().ByName("customer_id") != nil
+		m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			switch {
+			case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+				for i := 0; i < v.List().Len(); i++ {
+					walk(v.List().Get(i).Message())
+				}
+			case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+				walk(v.Message())
+			case fd.Kind() == protoreflect.Int64Kind && customerIDFields[fd.Name()]:
+				if fd.Name() == "id" && ownCustomer {
+					return true
+				}
+				if fd.IsList() {
+					for i := 0; i < v.List().Len(); i++ {
+						add(v.List().Get(i).Int())
+					}
+				} else {
+					add(v.Int())
+				}
+			}
+			return true
+		})
+	}
+	for _, m := range msgs {
+		walk(m.ProtoReflect())
+	}
+	return ids
+}
+
+// requestFingerprint identifies a request by its operation and content.
+func requestFingerprint(operation string, req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(operation))
+	h.Write([]byte{0})
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/proto"
+)
+
+// testHeader is a transport.Header of single values.
+type testHeader map[string]string
+
+func (h testHeader) Get(key string) string      { return h[key] }
+func (h testHeader) Set(key, value string)      { h[key] = value }
+func (h testHeader) Add(key, value string)      { h[key] = value }
+func (h testHeader) Values(key string) []string { return []string{h[key]} }
+func (h testHeader) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// testTransport is the server transport of a request to operation.
+type testTransport struct {
+	operation string
+	request   testHeader
+	reply     testHeader
+}
+
+func (t *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t *testTransport) Endpoint() string                { return "" }
+func (t *testTransport) Operation() string               { return t.operation }
+func (t *testTransport) RequestHeader() transport.Header { return t.request }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.reply }
+
+// serverContext returns the context of a request to operation with the
+// given headers, and its transport.
+func serverContext(operation string, header testHeader) (context.Context, *testTransport) {
+	if header == nil {
+		header = testHeader{}
+	}
+	tr := &testTransport{operation: operation, request: header, reply: testHeader{}}
+	return transport.NewServerContext(context.Background(), tr), tr
+}
+
+// memIdempotencyRepo keeps the records in memory, without expiry.
+type memIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]*biz.IdempotencyRecord
+}
+
+func (r *memIdempotencyRepo) Claim(_ context.Context, key, fingerprint string, _ time.Duration) (*biz.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.records[key]; ok {
+		c := *rec
+		return &c, nil
+	}
+	r.records[key] = &biz.IdempotencyRecord{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (r *memIdempotencyRepo) Complete(_ context.Context, key string, rec *biz.IdempotencyRecord, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[key] = rec
+	return nil
+}
+
+func (r *memIdempotencyRepo) Renew(context.Context, string, string, time.Duration) error { return nil }
+
+func (r *memIdempotencyRepo) Release(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, key)
+	return nil
+}
+
+func (r *memIdempotencyRepo) Forget(context.Context, []int64) error { return nil }
+
+func (r *memIdempotencyRepo) DeleteExpired(context.Context, int) (int, error) { return 0, nil }
+
+const createCustomer = "/api.customer.v1.Customer/CreateCustomer"
+
+// countingCreate serves CreateCustomer with a new id every call.
+type countingCreate struct {
+	mu    sync.Mutex
+	calls int64
+	// started and block, when set, report a call and hold it until
+	// block is closed
+	started chan struct{}
+	block   chan struct{}
+}
+
+func (c *countingCreate) handle(context.Context, interface{}) (interface{}, error) {
+	if c.block != nil {
+		close(c.started)
+		<-c.block
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return &pb.CreateCustomerReply{Id: c.calls, Name: "Jane Doe"}, nil
+}
+
+func newIdempotentCreate(c *countingCreate) func(ctx context.Context, req interface{}) (interface{}, error) {
+	uc := biz.NewIdempotencyUsecase(&conf.Data{}, &memIdempotencyRepo{records: map[string]*biz.IdempotencyRecord{}}, log.DefaultLogger)
+	return NewIdempotencyMiddleware(uc)(c.handle)
+}
+
+func TestIdempotencyMiddlewareReplay(t *testing.T) {
+	c := &countingCreate{}
+	h := newIdempotentCreate(c)
+	req := &pb.CreateCustomerReq{Name: "Jane Doe", DateOfBirth: "1990-04-12"}
+
+	ctx, _ := serverContext(createCustomer, testHeader{idempotencyKeyHeader: "k1"})
+	first, err := h(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, tr := serverContext(createCustomer, testHeader{idempotencyKeyHeader: "k1"})
+	again, err := h(ctx, proto.Clone(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(first.(proto.Message), again.(proto.Message)) {
+		t.Errorf("retry = %v, want the stored %v", again, first)
+	}
+	if tr.reply.Get(idempotentReplayHeader) != "true" {
+		t.Errorf("replay not flagged: %v", tr.reply)
+	}
+	if c.calls != 1 {
+		t.Errorf("created %d customers, want 1", c.calls)
+	}
+
+	// without a key, or for operations reading only, requests always run
+	for _, op := range []string{createCustomer, "/api.customer.v1.Customer/GetCustomer"} {
+		header := testHeader{}
+		if op != createCustomer {
+			header[idempotencyKeyHeader] = "k1"
+		}
+		ctx, tr := serverContext(op, header)
+		if _, err := h(ctx, req); err != nil || tr.reply.Get(idempotentReplayHeader) != "" {
+			t.Errorf("%s: %v, replayed %q", op, err, tr.reply.Get(idempotentReplayHeader))
+		}
+	}
+	if c.calls != 3 {
+		t.Errorf("created %d customers, want 3", c.calls)
+	}
+}
+
+func TestIdempotencyMiddlewareConflictingBody(t *testing.T) {
+	c := &countingCreate{}
+	h := newIdempotentCreate(c)
+	ctx, _ := serverContext(createCustomer, testHeader{idempotencyKeyHeader: "k1"})
+	if _, err := h(ctx, &pb.CreateCustomerReq{Name: "Jane Doe"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, _ = serverContext(createCustomer, testHeader{idempotencyKeyHeader: "k1"})
+	_, err := h(ctx, &pb.CreateCustomerReq{Name: "John Doe"})
+	if errors.Code(err) != 400 || errors.Reason(err) != "IDEMPOTENCY_KEY_REUSED" {
+		t.Errorf("another body under the key: %v, want IDEMPOTENCY_KEY_REUSED", err)
+	}
+
+	ctx, _ = serverContext(createCustomer, testHeader{idempotencyKeyHeader: strings.Repeat("k", maxIdempotencyKeyLength+1)})
+	if _, err := h(ctx, &pb.CreateCustomerReq{Name: "Jane Doe"}); errors.Reason(err) != "IDEMPOTENCY_KEY_INVALID" {
+		t.Errorf("overlong key: %v, want IDEMPOTENCY_KEY_INVALID", err)
+	}
+	if c.calls != 1 {
+		t.Errorf("created %d customers, want 1", c.calls)
+	}
+}
+
+func TestIdempotencyMiddlewareInFlight(t *testing.T) {
+	c := &countingCreate{started: make(chan struct{}), block: make(chan struct{})}
+	h := newIdempotentCreate(c)
+	req := &pb.CreateCustomerReq{Name: "Jane Doe"}
+
+	done := make(chan error)
+	go func() {
+		ctx, _ := serverContext(createCustomer, testHeader{idempotencyKeyHeader: "k1"})
+		_, err := h(ctx, req)
+		done <- err
+	}()
+	<-c.started
+	ctx, _ := serverContext(createCustomer, testHeader{idempotencyKeyHeader: "k1"})
+	_, err := h(ctx, req)
+	if errors.Code(err) != 409 || errors.Reason(err) != "IDEMPOTENCY_KEY_IN_PROGRESS" {
+		t.Errorf("retry in flight: %v, want IDEMPOTENCY_KEY_IN_PROGRESS", err)
+	}
+	close(c.block)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if c.calls != 1 {
+		t.Errorf("created %d customers, want 1", c.calls)
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"regexp"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// maskedValue replaces personal data in logs.
+const maskedValue = "***"
+
+// maskedLogFields are the fields masked whole wherever they appear in a
+// logged message. Free text like search queries can be anything.
+var maskedLogFields = map[protoreflect.Name]bool{
+	"name":          true,
+	"date_of_birth": true,
+	"born_after":    true,
+	"born_before":   true,
+	"email":         true,
+	"emails":        true,
+	"phone_number":  true,
+	"phone_numbers": true,
+	"address":       true,
+	"addresses":     true,
+	"contact":       true,
+	"query":         true,
+	"value":         true,
+	"highlighted":   true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// dates are masked before phone numbers, whose pattern would take
+	// the digits of an ISO date but leave a written month
+	datePattern = regexp.MustCompile(`(?i)\b(?:\d{4}-\d{1,2}-\d{1,2}|\d{1,2}[./]\d{1,2}[./]\d{4}|` +
+		`\d{1,2}\.?\s+` + monthNames + `\.?\s+\d{4}|` + monthNames + `\.?\s+\d{1,2},?\s+\d{4})\b`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d\s().-]{6,}\d`)
+	// addresses are a street with its house number, before or after it,
+	// and the postcode and city following them
+	addressPattern = regexp.MustCompile(`(?i)(?:\b\d+[a-z]?\s+(?:\p{L}[\p{L}'.-]*\s+){1,3}` + streetTypes + `|` +
+		`\b\p{L}[\p{L}'.-]*\s+` + streetTypes + `\s+\d+[a-z]?\b|` +
+		`\b\p{L}+` + streetSuffixes + `\s+\d+[a-z]?\b)` +
+		`(?:,\s*(?:\d{4,5}\s+)?\p{L}[\p{L}'-]*){0,2}`)
+)
+
+const (
+	monthNames  = `(?:jan|feb|mar|apr|may|jun|jul|aug|sep|sept|oct|nov|dec)[a-z]*`
+	streetTypes = `(?:street|road|avenue|lane|drive|boulevard|way|court|place|square|st|rd|ave|ln|dr|blvd)\b\.?`
+	// German streets are mostly written as one word, Hauptstraße 5
+	streetSuffixes = `(?:straße|strasse|str\.|weg|platz|allee|gasse|ring|damm)`
+)
+
+// maskText masks what looks like an email, date, phone number or street
+// address in free text, such as error messages.
+func maskText(s string) string {
+	s = emailPattern.ReplaceAllString(s, maskedValue)
+	s = addressPattern.ReplaceAllString(s, maskedValue)
+	s = datePattern.ReplaceAllString(s, maskedValue)
+	return phonePattern.ReplaceAllString(s, maskedValue)
+}
+
+// maskPayload returns a copy of m fit for logs: personal fields and
+// bytes, which may hold whole documents, are masked and the other strings
+// go through maskText. Messages packed in Any fields are masked too.
+func maskPayload(m proto.Message) proto.Message {
+	c := proto.Clone(m)
+	maskMessage(c.ProtoReflect())
+	return c
+}
+
+func maskMessage(m protoreflect.Message) {
+	if a, ok := m.Interface().(*anypb.Any); ok {
+		maskAny(a)
+		return
+	}
+
+	// fields are changed after ranging, Range does not allow it
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	for _, fd := range fields {
+		v := m.Get(fd)
+		switch {
+		case fd.IsMap():
+			mv := v.Map()
+			mv.Range(func(k protoreflect.MapKey, e protoreflect.Value) bool {
+				switch fd.MapValue().Kind() {
+				case protoreflect.MessageKind, protoreflect.GroupKind:
+					maskMessage(e.Message())
+				case protoreflect.StringKind:
+					mv.Set(k, protoreflect.ValueOfString(maskString(fd, e.String())))
+				}
+				return true
+			})
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				maskValue(fd, l.Get(i), func(nv protoreflect.Value) { l.Set(i, nv) })
+			}
+		default:
+			maskValue(fd, v, func(nv protoreflect.Value) { m.Set(fd, nv) })
+		}
+	}
+}
+
+func maskValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, set func(protoreflect.Value)) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		maskMessage(v.Message())
+	case protoreflect.StringKind:
+		set(protoreflect.ValueOfString(maskString(fd, v.String())))
+	case protoreflect.BytesKind:
+		set(protoreflect.ValueOfBytes([]byte(maskedValue)))
+	}
+}
+
+func maskString(fd protoreflect.FieldDescriptor, s string) string {
+	if s == "" {
+		return s
+	}
+	if maskedLogFields[fd.Name()] {
+		return maskedValue
+	}
+	return maskText(s)
+}
+
+// maskAny masks the message packed in a, or drops it when its type is
+// unknown.
+func maskAny(a *anypb.Any) {
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		a.Value = nil
+		return
+	}
+	maskMessage(m.ProtoReflect())
+	b, err := proto.Marshal(m)
+	if err != nil {
+		a.Value = nil
+		return
+	}
+	a.Value = b
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	pb "customer/api/customer/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// personal is what the fixtures below hold about their customer, none of
+// which may reach a log.
+var personal = []string{
+	"Jane", "Doe", "jane@example.com", "+4915112345678", "0151 1234567",
+	"Main Street", "Berlin", "Hauptstraße", "Baker Street", "1990-04-12", "12.04.1990", "April 1990",
+}
+
+func assertMasked(t *testing.T, name, logged string) {
+	t.Helper()
+	for _, p := range personal {
+		if strings.Contains(logged, p) {
+			t.Errorf("%s: %q is in %s", name, p, logged)
+		}
+	}
+}
+
+func TestMaskText(t *testing.T) {
+	for _, c := range []struct {
+		text, want string
+	}{
+		{"no customer with email jane@example.com", "no customer with email ***"},
+		{"phone +4915112345678 is taken", "phone *** is taken"},
+		{"phone 0151 1234567 is taken", "phone *** is taken"},
+		{"born 1990-04-12", "born ***"},
+		{"born 12.04.1990", "born ***"},
+		{"born 12 April 1990", "born ***"},
+		{"born April 12, 1990", "born ***"},
+		{"address Main Street 1, Berlin is taken", "address *** is taken"},
+		{"address Hauptstraße 5a, 10115 Berlin", "address ***"},
+		{"address 221b Baker Street, London", "address ***"},
+		{"at least 2 emails are required", "at least 2 emails are required"},
+		{"reason is longer than 512 bytes", "reason is longer than 512 bytes"},
+		{"the status of customer 42 changed meanwhile", "the status of customer 42 changed meanwhile"},
+	} {
+		if got := maskText(c.text); got != c.want {
+			t.Errorf("maskText(%q) = %q, want %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestMaskPayload(t *testing.T) {
+	added, err := anypb.New(&pb.AddressAdded{CustomerId: 1, AddressId: 2, Address: "Main Street 1, Berlin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := anypb.New(&pb.CustomerCreated{CustomerId: 1, Name: "Jane Doe", DateOfBirth: "1990-04-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, m := range map[string]proto.Message{
+		"customer reply": &pb.GetCustomerReply{
+			Id:           1,
+			Name:         "Jane Doe",
+			DateOfBirth:  "1990-04-12",
+			Emails:       []string{"jane@example.com"},
+			PhoneNumbers: []string{"+4915112345678"},
+			Addresses:    []string{"Main Street 1, Berlin"},
+			StatusReason: "moved to Hauptstraße 5, Berlin, born 12.04.1990",
+		},
+		"event in Any":   &pb.WatchCustomersReply{Sequence: 3, CustomerId: 1, Event: added},
+		"created in Any": &pb.CustomerDataChange{Sequence: 1, Event: created},
+	} {
+		logged := logPayload(m)
+		if logged == "" {
+			t.Errorf("%s: nothing logged", name)
+		}
+		assertMasked(t, name, logged)
+	}
+
+	// the payload is masked on a copy
+	reply := &pb.GetCustomerReply{Name: "Jane Doe"}
+	maskPayload(reply)
+	if reply.Name != "Jane Doe" {
+		t.Errorf("maskPayload changed the reply: %q", reply.Name)
+	}
+	// events of unknown types are dropped
+	unknown := maskPayload(&pb.WatchCustomersReply{Event: &anypb.Any{TypeUrl: "example.com/Unknown", Value: []byte("Jane Doe")}})
+	if v := unknown.(*pb.WatchCustomersReply).Event.Value; v != nil {
+		t.Errorf("unknown event kept %q", v)
+	}
+	// packed events stay readable
+	b, err := protojson.Marshal(maskPayload(&pb.WatchCustomersReply{Event: added}))
+	if err != nil || !strings.Contains(string(b), `"addressId":"2"`) {
+		t.Errorf("masked event = %s, %v", b, err)
+	}
+}
+
+func TestMaskedError(t *testing.T) {
+	err := errors.BadRequest("ADDRESS_INVALID",
+		"address Main Street 1, Berlin of the customer born 12 April 1990 is not deliverable")
+	masked := &maskedError{err: err}
+	assertMasked(t, "error", masked.Error())
+	if errors.Reason(masked) != "ADDRESS_INVALID" || errors.Code(masked) != 400 {
+		t.Errorf("masked error lost its reason: %d %s", errors.Code(masked), errors.Reason(masked))
+	}
+}
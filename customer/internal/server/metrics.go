@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/metrics"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	defaultMetricsPath = "/metrics"
+
+	serverSecondsHistogram = "server_requests_seconds"
+	serverRequestsCounter  = "server_requests_code_total"
+)
+
+// MetricsServer serves the Prometheus metrics of the service as a kratos
+// transport.Server. Without metrics configured it does nothing.
+type MetricsServer struct {
+	srv *http.Server
+	log *log.Helper
+}
+
+// NewMetricsServer new a metrics server. It installs the global meter
+// provider the other layers record their metrics with, so that nothing is
+// collected when metrics are not configured.
+func NewMetricsServer(c *conf.Server, logger log.Logger) (*MetricsServer, error) {
+	s := &MetricsServer{log: log.NewHelper(logger)}
+	if c.Metrics == nil {
+		return s, nil
+	}
+
+	exporter, err := prometheus.New(prometheus.WithoutUnits())
+	if err != nil {
+		return nil, err
+	}
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(exporter),
+		sdkmetric.WithView(metrics.DefaultSecondsHistogramView(serverSecondsHistogram)),
+	))
+
+	path := c.Metrics.Path
+	if path == "" {
+		path = defaultMetricsPath
+	}
+	var opts []http.ServerOption
+	if c.Metrics.Addr != "" {
+		opts = append(opts, http.Address(c.Metrics.Addr))
+	}
+	s.srv = http.NewServer(opts...)
+	s.srv.Handle(path, promhttp.Handler())
+	return s, nil
+}
+
+func (s *MetricsServer) Start(ctx context.Context) error {
+	if s.srv == nil {
+		return nil
+	}
+	return s.srv.Start(ctx)
+}
+
+func (s *MetricsServer) Stop(ctx context.Context) error {
+	if s.srv == nil {
+		return nil
+	}
+	return s.srv.Stop(ctx)
+}
+
+// NewMetricsMiddleware counts the requests of every operation by code and
+// times them.
+func NewMetricsMiddleware() (middleware.Middleware, error) {
+	meter := otel.Meter("customer/internal/server")
+	requests, err := metrics.DefaultRequestsCounter(meter, serverRequestsCounter)
+	if err != nil {
+		return nil, err
+	}
+	seconds, err := metrics.DefaultSecondsHistogram(meter, serverSecondsHistogram)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.Server(metrics.WithRequests(requests), metrics.WithSeconds(seconds)), nil
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultOutboxInterval  = time.Second
+	defaultOutboxBatchSize = 100
+	defaultOutboxLeaseTTL  = 30 * time.Second
+)
+
+// OutboxServer runs the outbox relay as a kratos transport.Server so it
+// starts and stops with the application. Replicas share a lease, so one
+// of them relays at a time and events keep their order.
+type OutboxServer struct {
+	relay     *biz.OutboxRelay
+	holder    string
+	interval  time.Duration
+	batchSize int
+	leaseTTL  time.Duration
+	log       *log.Helper
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewOutboxServer new an outbox relay server.
+func NewOutboxServer(c *conf.Server, relay *biz.OutboxRelay, logger log.Logger) *OutboxServer {
+	host, _ := os.Hostname()
+	s := &OutboxServer{
+		relay:     relay,
+		holder:    fmt.Sprintf("%s/%d", host, os.Getpid()),
+		interval:  defaultOutboxInterval,
+		batchSize: defaultOutboxBatchSize,
+		leaseTTL:  defaultOutboxLeaseTTL,
+		log:       log.NewHelper(logger),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if c.Outbox != nil {
+		if c.Outbox.Interval != nil {
+			s.interval = c.Outbox.Interval.AsDuration()
+		}
+		if c.Outbox.BatchSize > 0 {
+			s.batchSize = int(c.Outbox.BatchSize)
+		}
+		if c.Outbox.LeaseTtl != nil {
+			s.leaseTTL = c.Outbox.LeaseTtl.AsDuration()
+		}
+	}
+	return s
+}
+
+func (s *OutboxServer) Start(ctx context.Context) error {
+	defer close(s.done)
+	defer func() {
+		if err := s.relay.Release(context.WithoutCancel(ctx), s.holder); err != nil {
+			s.log.Warnf("[outbox] release lease: %v", err)
+		}
+	}()
+	s.log.Infof("[outbox] relay started, interval %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		// drain full batches back to back, then wait for the next tick
+		for {
+			n, err := s.relay.Relay(ctx, s.holder, s.batchSize, s.leaseTTL)
+			if err != nil {
+				s.log.Errorf("[outbox] relay: %v", err)
+				break
+			}
+			if n < s.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *OutboxServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.log.Info("[outbox] relay stopped")
+	return nil
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultPurgeInterval  = time.Hour
+	defaultPurgeBatchSize = 500
+	defaultPurgeLeaseTTL  = 5 * time.Minute
+)
+
+// PurgeServer applies the retention policies as a kratos
+// transport.Server so it starts and stops with the application. Replicas
+// share a lease, so one of them purges at a time.
+type PurgeServer struct {
+	retention *biz.RetentionUsecase
+	// idempotency records past their window are swept with every run
+	idempotency *biz.IdempotencyUsecase
+	holder      string
+	interval    time.Duration
+	batchSize   int
+	leaseTTL    time.Duration
+	log         *log.Helper
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewPurgeServer new a purge server.
+func NewPurgeServer(c *conf.Server, retention *biz.RetentionUsecase, idempotency *biz.IdempotencyUsecase, logger log.Logger) *PurgeServer {
+	host, _ := os.Hostname()
+	s := &PurgeServer{
+		retention:   retention,
+		idempotency: idempotency,
+		holder:      fmt.Sprintf("%s/%d", host, os.Getpid()),
+		interval:    defaultPurgeInterval,
+		batchSize:   defaultPurgeBatchSize,
+		leaseTTL:    defaultPurgeLeaseTTL,
+		log:         log.NewHelper(logger),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	if c.Purge != nil {
+		if c.Purge.Interval != nil {
+			s.interval = c.Purge.Interval.AsDuration()
+		}
+		if c.Purge.BatchSize > 0 {
+			s.batchSize = int(c.Purge.BatchSize)
+		}
+		if c.Purge.LeaseTtl != nil {
+			s.leaseTTL = c.Purge.LeaseTtl.AsDuration()
+		}
+	}
+	return s
+}
+
+func (s *PurgeServer) Start(ctx context.Context) error {
+	defer close(s.done)
+	s.log.Infof("[purge] started, interval %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		sum, err := s.retention.Purge(ctx, s.holder, s.batchSize, s.leaseTTL)
+		switch {
+		case err != nil:
+			s.log.Errorf("[purge] run: %v", err)
+		case sum.Skipped:
+			s.log.Debug("[purge] skipped, another replica holds the lease")
+		default:
+			s.log.Infof("[purge] %d deleted customers purged, %d inactive customers deleted, %d unverified emails and %d unverified phone numbers removed in %s",
+				sum.DeletedCustomers, sum.InactiveCustomers, sum.UnverifiedEmails, sum.UnverifiedPhoneNumbers, sum.Took)
+		}
+		if n, err := s.idempotency.Sweep(ctx, s.batchSize); err != nil {
+			s.log.Errorf("[purge] idempotency keys: %v", err)
+		} else if n > 0 {
+			s.log.Infof("[purge] %d expired idempotency keys removed", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PurgeServer) Stop(ctx context.Context) error {
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.log.Info("[purge] stopped")
+	return nil
+}
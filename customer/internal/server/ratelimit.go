@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"time"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/peer"
+)
+
+// retryAfterHeader tells a rate limited client how many seconds to wait
+// before it tries again.
+const retryAfterHeader = "retry-after"
+
+type rateLimit struct {
+	name       string
+	operations []string
+	limit      biz.RateLimit
+}
+
+// NewRateLimitMiddleware rejects requests of clients over the limit of
+// their operation with ResourceExhausted. It returns nil when no limits
+// are configured. It must run after auth and tenancy, clients are told
+// apart by their subject and tenant.
+func NewRateLimitMiddleware(c *conf.Server, uc *biz.RateLimitUsecase) (middleware.Middleware, error) {
+	rc := c.GetRateLimit()
+	if rc.GetDefault() == nil && len(rc.GetLimits()) == 0 {
+		return nil, nil
+	}
+
+	var limits []rateLimit
+	for i, l := range rc.GetLimits() {
+		if l.Name == "" {
+			return nil, fmt.Errorf("rate limit: limit %d without name", i)
+		}
+		if len(l.Operations) == 0 {
+			return nil, fmt.Errorf("rate limit %q: no operations", l.Name)
+		}
+		for _, pattern := range l.Operations {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("rate limit %q: invalid pattern %q", l.Name, pattern)
+			}
+		}
+		limit, err := newRateLimit(l)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, limit)
+	}
+	var fallback *rateLimit
+	if d := rc.GetDefault(); d != nil {
+		limit, err := newRateLimit(d)
+		if err != nil {
+			return nil, err
+		}
+		if limit.name == "" {
+			limit.name = "default"
+		}
+		fallback = &limit
+	}
+
+	// the first limit naming an operation applies
+	limitOf := func(operation string) *rateLimit {
+		for i := range limits {
+			if allowsOperation(limits[i].operations, operation) {
+				return &limits[i]
+			}
+		}
+		return fallback
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok || containsString(defaultUnauthenticated, tr.Operation()) {
+				// probes are never limited
+				return handler(ctx, req)
+			}
+			l := limitOf(tr.Operation())
+			if l == nil {
+				return handler(ctx, req)
+			}
+			allowed, retryAfter := uc.Allow(ctx, rateLimitClient(ctx), l.name, l.limit)
+			if allowed {
+				return handler(ctx, req)
+			}
+			// whole seconds, rounded up so a client waiting them is let through
+			secs := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+			tr.ReplyHeader().Set(retryAfterHeader, secs)
+			return nil, errors.New(429, "RATE_LIMITED", "too many requests, retry after "+secs+"s").
+				WithMetadata(map[string]string{"limit": l.name, "retry_after": secs})
+		}
+	}, nil
+}
+
+func newRateLimit(l *conf.Server_RateLimit_Limit) (rateLimit, error) {
+	if l.Requests <= 0 {
+		return rateLimit{}, fmt.Errorf("rate limit %q: requests must be positive", l.Name)
+	}
+	r := rateLimit{
+		name:       l.Name,
+		operations: l.Operations,
+		limit:      biz.RateLimit{Requests: int(l.Requests), Period: time.Second, Burst: int(l.Requests)},
+	}
+	if l.Period != nil {
+		r.limit.Period = l.Period.AsDuration()
+	}
+	if r.limit.Period <= 0 {
+		return rateLimit{}, fmt.Errorf("rate limit %q: period must be positive", l.Name)
+	}
+	if l.Burst > 0 {
+		r.limit.Burst = int(l.Burst)
+	}
+	return r, nil
+}
+
+// rateLimitClient names the client a request counts against: its subject
+// within the tenant, or the host an anonymous client connects from.
+func rateLimitClient(ctx context.Context) string {
+	if subject, ok := biz.SubjectFromContext(ctx); ok {
+		return biz.TenantFromContext(ctx) + "/" + subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "@" + host
+	}
+	return "@"
+}
@@ -5,4 +5,4 @@ import (
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer)
+var ProviderSet = wire.NewSet(NewGRPCServer, NewOutboxServer, NewErasureServer, NewPurgeServer, NewMetricsServer, NewTracerProvider, NewHealthServer)
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"net/http"
+
+	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
+	"google.golang.org/grpc/codes"
+)
+
+func init() {
+	httpstatus.DefaultConverter = statusConverter{httpstatus.DefaultConverter}
+}
+
+// statusConverter adds FailedPrecondition to the gRPC codes kratos errors
+// map to. Their codes are HTTP statuses, 412 Precondition Failed stands
+// for it.
+type statusConverter struct {
+	httpstatus.Converter
+}
+
+func (c statusConverter) ToGRPCCode(code int) codes.Code {
+	if code == http.StatusPreconditionFailed {
+		return codes.FailedPrecondition
+	}
+	return c.Converter.ToGRPCCode(code)
+}
+
+func (c statusConverter) FromGRPCCode(code codes.Code) int {
+	if code == codes.FailedPrecondition {
+		return http.StatusPreconditionFailed
+	}
+	return c.Converter.FromGRPCCode(code)
+}
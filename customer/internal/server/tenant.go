@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+const defaultTenantHeader = "x-tenant-id"
+
+// NewTenantMiddleware scopes every Customer call to one tenant. The
+// tenant claim of a token decides the tenant on its own; a header naming
+// another tenant is rejected, so callers cannot reach across tenants, and
+// tokens without the claim are rejected rather than falling back to the
+// header or the default tenant. Unauthenticated calls take the tenant
+// from the metadata header.
+func NewTenantMiddleware(c *conf.Server) middleware.Middleware {
+	header, required := defaultTenantHeader, false
+	if t := c.Tenancy; t != nil {
+		if t.Header != "" {
+			header = t.Header
+		}
+		required = t.Required
+	}
+
+	m := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tenant, err := requestTenant(ctx, header)
+			if err != nil {
+				return nil, err
+			}
+			if tenant == "" && required {
+				return nil, errors.Forbidden("TENANT_REQUIRED", "request has no tenant")
+			}
+			return handler(biz.NewTenantContext(ctx, tenant), req)
+		}
+	}
+	return selector.Server(m).Prefix("/api.customer.v1.Customer/").Build()
+}
+
+func requestTenant(ctx context.Context, header string) (string, error) {
+	var requested string
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		requested = tr.RequestHeader().Get(header)
+	}
+
+	claims, ok := jwt.FromContext(ctx)
+	if !ok {
+		return requested, nil
+	}
+	tc, _ := claims.(*tokenClaims)
+	if tc == nil || tc.Tenant == "" {
+		return "", errors.Forbidden("TENANT_CLAIM_REQUIRED", "token has no tenant claim")
+	}
+	if requested != "" && requested != tc.Tenant {
+		return "", errors.Forbidden("TENANT_MISMATCH", fmt.Sprintf("token is not scoped to tenant %q", requested))
+	}
+	return tc.Tenant, nil
+}
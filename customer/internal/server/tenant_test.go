@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"customer/internal/biz"
+	"customer/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	jwtv5 "github.com/golang-jwt/jwt/v5"
+)
+
+func TestTenantMiddleware(t *testing.T) {
+	const getCustomer = "/api.customer.v1.Customer/GetCustomer"
+	token := func(tenant string) *tokenClaims {
+		return &tokenClaims{RegisteredClaims: jwtv5.RegisteredClaims{Subject: "agent-1"}, Tenant: tenant}
+	}
+	for _, c := range []struct {
+		name       string
+		operation  string
+		required   bool
+		claims     *tokenClaims
+		header     string
+		want       string
+		wantReason string
+	}{
+		{name: "header", header: "acme", want: "acme"},
+		{name: "no tenant", want: ""},
+		{name: "no tenant, required", required: true, wantReason: "TENANT_REQUIRED"},
+		{name: "token", claims: token("acme"), want: "acme"},
+		{name: "token and its header", claims: token("acme"), header: "acme", want: "acme"},
+		{name: "token and another header", claims: token("acme"), header: "globex", wantReason: "TENANT_MISMATCH"},
+		{name: "token without claim", claims: token(""), wantReason: "TENANT_CLAIM_REQUIRED"},
+		{name: "token without claim and a header", claims: token(""), header: "acme", wantReason: "TENANT_CLAIM_REQUIRED"},
+		{name: "other services", operation: "/grpc.health.v1.Health/Check", claims: token(""), header: "acme", want: ""},
+	} {
+		m := NewTenantMiddleware(&conf.Server{Tenancy: &conf.Server_Tenancy{Required: c.required}})
+		var got string
+		h := m(func(ctx context.Context, _ interface{}) (interface{}, error) {
+			got = biz.TenantFromContext(ctx)
+			return nil, nil
+		})
+		operation := c.operation
+		if operation == "" {
+			operation = getCustomer
+		}
+		header := testHeader{}
+		if c.header != "" {
+			header[defaultTenantHeader] = c.header
+		}
+		ctx, _ := serverContext(operation, header)
+		if c.claims != nil {
+			ctx = jwt.NewContext(ctx, c.claims)
+		}
+		_, err := h(ctx, nil)
+		if c.wantReason != "" {
+			if errors.Code(err) != 403 || errors.Reason(err) != c.wantReason {
+				t.Errorf("%s: %v, want %s", c.name, err, c.wantReason)
+			}
+			continue
+		}
+		if err != nil || got != c.want {
+			t.Errorf("%s: tenant %q, %v, want %q", c.name, got, err, c.want)
+		}
+	}
+}
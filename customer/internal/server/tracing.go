@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"customer/internal/conf"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultServiceName = "customer"
+	// how long buffered spans get to go out on shutdown
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+// NewTracerProvider builds the tracer provider for c.Tracing and installs
+// it, with the W3C trace context propagator, as the global one the other
+// layers trace with. Without tracing configured it returns the global
+// no-op provider.
+func NewTracerProvider(c *conf.Server) (trace.TracerProvider, func(), error) {
+	t := c.Tracing
+	if t == nil {
+		return otel.GetTracerProvider(), func() {}, nil
+	}
+
+	var (
+		exporter sdktrace.SpanExporter
+		file     io.Closer
+		err      error
+	)
+	switch t.Exporter {
+	case "otlp":
+		opts := []otlptracegrpc.Option{}
+		if t.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(t.Endpoint))
+		}
+		if t.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "file":
+		if t.File == "" {
+			return nil, nil, fmt.Errorf("tracing: file is required for the file exporter")
+		}
+		f, ferr := os.OpenFile(t.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if ferr != nil {
+			return nil, nil, ferr
+		}
+		file = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, nil, fmt.Errorf("tracing: unknown exporter %q", t.Exporter)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ratio := t.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+	name := t.ServiceName
+	if name == "" {
+		name = defaultServiceName
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", name))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		_ = tp.Shutdown(ctx)
+		if file != nil {
+			_ = file.Close()
+		}
+	}
+	return tp, cleanup, nil
+}
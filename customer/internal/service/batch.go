@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+)
+
+func (s *CustomerService) BatchGetCustomers(ctx context.Context, req *pb.BatchGetCustomersReq) (*pb.BatchGetCustomersReply, error) {
+	keys := make([]*biz.CustomerKey, len(req.Keys))
+	for i, k := range req.Keys {
+		keys[i] = &biz.CustomerKey{ID: k.GetId(), Email: k.GetEmail(), PhoneNumber: k.GetPhoneNumber()}
+	}
+	customers, err := s.uc.BatchGetCustomers(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.BatchGetCustomersReply{
+		Results: make([]*pb.BatchGetCustomersResult, len(customers)),
+	}
+	for i, c := range customers {
+		res := &pb.BatchGetCustomersResult{Key: req.Keys[i], Found: c != nil}
+		if c != nil {
+			res.Customer = toCustomerReply(c)
+		}
+		reply.Results[i] = res
+	}
+	return reply, nil
+}
+
+func (s *CustomerService) BatchUpdateCustomers(ctx context.Context, req *pb.BatchUpdateCustomersReq) (*pb.BatchUpdateCustomersReply, error) {
+	updates := make([]*biz.Customer, len(req.Updates))
+	for i, u := range req.Updates {
+		updates[i] = &biz.Customer{ID: u.Id, Name: u.Name, DateOfBirth: u.DateOfBirth}
+	}
+	results, err := s.uc.BatchUpdateCustomers(ctx, updates, req.BestEffort)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.BatchUpdateCustomersReply{
+		Results: make([]*pb.BatchUpdateCustomersResult, len(results)),
+	}
+	for i, r := range results {
+		res := &pb.BatchUpdateCustomersResult{Id: r.ID}
+		if r.Err != nil {
+			res.Error = r.Err.Error()
+		} else {
+			res.Customer = toCustomerReply(r.Customer)
+		}
+		reply.Results[i] = res
+	}
+	return reply, nil
+}
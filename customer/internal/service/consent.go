@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *CustomerService) GrantConsent(ctx context.Context, req *pb.GrantConsentReq) (*pb.GrantConsentReply, error) {
+	c := &biz.Consent{
+		CustomerID:    req.CustomerId,
+		Channel:       biz.ConsentChannel(req.Channel),
+		Purpose:       req.Purpose,
+		Contact:       req.Contact,
+		Source:        req.Source,
+		PolicyVersion: req.PolicyVersion,
+	}
+	if err := s.uc.GrantConsent(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return &pb.GrantConsentReply{Consent: toConsentReply(c)}, nil
+}
+
+func (s *CustomerService) WithdrawConsent(ctx context.Context, req *pb.WithdrawConsentReq) (*pb.WithdrawConsentReply, error) {
+	c := &biz.Consent{
+		CustomerID:    req.CustomerId,
+		Channel:       biz.ConsentChannel(req.Channel),
+		Purpose:       req.Purpose,
+		Contact:       req.Contact,
+		Source:        req.Source,
+		PolicyVersion: req.PolicyVersion,
+	}
+	if err := s.uc.WithdrawConsent(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return &pb.WithdrawConsentReply{Consent: toConsentReply(c)}, nil
+}
+
+func (s *CustomerService) ListConsents(ctx context.Context, req *pb.ListConsentsReq) (*pb.ListConsentsReply, error) {
+	consents, err := s.uc.ListConsents(ctx, req.CustomerId, req.History)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListConsentsReply{Consents: make([]*pb.Consent, 0, len(consents))}
+	for _, c := range consents {
+		reply.Consents = append(reply.Consents, toConsentReply(c))
+	}
+	return reply, nil
+}
+
+func (s *CustomerService) ListReachableContacts(ctx context.Context, req *pb.ListReachableContactsReq) (*pb.ListReachableContactsReply, error) {
+	page, err := s.uc.ListReachableContacts(ctx, req.Purpose, biz.ConsentChannel(req.Channel), int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.ListReachableContactsReply{
+		Contacts:      make([]*pb.ReachableContact, 0, len(page.Contacts)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, c := range page.Contacts {
+		reply.Contacts = append(reply.Contacts, &pb.ReachableContact{
+			CustomerId: c.CustomerID,
+			Channel:    pb.ConsentChannel(c.Channel),
+			Contact:    c.Contact,
+		})
+	}
+	return reply, nil
+}
+
+func toConsentReply(c *biz.Consent) *pb.Consent {
+	return &pb.Consent{
+		Id:            c.ID,
+		CustomerId:    c.CustomerID,
+		Channel:       pb.ConsentChannel(c.Channel),
+		Purpose:       c.Purpose,
+		Status:        pb.ConsentStatus(c.Status),
+		Contact:       c.Contact,
+		Source:        c.Source,
+		PolicyVersion: c.PolicyVersion,
+		RecordedBy:    c.RecordedBy,
+		RecordedAt:    timestamppb.New(c.RecordedAt),
+	}
+}
@@ -4,15 +4,19 @@ import (
 	"context"
 	pb "customer/api/customer/v1"
 	"customer/internal/biz"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type CustomerService struct {
 	pb.UnimplementedCustomerServer
-	uc *biz.CustomerUsecase
+	uc       *biz.CustomerUsecase
+	feed     *biz.ChangeFeed
+	erasures *biz.ErasureUsecase
 }
 
-func NewCustomerService(uc *biz.CustomerUsecase) *CustomerService {
-	return &CustomerService{uc: uc}
+func NewCustomerService(uc *biz.CustomerUsecase, feed *biz.ChangeFeed, erasures *biz.ErasureUsecase) *CustomerService {
+	return &CustomerService{uc: uc, feed: feed, erasures: erasures}
 }
 
 func (s *CustomerService) CreateCustomer(ctx context.Context, req *pb.CreateCustomerReq) (*pb.CreateCustomerReply, error) {
@@ -28,9 +32,41 @@ func (s *CustomerService) CreateCustomer(ctx context.Context, req *pb.CreateCust
 	return &pb.CreateCustomerReply{Id: customer.ID}, nil
 }
 
-// func (s *CustomerService) CreateCustomerWithDetails (ctx context.Context, req *pb.CreateCustomerWithDetailsReq) (*pb.CreateCustomerReply, error) {
-    
-// }
+func (s *CustomerService) CreateCustomerWithDetails(ctx context.Context, req *pb.CreateCustomerWithDetailsReq) (*pb.CreateCustomerWithDetailsReply, error) {
+    customer := &biz.Customer{
+        Name:        req.Name,
+        DateOfBirth: req.DateOfBirth,
+    }
+
+    var (
+        email   *biz.Email
+        phone   *biz.PhoneNumber
+        address *biz.Address
+    )
+    reply := &pb.CreateCustomerWithDetailsReply{
+        Name:        req.Name,
+        DateOfBirth: req.DateOfBirth,
+    }
+    if req.Email != "" {
+        email = &biz.Email{Email: req.Email}
+        reply.Emails = []string{req.Email}
+    }
+    if req.PhoneNumber != "" {
+        phone = &biz.PhoneNumber{PhoneNumber: req.PhoneNumber}
+        reply.PhoneNumbers = []string{req.PhoneNumber}
+    }
+    if req.Address != "" {
+        address = &biz.Address{Address: req.Address}
+        reply.Addresses = []string{req.Address}
+    }
+
+    if err := s.uc.CreateCustomerWithDetails(ctx, customer, email, phone, address); err != nil {
+        return nil, err
+    }
+
+    reply.Id = customer.ID
+    return reply, nil
+}
 
 func (s *CustomerService) AddEmail(ctx context.Context, req *pb.AddEmailReq) (*pb.AddEmailReply, error) {
     email, err := s.uc.AddEmail(ctx, req.CustomerId, req.Email)
@@ -140,12 +176,15 @@ func (s *CustomerService) ListCustomer(ctx context.Context, req *pb.ListCustomer
         // }
 
         pbCustomers = append(pbCustomers, &pb.GetCustomerReply{
-            Id:           c.ID,
-            Name:         c.Name,
+            Id:              c.ID,
+            Name:            c.Name,
             // PhoneNumbers: phoneNumbers,
             // Emails:       emails,
             // Addresses:    addresses,
-            DateOfBirth:  c.DateOfBirth,
+            DateOfBirth:     c.DateOfBirth,
+            Status:          pb.CustomerStatus(c.Status),
+            StatusReason:    c.StatusReason,
+            StatusChangedAt: toStatusChangedAt(c),
         })
     }
 
@@ -154,6 +193,45 @@ func (s *CustomerService) ListCustomer(ctx context.Context, req *pb.ListCustomer
     }, nil
 }
 
+// toCustomerReply maps a customer loaded with its contact points.
+func toCustomerReply(c *biz.Customer) *pb.GetCustomerReply {
+    phoneNumbers := make([]string, len(c.PhoneNumbers))
+    for i, p := range c.PhoneNumbers {
+        phoneNumbers[i] = p.PhoneNumber
+    }
+
+    emails := make([]string, len(c.Emails))
+    for i, e := range c.Emails {
+        emails[i] = e.Email
+    }
+
+    addresses := make([]string, len(c.Addresses))
+    for i, a := range c.Addresses {
+        addresses[i] = a.Address
+    }
+
+    return &pb.GetCustomerReply{
+        Id:              c.ID,
+        Name:            c.Name,
+        PhoneNumbers:    phoneNumbers,
+        Emails:          emails,
+        Addresses:       addresses,
+        DateOfBirth:     c.DateOfBirth,
+        Status:          pb.CustomerStatus(c.Status),
+        StatusReason:    c.StatusReason,
+        StatusChangedAt: toStatusChangedAt(c),
+    }
+}
+
+// toStatusChangedAt leaves the time unset for customers whose status never
+// changed.
+func toStatusChangedAt(c *biz.Customer) *timestamppb.Timestamp {
+    if c.StatusChangedAt.IsZero() {
+        return nil
+    }
+    return timestamppb.New(c.StatusChangedAt)
+}
+
 func (s *CustomerService) AddAddress(ctx context.Context, req *pb.AddAddressReq) (*pb.AddAddressReply, error) {
     addr, err := s.uc.AddAddress(ctx, req.CustomerId, req.Address)
     if err != nil {
@@ -248,9 +326,12 @@ func (s *CustomerService) GetCustomer(ctx context.Context, req *pb.GetCustomerRe
     // }
 
     return &pb.GetCustomerReply{
-        Id:           customer.ID,
-        Name:         customer.Name,
-        DateOfBirth:  customer.DateOfBirth,
+        Id:              customer.ID,
+        Name:            customer.Name,
+        DateOfBirth:     customer.DateOfBirth,
+        Status:          pb.CustomerStatus(customer.Status),
+        StatusReason:    customer.StatusReason,
+        StatusChangedAt: toStatusChangedAt(customer),
         // PhoneNumbers: phoneStrings,
         // Emails:       emailStrings,
         // Addresses:    addressStrings,
@@ -264,7 +345,7 @@ func (s *CustomerService) GetCustomerByEmail(ctx context.Context, req *pb.GetCus
     if err != nil {
         return nil, err
     }
-    //     phoneNumbers := make([]string, len(customer.PhoneNumbers))
+    // phoneNumbers := make([]string, len(customer.PhoneNumbers))
     // for i, p := range customer.PhoneNumbers {
     //     phoneNumbers[i] = p.PhoneNumber
     // }
@@ -280,12 +361,15 @@ func (s *CustomerService) GetCustomerByEmail(ctx context.Context, req *pb.GetCus
     // }
 
     return &pb.GetCustomerByEmailReply{
-        Id:           customer.ID,
-        Name:         customer.Name,
+        Id:              customer.ID,
+        Name:            customer.Name,
         // PhoneNumbers: phoneNumbers,
         // Emails:       emails,
         // Addresses:    addresses,
-        DateOfBirth:  customer.DateOfBirth,
+        DateOfBirth:     customer.DateOfBirth,
+        Status:          pb.CustomerStatus(customer.Status),
+        StatusReason:    customer.StatusReason,
+        StatusChangedAt: toStatusChangedAt(customer),
     }, nil
 }
 
@@ -310,12 +394,15 @@ func (s *CustomerService) GetCustomerByPhoneNumber(ctx context.Context, req *pb.
     // }
 
     return &pb.GetCustomerByPhoneNumberReply{
-        Id:           customer.ID,
-        Name:         customer.Name,
+        Id:              customer.ID,
+        Name:            customer.Name,
         // PhoneNumbers: phoneNumbers,
-        // Emails:       emails, 
+        // Emails:       emails,
         // Addresses:    addresses,
-        DateOfBirth:  customer.DateOfBirth,
+        DateOfBirth:     customer.DateOfBirth,
+        Status:          pb.CustomerStatus(customer.Status),
+        StatusReason:    customer.StatusReason,
+        StatusChangedAt: toStatusChangedAt(customer),
     }, nil
 }
 
@@ -351,3 +438,17 @@ func (s *CustomerService) DeleteEmail(ctx context.Context, req *pb.DeleteEmailRe
         Success: true,
     }, nil
 }
+
+func (s *CustomerService) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReq) (*pb.VerifyEmailReply, error) {
+    if err := s.uc.VerifyEmail(ctx, req.CustomerId, req.Email); err != nil {
+        return nil, err
+    }
+    return &pb.VerifyEmailReply{Success: true}, nil
+}
+
+func (s *CustomerService) VerifyPhoneNumber(ctx context.Context, req *pb.VerifyPhoneNumberReq) (*pb.VerifyPhoneNumberReply, error) {
+    if err := s.uc.VerifyPhoneNumber(ctx, req.CustomerId, req.PhoneNumber); err != nil {
+        return nil, err
+    }
+    return &pb.VerifyPhoneNumberReply{Success: true}, nil
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	pb "customer/api/customer/v1"
+)
+
+func (s *CustomerService) ExportCustomerData(ctx context.Context, req *pb.ExportCustomerDataReq) (*pb.ExportCustomerDataReply, error) {
+	e, err := s.uc.ExportCustomerData(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExportCustomerDataReply{Bundle: e.Bundle, Checksum: e.Checksum}, nil
+}
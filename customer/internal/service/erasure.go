@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *CustomerService) EraseCustomer(ctx context.Context, req *pb.EraseCustomerReq) (*pb.EraseCustomerReply, error) {
+	e, err := s.erasures.EraseCustomer(ctx, req.Id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EraseCustomerReply{Erasure: toErasureReply(e)}, nil
+}
+
+func (s *CustomerService) CancelCustomerErasure(ctx context.Context, req *pb.CancelCustomerErasureReq) (*pb.CancelCustomerErasureReply, error) {
+	e, err := s.erasures.CancelCustomerErasure(ctx, req.ErasureId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelCustomerErasureReply{Erasure: toErasureReply(e)}, nil
+}
+
+func toErasureReply(e *biz.Erasure) *pb.CustomerErasure {
+	return &pb.CustomerErasure{
+		Id:          e.ID,
+		CustomerId:  e.CustomerID,
+		Status:      pb.ErasureStatus(e.Status),
+		Reason:      e.Reason,
+		RequestedBy: e.RequestedBy,
+		RequestedAt: timestamppb.New(e.RequestedAt),
+		EraseAfter:  timestamppb.New(e.EraseAfter),
+		CompletedAt: optionalTimestamp(e.CompletedAt),
+		CancelledAt: optionalTimestamp(e.CancelledAt),
+		Hash:        e.Hash,
+	}
+}
+
+// optionalTimestamp leaves unset times out of the reply.
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
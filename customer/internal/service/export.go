@@ -0,0 +1,56 @@
+package service
+
+import (
+	"bufio"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+)
+
+// exportChunkSize is the size of the data chunks ExportCustomers sends.
+const exportChunkSize = 64 * 1024
+
+func (s *CustomerService) ExportCustomers(req *pb.ExportCustomersReq, stream pb.Customer_ExportCustomersServer) error {
+	w := bufio.NewWriterSize(&exportStreamWriter{stream: stream}, exportChunkSize)
+	_, err := s.uc.ExportCustomers(stream.Context(), toBizFilter(req.Filter), biz.ExportOptions{
+		Format:  biz.ExportFormat(req.Format),
+		Exclude: req.ExcludeColumns,
+		Mask:    req.MaskColumns,
+	}, w)
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// exportStreamWriter sends everything written to it as one reply.
+type exportStreamWriter struct {
+	stream pb.Customer_ExportCustomersServer
+}
+
+func (w *exportStreamWriter) Write(p []byte) (int, error) {
+	// the stream may hold on to the message, so send a copy
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&pb.ExportCustomersReply{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func toBizFilter(f *pb.CustomerFilter) *biz.CustomerFilter {
+	if f == nil {
+		return nil
+	}
+	statuses := make([]biz.CustomerStatus, len(f.Statuses))
+	for i, st := range f.Statuses {
+		statuses[i] = biz.CustomerStatus(st)
+	}
+	return &biz.CustomerFilter{
+		IDs:        f.Ids,
+		Name:       f.Name,
+		BornAfter:  f.BornAfter,
+		BornBefore: f.BornBefore,
+		Statuses:   statuses,
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"io"
+
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+)
+
+// importReplyRows is the most rows an ImportCustomers reply reports, which
+// keeps replies far below the message size limit.
+const importReplyRows = 500
+
+func (s *CustomerService) ImportCustomers(stream pb.Customer_ImportCustomersServer) error {
+	var (
+		opts    biz.ImportOptions
+		pending *pb.ImportCustomerRow
+	)
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return stream.Send(&pb.ImportCustomersReply{})
+	}
+	if err != nil {
+		return err
+	}
+	if o := first.GetOptions(); o != nil {
+		opts.DryRun = o.DryRun
+		opts.BatchSize = int(o.BatchSize)
+	} else {
+		pending = first.GetRow()
+	}
+
+	next := func() (*biz.ImportRecord, error) {
+		if pending != nil {
+			row := pending
+			pending = nil
+			return toImportRecord(row), nil
+		}
+		req, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		row := req.GetRow()
+		if row == nil {
+			return nil, errors.New("import options must be sent before the first row")
+		}
+		return toImportRecord(row), nil
+	}
+
+	// rows are reported once their batch is done, and sent on before the
+	// import goes on
+	reply := &pb.ImportCustomersReply{DryRun: opts.DryRun}
+	summary, err := s.uc.ImportCustomers(stream.Context(), opts, next, func(r *biz.ImportResult) error {
+		reply.Results = append(reply.Results, toImportRowResult(r))
+		if len(reply.Results) < importReplyRows {
+			return nil
+		}
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+		reply = &pb.ImportCustomersReply{DryRun: opts.DryRun}
+		return nil
+	})
+	if err != nil {
+		// the rows reported so far are committed, the client needs to
+		// know them before it retries
+		if len(reply.Results) > 0 {
+			_ = stream.Send(reply)
+		}
+		return err
+	}
+
+	reply.Total = summary.Total
+	reply.Created = summary.Created
+	reply.Valid = summary.Valid
+	reply.Failed = summary.Failed
+	return stream.Send(reply)
+}
+
+func toImportRecord(row *pb.ImportCustomerRow) *biz.ImportRecord {
+	return &biz.ImportRecord{
+		Line: row.Line,
+		Customer: biz.Customer{
+			Name:        row.Name,
+			DateOfBirth: row.DateOfBirth,
+		},
+		Emails:       row.Emails,
+		PhoneNumbers: row.PhoneNumbers,
+		Addresses:    row.Addresses,
+	}
+}
+
+func toImportRowResult(r *biz.ImportResult) *pb.ImportRowResult {
+	res := &pb.ImportRowResult{
+		Line:       r.Line,
+		Status:     pb.ImportRowStatus(r.Status),
+		CustomerId: r.CustomerID,
+	}
+	if r.Err != nil {
+		res.Error = r.Err.Error()
+	}
+	return res
+}
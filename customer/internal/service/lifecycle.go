@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	pb "customer/api/customer/v1"
+)
+
+func (s *CustomerService) ActivateCustomer(ctx context.Context, req *pb.ActivateCustomerReq) (*pb.ActivateCustomerReply, error) {
+	c, err := s.uc.ActivateCustomer(ctx, req.Id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ActivateCustomerReply{Customer: toCustomerReply(c)}, nil
+}
+
+func (s *CustomerService) SuspendCustomer(ctx context.Context, req *pb.SuspendCustomerReq) (*pb.SuspendCustomerReply, error) {
+	c, err := s.uc.SuspendCustomer(ctx, req.Id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SuspendCustomerReply{Customer: toCustomerReply(c)}, nil
+}
+
+func (s *CustomerService) CloseCustomer(ctx context.Context, req *pb.CloseCustomerReq) (*pb.CloseCustomerReply, error) {
+	c, err := s.uc.CloseCustomer(ctx, req.Id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CloseCustomerReply{Customer: toCustomerReply(c)}, nil
+}
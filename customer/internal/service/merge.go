@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	pb "customer/api/customer/v1"
+)
+
+func (s *CustomerService) FindDuplicateCustomers(ctx context.Context, req *pb.FindDuplicateCustomersReq) (*pb.FindDuplicateCustomersReply, error) {
+	candidates, err := s.uc.FindDuplicateCustomers(ctx, toBizFilter(req.Filter), req.MinScore, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.FindDuplicateCustomersReply{
+		Candidates: make([]*pb.DuplicateCandidate, 0, len(candidates)),
+	}
+	for _, c := range candidates {
+		reply.Candidates = append(reply.Candidates, &pb.DuplicateCandidate{
+			CustomerId:  c.CustomerID,
+			DuplicateId: c.DuplicateID,
+			Score:       c.Score,
+			Reasons:     c.Reasons,
+		})
+	}
+	return reply, nil
+}
+
+func (s *CustomerService) MergeCustomers(ctx context.Context, req *pb.MergeCustomersReq) (*pb.MergeCustomersReply, error) {
+	c, err := s.uc.MergeCustomers(ctx, req.SurvivorId, req.DuplicateIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.MergeCustomersReply{Customer: toCustomerReply(c)}, nil
+}
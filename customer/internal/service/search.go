@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	pb "customer/api/customer/v1"
+)
+
+func (s *CustomerService) SearchCustomers(ctx context.Context, req *pb.SearchCustomersReq) (*pb.SearchCustomersReply, error) {
+	page, err := s.uc.SearchCustomers(ctx, req.Query, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &pb.SearchCustomersReply{
+		Hits:          make([]*pb.SearchHit, 0, len(page.Hits)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, h := range page.Hits {
+		hit := &pb.SearchHit{
+			Customer: toCustomerReply(h.Customer),
+			Score:    h.Score,
+		}
+		for _, hl := range h.Highlights {
+			hit.Highlights = append(hit.Highlights, &pb.SearchHighlight{
+				Field:       hl.Field,
+				Value:       hl.Value,
+				Highlighted: hl.Highlighted,
+			})
+		}
+		reply.Hits = append(reply.Hits, hit)
+	}
+	return reply, nil
+}
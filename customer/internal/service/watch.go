@@ -0,0 +1,32 @@
+package service
+
+import (
+	pb "customer/api/customer/v1"
+	"customer/internal/biz"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *CustomerService) WatchCustomers(req *pb.WatchCustomersReq, stream pb.Customer_WatchCustomersServer) error {
+	filter := &biz.WatchFilter{
+		CustomerIDs:   req.CustomerIds,
+		AfterSequence: req.AfterSequence,
+	}
+	for _, t := range req.Types {
+		filter.Types = append(filter.Types, biz.ChangeType(t))
+	}
+
+	return s.feed.Watch(stream.Context(), filter, func(c *biz.Change) error {
+		return stream.Send(&pb.WatchCustomersReply{
+			Sequence:   c.Sequence,
+			CustomerId: c.CustomerID,
+			Type:       pb.ChangeType(c.Type),
+			Event: &anypb.Any{
+				TypeUrl: "type.googleapis.com/" + c.Event.Type,
+				Value:   c.Event.Payload,
+			},
+			OccurredAt: timestamppb.New(c.Event.OccurredAt),
+		})
+	})
+}